@@ -0,0 +1,4 @@
+data "clickhousedbops_database" "logs" {
+  cluster_name = "cluster"
+  name         = "logs"
+}
@@ -0,0 +1,8 @@
+data "clickhousedbops_databases" "all" {
+  cluster_name = "cluster"
+}
+
+data "clickhousedbops_databases" "analytics" {
+  cluster_name = "cluster"
+  name_regex   = "^analytics_"
+}
@@ -0,0 +1,9 @@
+data "clickhousedbops_grants" "john" {
+  cluster_name      = "cluster"
+  grantee_user_name = "john"
+  include_inherited = true
+}
+
+output "john_privileges" {
+  value = data.clickhousedbops_grants.john.privileges
+}
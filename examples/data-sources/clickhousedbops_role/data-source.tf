@@ -0,0 +1,4 @@
+data "clickhousedbops_role" "writer" {
+  cluster_name = "cluster"
+  name         = "writer"
+}
@@ -0,0 +1,8 @@
+data "clickhousedbops_roles" "all" {
+  cluster_name = "cluster"
+}
+
+data "clickhousedbops_roles" "readers" {
+  cluster_name = "cluster"
+  name_regex   = "_reader$"
+}
@@ -0,0 +1,4 @@
+data "clickhousedbops_settings_profile" "profile1" {
+  cluster_name = "cluster"
+  name         = "profile1"
+}
@@ -0,0 +1,4 @@
+data "clickhousedbops_user" "john" {
+  cluster_name = "cluster"
+  name         = "john"
+}
@@ -0,0 +1,8 @@
+data "clickhousedbops_users" "all" {
+  cluster_name = "cluster"
+}
+
+data "clickhousedbops_users" "service_accounts" {
+  cluster_name = "cluster"
+  name_regex   = "^svc_"
+}
@@ -0,0 +1,7 @@
+# Functions can be imported by specifying their name.
+
+terraform import clickhousedbops_function.example linear_equation
+
+# IMPORTANT: if you have a multi node cluster, you need to specify the cluster name!
+
+terraform import clickhousedbops_function.example cluster:linear_equation
@@ -0,0 +1,6 @@
+resource "clickhousedbops_function" "linear_equation" {
+  cluster_name = "cluster"
+  name         = "linear_equation"
+  parameters   = ["x", "k", "b"]
+  body         = "k * x + b"
+}
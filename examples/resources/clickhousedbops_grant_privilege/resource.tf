@@ -6,3 +6,9 @@ resource "clickhousedbops_grant_privilege" "grant" {
   grantee_user_name = "my_user_name"
   grant_option      = true
 }
+
+resource "clickhousedbops_grant_privilege" "named_collection" {
+  privilege_name        = "NAMED COLLECTION"
+  named_collection_name = "s3_data"
+  grantee_role_name     = "my_role_name"
+}
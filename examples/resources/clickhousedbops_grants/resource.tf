@@ -0,0 +1,21 @@
+resource "clickhousedbops_grants" "reader" {
+  cluster_name      = "cluster"
+  grantee_role_name = "reader"
+
+  privileges = [
+    {
+      privilege_name = "SELECT"
+      database_name  = "analytics"
+    },
+    {
+      privilege_name = "SHOW TABLES"
+      database_name  = "analytics"
+    },
+    {
+      privilege_name = "SELECT"
+      database_name  = "default"
+      table_name     = "events"
+      grant_option   = true
+    },
+  ]
+}
@@ -0,0 +1,8 @@
+# Named collections can be imported by specifying their name.
+# Values are not shown by ClickHouse, they are set again from the configuration on the next apply.
+
+terraform import clickhousedbops_named_collection.example s3_data
+
+# IMPORTANT: if you have a multi node cluster, you need to specify the cluster name!
+
+terraform import clickhousedbops_named_collection.example cluster:s3_data
@@ -0,0 +1,12 @@
+resource "clickhousedbops_named_collection" "s3_data" {
+  cluster_name = "cluster"
+  name         = "s3_data"
+  values = {
+    url    = "https://my-bucket.s3.amazonaws.com/data/"
+    format = "Parquet"
+  }
+  sensitive_values = {
+    access_key_id     = var.s3_access_key_id
+    secret_access_key = var.s3_secret_access_key
+  }
+}
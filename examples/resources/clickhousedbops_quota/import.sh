@@ -0,0 +1,12 @@
+# Quotas can be imported by specifying the ID.
+# Find the ID of the quota by checking system.quotas table.
+terraform import clickhousedbops_quota.example xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+
+# It's also possible to import quotas by name:
+
+terraform import clickhousedbops_quota.example quotaname
+
+# IMPORTANT: if you have a multi node cluster, you need to specify the cluster name!
+
+terraform import clickhousedbops_quota.example cluster:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+terraform import clickhousedbops_quota.example cluster:quotaname
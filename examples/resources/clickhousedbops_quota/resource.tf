@@ -0,0 +1,22 @@
+resource "clickhousedbops_quota" "limited" {
+  cluster_name = "cluster"
+  name         = "limited"
+  keyed_by     = "user_name"
+
+  intervals = [
+    {
+      duration           = 1
+      unit               = "hour"
+      max_queries        = 1000
+      max_execution_time = 600
+    },
+    {
+      duration       = 1
+      unit           = "day"
+      randomized     = true
+      max_read_bytes = 1000000000000
+    },
+  ]
+
+  apply_to = [clickhousedbops_role.writer.name]
+}
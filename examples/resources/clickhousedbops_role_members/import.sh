@@ -0,0 +1,6 @@
+# Role members can be imported by specifying the name of the role.
+terraform import clickhousedbops_role_members.example rolename
+
+# IMPORTANT: if you have a multi node cluster, you need to specify the cluster name!
+
+terraform import clickhousedbops_role_members.example cluster:rolename
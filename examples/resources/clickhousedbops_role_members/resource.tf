@@ -0,0 +1,6 @@
+resource "clickhousedbops_role_members" "writers" {
+  cluster_name = "cluster"
+  role_name    = "writer"
+  user_names   = ["john", "jane"]
+  role_names   = ["etl"]
+}
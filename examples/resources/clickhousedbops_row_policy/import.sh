@@ -0,0 +1,12 @@
+# Row policies can be imported by specifying the ID.
+# Find the ID of the row policy by checking system.row_policies table.
+terraform import clickhousedbops_row_policy.example xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+
+# It's also possible to import row policies by their full name, as shown in the name column of system.row_policies:
+
+terraform import clickhousedbops_row_policy.example "tenant ON analytics.events"
+
+# IMPORTANT: if you have a multi node cluster, you need to specify the cluster name!
+
+terraform import clickhousedbops_row_policy.example cluster:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+terraform import clickhousedbops_row_policy.example "cluster:tenant ON analytics.events"
@@ -0,0 +1,9 @@
+resource "clickhousedbops_row_policy" "tenant" {
+  cluster_name  = "cluster"
+  name          = "tenant"
+  database_name = "analytics"
+  table_name    = "events"
+  select_filter = "tenant_id = 1"
+  restrictive   = false
+  apply_to      = [clickhousedbops_role.writer.name]
+}
@@ -0,0 +1,7 @@
+# Tables can be imported by specifying their database and name.
+
+terraform import clickhousedbops_table.example logs.events
+
+# IMPORTANT: if you have a multi node cluster, you need to specify the cluster name!
+
+terraform import clickhousedbops_table.example cluster:logs.events
@@ -0,0 +1,34 @@
+resource "clickhousedbops_table" "events" {
+  cluster_name  = "cluster"
+  database_name = "logs"
+  name          = "events"
+
+  columns = [
+    {
+      name = "id"
+      type = "UInt64"
+    },
+    {
+      name    = "ts"
+      type    = "DateTime"
+      default = "now()"
+      codec   = "Delta, ZSTD(1)"
+    },
+    {
+      name    = "message"
+      type    = "String"
+      comment = "Raw log line"
+    },
+  ]
+
+  engine       = "ReplicatedMergeTree('/clickhouse/tables/{shard}/{database}/{table}', '{replica}')"
+  partition_by = "toYYYYMM(ts)"
+  order_by     = "(id, ts)"
+  ttl          = "ts + INTERVAL 1 MONTH DELETE"
+
+  settings = {
+    index_granularity = "8192"
+  }
+
+  comment = "Application events"
+}
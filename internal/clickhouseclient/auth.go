@@ -1,5 +1,11 @@
 package clickhouseclient
 
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
 type UserPasswordAuth struct {
 	Username string
 	Password string
@@ -28,3 +34,57 @@ func (b *BasicAuth) ValidateConfig() (bool, []string) {
 
 	return len(errors) == 0, errors
 }
+
+// CertificateAuth authenticates using the TLS client certificate, for users identified with ssl_certificate.
+// The client certificate itself must be set in the TLS configuration.
+type CertificateAuth struct {
+	Username string
+	Database string
+}
+
+func (c *CertificateAuth) ValidateConfig() (bool, []string) {
+	errors := make([]string, 0)
+	if c.Username == "" {
+		errors = append(errors, "Username must be set")
+	}
+
+	return len(errors) == 0, errors
+}
+
+// TokenAuth authenticates sending a bearer token in the Authorization header.
+// When TokenFile is set, the file is read on every request so that short-lived tokens can be rotated on disk.
+type TokenAuth struct {
+	Token     string
+	TokenFile string
+}
+
+func (t *TokenAuth) ValidateConfig() (bool, []string) {
+	errors := make([]string, 0)
+	if t.Token == "" && t.TokenFile == "" {
+		errors = append(errors, "Either Token or TokenFile must be set")
+	}
+	if t.Token != "" && t.TokenFile != "" {
+		errors = append(errors, "Token and TokenFile cannot be both set")
+	}
+
+	return len(errors) == 0, errors
+}
+
+// GetToken returns the token to be sent to the server.
+func (t *TokenAuth) GetToken() (string, error) {
+	if t.TokenFile == "" {
+		return t.Token, nil
+	}
+
+	data, err := os.ReadFile(t.TokenFile)
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %q is empty", t.TokenFile)
+	}
+
+	return token, nil
+}
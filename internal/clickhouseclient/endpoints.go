@@ -0,0 +1,103 @@
+package clickhouseclient
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+)
+
+// HostSelectionStrategy controls the order in which endpoints are tried when more than one is configured.
+type HostSelectionStrategy string
+
+const (
+	// HostSelectionInOrder always tries the endpoints in the configured order, so the first healthy one is used.
+	HostSelectionInOrder HostSelectionStrategy = "in_order"
+	// HostSelectionRandom starts from a random endpoint for each connection.
+	HostSelectionRandom HostSelectionStrategy = "random"
+	// HostSelectionRoundRobin rotates the starting endpoint for each connection.
+	HostSelectionRoundRobin HostSelectionStrategy = "round_robin"
+)
+
+var AvailableHostSelectionStrategies = []HostSelectionStrategy{HostSelectionInOrder, HostSelectionRandom, HostSelectionRoundRobin}
+
+type Endpoint struct {
+	Host string
+	Port uint16
+}
+
+func (e Endpoint) String() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(int(e.Port)))
+}
+
+// resolveEndpoints returns the list of endpoints to use from the Host/Port pair or the Endpoints list of a client config.
+func resolveEndpoints(host string, port uint16, endpoints []Endpoint) ([]Endpoint, error) {
+	if len(endpoints) == 0 {
+		if host == "" {
+			return nil, errors.New("Host is required")
+		}
+		if port == 0 {
+			return nil, errors.New("Port is required")
+		}
+
+		return []Endpoint{{Host: host, Port: port}}, nil
+	}
+
+	if host != "" {
+		return nil, errors.New("Host and Endpoints cannot be both set")
+	}
+
+	for _, e := range endpoints {
+		if e.Host == "" {
+			return nil, errors.New("Host is required for every endpoint")
+		}
+		if e.Port == 0 {
+			return nil, errors.New(fmt.Sprintf("Port is required for endpoint %q", e.Host))
+		}
+	}
+
+	return endpoints, nil
+}
+
+// endpointSelector returns the order in which endpoints should be tried according to a HostSelectionStrategy.
+type endpointSelector struct {
+	endpoints []Endpoint
+	strategy  HostSelectionStrategy
+	next      atomic.Uint64
+}
+
+func newEndpointSelector(endpoints []Endpoint, strategy HostSelectionStrategy) (*endpointSelector, error) {
+	switch strategy {
+	case "":
+		strategy = HostSelectionInOrder
+	case HostSelectionInOrder, HostSelectionRandom, HostSelectionRoundRobin:
+	default:
+		return nil, errors.New(fmt.Sprintf("invalid host selection strategy %q", strategy))
+	}
+
+	return &endpointSelector{
+		endpoints: endpoints,
+		strategy:  strategy,
+	}, nil
+}
+
+// Order returns all the endpoints, starting from the one that should be tried first.
+func (s *endpointSelector) Order() []Endpoint {
+	start := 0
+	switch s.strategy {
+	case HostSelectionRandom:
+		start = rand.IntN(len(s.endpoints)) //nolint:gosec
+	case HostSelectionRoundRobin:
+		start = int((s.next.Add(1) - 1) % uint64(len(s.endpoints))) //nolint:gosec
+	}
+
+	ret := make([]Endpoint, 0, len(s.endpoints))
+	for i := range s.endpoints {
+		ret = append(ret, s.endpoints[(start+i)%len(s.endpoints)])
+	}
+
+	return ret
+}
@@ -0,0 +1,121 @@
+package clickhouseclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEndpointSelector_Order(t *testing.T) {
+	endpoints := []Endpoint{
+		{Host: "a", Port: 9000},
+		{Host: "b", Port: 9000},
+		{Host: "c", Port: 9000},
+	}
+
+	tests := []struct {
+		name     string
+		strategy HostSelectionStrategy
+		want     [][]Endpoint
+	}{
+		{
+			name:     "Default is in order",
+			strategy: "",
+			want: [][]Endpoint{
+				{endpoints[0], endpoints[1], endpoints[2]},
+				{endpoints[0], endpoints[1], endpoints[2]},
+			},
+		},
+		{
+			name:     "Round robin rotates the first endpoint",
+			strategy: HostSelectionRoundRobin,
+			want: [][]Endpoint{
+				{endpoints[0], endpoints[1], endpoints[2]},
+				{endpoints[1], endpoints[2], endpoints[0]},
+				{endpoints[2], endpoints[0], endpoints[1]},
+				{endpoints[0], endpoints[1], endpoints[2]},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := newEndpointSelector(endpoints, tt.strategy)
+			if err != nil {
+				t.Fatalf("newEndpointSelector() error = %v", err)
+			}
+
+			for i, want := range tt.want {
+				if got := selector.Order(); !reflect.DeepEqual(got, want) {
+					t.Errorf("Order() call %d = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEndpointSelector_Random(t *testing.T) {
+	endpoints := []Endpoint{
+		{Host: "a", Port: 9000},
+		{Host: "b", Port: 9000},
+	}
+
+	selector, err := newEndpointSelector(endpoints, HostSelectionRandom)
+	if err != nil {
+		t.Fatalf("newEndpointSelector() error = %v", err)
+	}
+
+	for range 10 {
+		if got := selector.Order(); len(got) != len(endpoints) || got[0] == got[1] {
+			t.Fatalf("Order() = %v, want every endpoint exactly once", got)
+		}
+	}
+}
+
+func TestResolveEndpoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      string
+		port      uint16
+		endpoints []Endpoint
+		want      []Endpoint
+		wantErr   bool
+	}{
+		{
+			name: "Host and port",
+			host: "localhost",
+			port: 9000,
+			want: []Endpoint{{Host: "localhost", Port: 9000}},
+		},
+		{
+			name:      "Endpoints",
+			endpoints: []Endpoint{{Host: "a", Port: 9000}, {Host: "b", Port: 9001}},
+			want:      []Endpoint{{Host: "a", Port: 9000}, {Host: "b", Port: 9001}},
+		},
+		{
+			name:      "Host and endpoints",
+			host:      "localhost",
+			port:      9000,
+			endpoints: []Endpoint{{Host: "a", Port: 9000}},
+			wantErr:   true,
+		},
+		{
+			name:      "Endpoint without port",
+			endpoints: []Endpoint{{Host: "a"}},
+			wantErr:   true,
+		},
+		{
+			name:    "Nothing set",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveEndpoints(tt.host, tt.port, tt.endpoints)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveEndpoints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveEndpoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,57 +5,95 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/pingcap/errors"
 )
 
 type httpClient struct {
-	client  *http.Client
-	baseUrl url.URL
+	client       *http.Client
+	queryTimeout time.Duration
+	baseUrl      url.URL
+	endpoints    *endpointSelector
+	headers      http.Header
+	tokenAuth    *TokenAuth
+	logResults   bool
 }
 
 type HTTPClientConfig struct {
-	Protocol  string
-	Host      string
-	Port      uint16
-	BasicAuth *BasicAuth
-	TLSConfig *tls.Config
+	Protocol string
+	Host     string
+	Port     uint16
+	// Endpoints lists the servers to connect to, as an alternative to Host and Port.
+	// When a server can't be reached, the query is sent to the next one according to HostSelectionStrategy.
+	Endpoints             []Endpoint
+	HostSelectionStrategy HostSelectionStrategy
+	BasicAuth             *BasicAuth
+	CertificateAuth       *CertificateAuth
+	TokenAuth             *TokenAuth
+	TLSConfig             *tls.Config
+	// QueryTimeout bounds the time each query can take, including reading the response. Zero means no timeout.
+	QueryTimeout time.Duration
+	// ConnectTimeout bounds the time to establish a connection to a server. Defaults to 30 seconds.
+	ConnectTimeout time.Duration
+	// Settings are ClickHouse settings sent as URL parameters along with every query.
+	Settings map[string]string
+	// LogResults enables logging the results of queries at debug level.
+	LogResults bool
 }
 
 func NewHTTPClient(config HTTPClientConfig) (ClickhouseClient, error) {
-	if config.Host == "" {
-		return nil, errors.New("Host is required")
+	endpoints, err := resolveEndpoints(config.Host, config.Port, config.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := newEndpointSelector(endpoints, config.HostSelectionStrategy)
+	if err != nil {
+		return nil, err
 	}
-	if config.Port == 0 {
-		return nil, errors.New("Port is required")
+	authMethods := 0
+	for _, set := range []bool{config.BasicAuth != nil, config.CertificateAuth != nil, config.TokenAuth != nil} {
+		if set {
+			authMethods++
+		}
 	}
-	if config.BasicAuth == nil {
+	if authMethods != 1 {
 		return nil, errors.New("Exactly one authentication method is required")
 	}
+	if config.CertificateAuth != nil && (config.TLSConfig == nil || len(config.TLSConfig.Certificates) == 0) {
+		return nil, errors.New("Certificate authentication requires a TLS client certificate")
+	}
 	protocol := "http"
 	if config.Protocol != "" {
 		protocol = config.Protocol
 	}
 
-	urlStr := fmt.Sprintf("%s://%s", protocol, config.Host)
-
-	if config.Port != 0 {
-		urlStr = fmt.Sprintf("%s:%d", urlStr, config.Port)
-	}
-
-	baseUrl, err := url.Parse(urlStr)
+	// The host part of the URL is set for each request from the selected endpoint.
+	baseUrl, err := url.Parse(fmt.Sprintf("%s://%s", protocol, endpoints[0].String()))
 	if err != nil {
 		return nil, errors.WithMessage(err, "cannot parse URL")
 	}
 
 	baseUrl.Path = "/"
 
+	if len(config.Settings) > 0 {
+		params := url.Values{}
+		for name, value := range config.Settings {
+			params.Set(name, value)
+		}
+		baseUrl.RawQuery = params.Encode()
+	}
+
 	if config.BasicAuth != nil {
 		if config.BasicAuth.Password == "" {
 			baseUrl.User = url.User(config.BasicAuth.Username)
@@ -64,10 +102,33 @@ func NewHTTPClient(config HTTPClientConfig) (ClickhouseClient, error) {
 		}
 	}
 
+	headers := http.Header{}
+
+	if config.CertificateAuth != nil {
+		// See https://clickhouse.com/docs/guides/sre/ssl-user-auth
+		headers.Set("X-ClickHouse-User", config.CertificateAuth.Username)
+		headers.Set("X-ClickHouse-SSL-Certificate-Auth", "on")
+
+		if config.CertificateAuth.Database != "" {
+			headers.Set("X-ClickHouse-Database", config.CertificateAuth.Database)
+		}
+	}
+
+	connectTimeout := 30 * time.Second
+	if config.ConnectTimeout > 0 {
+		connectTimeout = config.ConnectTimeout
+	}
+
 	return &httpClient{
-		baseUrl: *baseUrl,
+		baseUrl:      *baseUrl,
+		endpoints:    selector,
+		headers:      headers,
+		tokenAuth:    config.TokenAuth,
+		queryTimeout: config.QueryTimeout,
+		logResults:   config.LogResults,
 		client: &http.Client{
 			Transport: &http.Transport{
+				DialContext:     (&net.Dialer{Timeout: connectTimeout}).DialContext,
 				TLSClientConfig: config.TLSConfig,
 			},
 		},
@@ -75,72 +136,215 @@ func NewHTTPClient(config HTTPClientConfig) (ClickhouseClient, error) {
 }
 
 func (i *httpClient) Select(ctx context.Context, qry string, callback func(Row) error) error {
-	body, err := i.runQuery(ctx, qry)
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	// Rows are decoded while they are read, so that large results don't need to be held in memory.
+	resp, err := i.runQuery(ctx, qry, nil, formatSelect)
 	if err != nil {
 		return errors.WithMessage(err, "error running query")
 	}
+	defer resp.Body.Close()
 
-	parsed := jsonCompatStrings{}
-
-	err = json.Unmarshal([]byte(body), &parsed)
-	if err != nil {
-		return errors.WithMessage(err, "error parsing response")
+	var body io.Reader = resp.Body
+	if i.logResults {
+		var result bytes.Buffer
+		body = io.TeeReader(resp.Body, &result)
+		defer func() {
+			tflog.Debug(ctx, "Query result", map[string]any{"QueryResult": result.String()})
+		}()
 	}
 
-	for _, row := range parsed.Rows() {
+	stream := newRowStream(body)
+	for {
+		row, err := stream.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.WithMessage(streamError(resp, stream, err), "error parsing response")
+		}
+
 		err = callback(row)
 		if err != nil {
 			return errors.WithMessage(err, "error calling callback function")
 		}
 	}
-
-	return nil
 }
 
 func (i *httpClient) Exec(ctx context.Context, qry string) error {
-	_, err := i.runQuery(ctx, qry)
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	var settings map[string]string
+	if isOnCluster(ctx) {
+		settings = distributedDDLSettings
+	}
+
+	resp, err := i.runQuery(ctx, qry, settings, formatExec)
 	if err != nil {
 		return errors.WithMessage(err, "error running query")
 	}
+	defer resp.Body.Close()
 
-	return nil
-}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.WithMessage(err, "error reading response")
+	}
+
+	if i.logResults {
+		tflog.Debug(ctx, "Query result", map[string]any{"QueryResult": string(body)})
+	}
 
-func (i *httpClient) runQuery(ctx context.Context, qry string) (string, error) {
-	ctx = tflog.SetField(ctx, "Query", qry)
+	if settings == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
 
-	req, err := http.NewRequest(http.MethodPost, i.baseUrl.String(), strings.NewReader(qry))
+	parsed := hostStatusResult{}
+	err = json.Unmarshal(body, &parsed)
 	if err != nil {
-		return "", errors.WithMessage(err, "error preparing HTTP request")
+		return errors.WithMessage(err, "error parsing ON CLUSTER query status")
 	}
 
-	req.Header.Add("X-ClickHouse-Format", "JSONCompactStrings")
+	return parsed.check()
+}
 
-	resp, err := i.client.Do(req)
+// Output formats requested to the server. Statements run with Exec only return small results, like the status of each
+// host for ON CLUSTER queries, that are read at once.
+const (
+	formatSelect = "JSONCompactStringsEachRowWithNamesAndTypes"
+	formatExec   = "JSONCompactStrings"
+)
+
+// runQuery sends qry to the first reachable endpoint, along with settings on top of the ones of the client, asking for
+// results in format. The caller must close the body of the returned response.
+func (i *httpClient) runQuery(ctx context.Context, qry string, settings map[string]string, format string) (*http.Response, error) {
+	queryID := newQueryID()
+	ctx = tflog.SetField(ctx, "Query", RedactQuery(qry))
+	ctx = tflog.SetField(ctx, "QueryID", queryID)
+
+	var resp *http.Response
+	var err error
+	for _, endpoint := range i.endpoints.Order() {
+		resp, err = i.do(ctx, endpoint, queryID, qry, settings, format)
+		if err == nil {
+			break
+		}
+
+		if ctx.Err() != nil {
+			return nil, errors.WithMessage(err, "error executing query")
+		}
+
+		tflog.Warn(ctx, "ClickHouse endpoint unreachable, trying next one", map[string]any{"Endpoint": endpoint.String(), "error": err.Error()})
+	}
 	if err != nil {
-		return "", errors.WithMessage(err, "error executing query")
+		return nil, errors.WithMessage(err, "error executing query on all endpoints")
 	}
 
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error reading response")
+		}
+
+		return nil, &ServerError{
+			Code:       exceptionCode(resp.Header, body),
+			StatusCode: resp.StatusCode,
+			Message:    string(body),
+		}
+	}
+
+	tflog.Debug(ctx, "Run Query")
+
+	return resp, nil
+}
+
+// maxExceptionSize bounds how much of the response is read to report an error happening while results are streamed.
+const maxExceptionSize = 64 * 1024
+
+// streamError returns the error to report when decoding a streamed response fails. Once the server started sending
+// rows with a 200 status code, errors are appended to the response as text, so they are returned as a ServerError.
+func streamError(resp *http.Response, stream *rowStream, err error) error {
+	var serverError *ServerError
+	if stderrors.As(err, &serverError) {
+		return err
+	}
+
+	rest, _ := io.ReadAll(io.LimitReader(io.MultiReader(stream.Buffered(), resp.Body), maxExceptionSize))
+	code := exceptionCode(resp.Header, rest)
+	if code == 0 {
+		return err
+	}
+
+	return &ServerError{
+		Code:       code,
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(rest)),
+	}
+}
+
+// do sends the query to a single endpoint. Only transport errors are returned, responses with any status code are
+// returned to the caller as they come from a reachable server.
+func (i *httpClient) do(ctx context.Context, endpoint Endpoint, queryID string, qry string, settings map[string]string, format string) (*http.Response, error) {
+	u := i.baseUrl
+	u.Host = endpoint.String()
+
+	params := u.Query()
+	for name, value := range settings {
+		params.Set(name, value)
+	}
+	params.Set("query_id", queryID)
+	params.Set("log_comment", logComment(ctx))
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(qry))
 	if err != nil {
-		return "", errors.WithMessage(err, "error reading response")
+		return nil, errors.WithMessage(err, "error preparing HTTP request")
 	}
 
-	var buf bytes.Buffer
-	err = json.Indent(&buf, body, "", "  ")
-	if err == nil {
-		// Best effort, if we fail parsing we leave the body as-is, otherwise we use the formatted version.
-		body = buf.Bytes()
+	for name, values := range i.headers {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
 	}
 
-	ctx = tflog.SetField(ctx, "QueryResult", string(body))
+	if i.tokenAuth != nil {
+		token, err := i.tokenAuth.GetToken()
+		if err != nil {
+			return nil, errors.WithMessage(err, "error reading authentication token")
+		}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", errors.New(string(body))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	}
 
-	tflog.Debug(ctx, "Run Query")
+	req.Header.Add("X-ClickHouse-Format", format)
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error connecting to %s", endpoint.String()))
+	}
+
+	return resp, nil
+}
+
+var exceptionCodeRegexp = regexp.MustCompile(`Code: (\d+)\.`)
+
+// exceptionCode returns the ClickHouse error code of a failed query, or 0 if the response doesn't come from ClickHouse.
+func exceptionCode(header http.Header, body []byte) int32 {
+	value := header.Get("X-ClickHouse-Exception-Code")
+	if value == "" {
+		matches := exceptionCodeRegexp.FindSubmatch(body)
+		if matches == nil {
+			return 0
+		}
+		value = string(matches[1])
+	}
+
+	code, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0
+	}
 
-	return string(body), nil
+	return int32(code)
 }
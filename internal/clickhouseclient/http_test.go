@@ -0,0 +1,420 @@
+package clickhouseclient
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+func TestNewHTTPClient_Auth(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	err = os.WriteFile(tokenFile, []byte("token-from-file\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(serverURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		config      HTTPClientConfig
+		wantErr     bool
+		wantHeaders map[string]string
+	}{
+		{
+			name: "Certificate auth sends user and certificate auth headers",
+			config: HTTPClientConfig{
+				Host:            serverURL.Hostname(),
+				Port:            uint16(port), //nolint:gosec
+				CertificateAuth: &CertificateAuth{Username: "automation"},
+				TLSConfig:       &tls.Config{Certificates: []tls.Certificate{cert}}, //nolint:gosec
+			},
+			wantHeaders: map[string]string{
+				"X-Clickhouse-User":                 "automation",
+				"X-Clickhouse-Ssl-Certificate-Auth": "on",
+			},
+		},
+		{
+			name: "Token auth sends bearer token",
+			config: HTTPClientConfig{
+				Host:      serverURL.Hostname(),
+				Port:      uint16(port), //nolint:gosec
+				TokenAuth: &TokenAuth{Token: "secret"},
+			},
+			wantHeaders: map[string]string{
+				"Authorization": "Bearer secret",
+			},
+		},
+		{
+			name: "Token auth reads bearer token from file",
+			config: HTTPClientConfig{
+				Host:      serverURL.Hostname(),
+				Port:      uint16(port), //nolint:gosec
+				TokenAuth: &TokenAuth{TokenFile: tokenFile},
+			},
+			wantHeaders: map[string]string{
+				"Authorization": "Bearer token-from-file",
+			},
+		},
+		{
+			name: "Both basic auth and token auth",
+			config: HTTPClientConfig{
+				Host:      serverURL.Hostname(),
+				Port:      uint16(port), //nolint:gosec
+				BasicAuth: &BasicAuth{Username: "default"},
+				TokenAuth: &TokenAuth{Token: "secret"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Certificate auth without client certificate",
+			config: HTTPClientConfig{
+				Host:            serverURL.Hostname(),
+				Port:            uint16(port), //nolint:gosec
+				CertificateAuth: &CertificateAuth{Username: "automation"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Both basic auth and certificate auth",
+			config: HTTPClientConfig{
+				Host:            serverURL.Hostname(),
+				Port:            uint16(port), //nolint:gosec
+				BasicAuth:       &BasicAuth{Username: "default"},
+				CertificateAuth: &CertificateAuth{Username: "automation"},
+				TLSConfig:       &tls.Config{Certificates: []tls.Certificate{cert}}, //nolint:gosec
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewHTTPClient(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewHTTPClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			got = nil
+			err = client.Exec(context.Background(), "SELECT 1")
+			if err != nil {
+				t.Fatalf("Exec() error = %v", err)
+			}
+
+			for name, want := range tt.wantHeaders {
+				if got.Get(name) != want {
+					t.Errorf("header %q = %q, want %q", name, got.Get(name), want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_Failover(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(serverURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reserve a port and close the listener so that connections to it are refused.
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL, err := url.Parse(down.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	downPort, err := strconv.Atoi(downURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	down.Close()
+
+	tests := []struct {
+		name      string
+		endpoints []Endpoint
+		wantErr   bool
+	}{
+		{
+			name: "First endpoint down",
+			endpoints: []Endpoint{
+				{Host: downURL.Hostname(), Port: uint16(downPort)}, //nolint:gosec
+				{Host: serverURL.Hostname(), Port: uint16(port)},   //nolint:gosec
+			},
+		},
+		{
+			name: "All endpoints down",
+			endpoints: []Endpoint{
+				{Host: downURL.Hostname(), Port: uint16(downPort)}, //nolint:gosec
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewHTTPClient(HTTPClientConfig{
+				Endpoints: tt.endpoints,
+				BasicAuth: &BasicAuth{Username: "default"},
+			})
+			if err != nil {
+				t.Fatalf("NewHTTPClient() error = %v", err)
+			}
+
+			hits = 0
+			err = client.Exec(context.Background(), "SELECT 1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && hits != 1 {
+				t.Errorf("server hits = %d, want 1", hits)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_QueryOptions(t *testing.T) {
+	var got url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		if r.URL.Query().Get("hang") != "" {
+			// The body must be consumed for the server to notice the client going away.
+			_, _ = io.ReadAll(r.Body)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(serverURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		settings   map[string]string
+		wantParams map[string]string
+		wantErr    bool
+	}{
+		{
+			name: "Settings are sent as URL parameters",
+			settings: map[string]string{
+				"distributed_ddl_task_timeout": "300",
+				"max_execution_time":           "60",
+			},
+			wantParams: map[string]string{
+				"distributed_ddl_task_timeout": "300",
+				"max_execution_time":           "60",
+			},
+		},
+		{
+			name:     "Query timeout",
+			settings: map[string]string{"hang": "1"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewHTTPClient(HTTPClientConfig{
+				Host:         serverURL.Hostname(),
+				Port:         uint16(port), //nolint:gosec
+				BasicAuth:    &BasicAuth{Username: "default"},
+				QueryTimeout: 100 * time.Millisecond,
+				Settings:     tt.settings,
+			})
+			if err != nil {
+				t.Fatalf("NewHTTPClient() error = %v", err)
+			}
+
+			err = client.Exec(context.Background(), "SELECT 1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			for name, want := range tt.wantParams {
+				if got.Get(name) != want {
+					t.Errorf("parameter %q = %q, want %q", name, got.Get(name), want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_ExecOnCluster(t *testing.T) {
+	var outputMode string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outputMode = r.URL.Query().Get("distributed_ddl_output_mode")
+		w.WriteHeader(http.StatusOK)
+
+		if outputMode == "" {
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"meta": [{"name": "host", "type": "String"}, {"name": "port", "type": "UInt16"}, {"name": "status", "type": "Nullable(Int64)"}, {"name": "error", "type": "Nullable(String)"}, {"name": "num_hosts_remaining", "type": "UInt64"}, {"name": "num_hosts_active", "type": "UInt64"}],
+			"data": [["ch-1", "9000", "0", "", "1", "0"], ["ch-2", "9000", "511", "Code: 511. DB::Exception: Role not found.", "0", "0"]]
+		}`))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(serverURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewHTTPClient(HTTPClientConfig{
+		Host:      serverURL.Hostname(),
+		Port:      uint16(port), //nolint:gosec
+		BasicAuth: &BasicAuth{Username: "default"},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	err = client.Exec(context.Background(), "GRANT `reader` TO `john`;")
+	if err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	if outputMode != "" {
+		t.Errorf("distributed_ddl_output_mode = %q, want it unset", outputMode)
+	}
+
+	clusterName := "cluster1"
+	err = client.Exec(WithCluster(context.Background(), &clusterName), "GRANT ON CLUSTER 'cluster1' `reader` TO `john`;")
+	if err == nil || !strings.Contains(err.Error(), "ch-2:9000") {
+		t.Fatalf("Exec() error = %v, want failure on ch-2:9000", err)
+	}
+	if outputMode != "never_throw" {
+		t.Errorf("distributed_ddl_output_mode = %q, want never_throw", outputMode)
+	}
+}
+
+func TestNewHTTPClient_SelectStream(t *testing.T) {
+	var body string
+	var format string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format = r.Header.Get("X-ClickHouse-Format")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(serverURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		wantRows int
+		wantCode int32
+	}{
+		{
+			name:     "Rows are passed to the callback",
+			body:     "[\"name\"]\n[\"String\"]\n[\"john\"]\n[\"frank\"]\n",
+			wantRows: 2,
+		},
+		{
+			name:     "Exception while streaming rows",
+			body:     "[\"name\"]\n[\"String\"]\n[\"john\"]\nCode: 241. DB::Exception: Memory limit (total) exceeded. (MEMORY_LIMIT_EXCEEDED)\n",
+			wantRows: 1,
+			wantCode: 241,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewHTTPClient(HTTPClientConfig{
+				Host:      serverURL.Hostname(),
+				Port:      uint16(port), //nolint:gosec
+				BasicAuth: &BasicAuth{Username: "default"},
+			})
+			if err != nil {
+				t.Fatalf("NewHTTPClient() error = %v", err)
+			}
+
+			body = tt.body
+			rows := 0
+			err = client.Select(context.Background(), "SELECT name FROM system.users", func(row Row) error {
+				rows++
+				return nil
+			})
+			if format != "JSONCompactStringsEachRowWithNamesAndTypes" {
+				t.Errorf("X-ClickHouse-Format = %q, want JSONCompactStringsEachRowWithNamesAndTypes", format)
+			}
+			if rows != tt.wantRows {
+				t.Errorf("Select() rows = %d, want %d", rows, tt.wantRows)
+			}
+
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("Select() error = %v", err)
+				}
+				return
+			}
+
+			serverError, ok := errors.Cause(err).(*ServerError)
+			if !ok || serverError.Code != tt.wantCode {
+				t.Fatalf("Select() error = %v, want a ServerError with code %d", err, tt.wantCode)
+			}
+		})
+	}
+}
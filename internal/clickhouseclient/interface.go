@@ -2,9 +2,19 @@ package clickhouseclient
 
 import (
 	"context"
+	"time"
 )
 
 type ClickhouseClient interface {
 	Select(ctx context.Context, qry string, callback func(Row) error) error
 	Exec(ctx context.Context, qry string) error
 }
+
+// withQueryTimeout returns a context bounded by timeout, or ctx itself if timeout is zero.
+func withQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
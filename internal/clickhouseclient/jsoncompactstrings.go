@@ -1,73 +1,417 @@
 package clickhouseclient
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
 )
 
 const nullString = "ᴺᵁᴸᴸ"
 
-// jsonCompatStrings is used to parse clickhouse query output when using 'jsonCompatStrings' format.
-type jsonCompatStrings struct {
-	Meta []struct {
-		Name string
-		Type string
-	} `json:"meta"`
-	Data [][]string `json:"data"`
-}
-
-func (j jsonCompatStrings) Rows() []Row {
-	ret := make([]Row, 0)
-
-	// Extract slice of column names and column data types in the result.
-	colNames := make([]string, 0)
-	colTypes := make([]string, 0)
-	for _, entry := range j.Meta {
-		colNames = append(colNames, entry.Name)
-		colTypes = append(colTypes, entry.Type)
-	}
-
-	// Create a slice of Rows associating the value to each column name.
-	for _, row := range j.Data {
-		data := Row{}
-
-		for i, field := range row {
-			switch colTypes[i] {
-			case "String":
-				data.Set(colNames[i], field)
-			case "Nullable(String)":
-				if field == nullString {
-					data.Set(colNames[i], nilPtr[string]())
-				} else {
-					data.Set(colNames[i], &field)
-				}
-			case "UInt8":
-				val, err := strconv.ParseUint(field, 10, 8)
-				if err != nil {
-					// Failed parsing as number, return value as-is.
-					data.Set(colNames[i], field)
-					break
-				} else {
-					data.Set(colNames[i], uint8(val))
-				}
-			case "UInt64":
-				val, err := strconv.ParseUint(field, 10, 64)
+// rowStream decodes rows one at a time from a response in JSONCompactStringsEachRowWithNamesAndTypes format: a JSON
+// array with the column names, one with the column types, then one array per row with values in their text
+// representation.
+type rowStream struct {
+	decoder  *json.Decoder
+	names    []string
+	decoders []*columnDecoder
+}
+
+func newRowStream(r io.Reader) *rowStream {
+	return &rowStream{
+		decoder: json.NewDecoder(r),
+	}
+}
+
+// Next returns the next row, or io.EOF when all of them were read.
+// Values are decoded according to the type of their column, using the same Go types as the native client: see
+// newColumnDecoder for the supported types.
+func (s *rowStream) Next() (Row, error) {
+	if s.decoders == nil {
+		err := s.readHeader()
+		if err != nil {
+			return Row{}, err
+		}
+	}
+
+	values, err := s.readLine()
+	if err != nil {
+		return Row{}, err
+	}
+
+	if len(values) != len(s.decoders) {
+		return Row{}, errors.New(fmt.Sprintf("row has %d values, expected %d", len(values), len(s.decoders)))
+	}
+
+	row := Row{}
+	for i, value := range values {
+		if value != nil && *value == nullString {
+			value = nil
+		}
+
+		val, err := s.decoders[i].decode(value)
+		if err != nil {
+			return Row{}, errors.WithMessage(err, fmt.Sprintf("cannot decode value of column %q", s.names[i]))
+		}
+
+		row.Set(s.names[i], val)
+	}
+
+	return row, nil
+}
+
+// Buffered returns the data read from the response but not decoded yet.
+func (s *rowStream) Buffered() io.Reader {
+	return s.decoder.Buffered()
+}
+
+func (s *rowStream) readHeader() error {
+	names, err := s.readLine()
+	if err != nil {
+		// An empty response has no rows.
+		return err
+	}
+
+	types, err := s.readLine()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(names) != len(types) {
+		return errors.New(fmt.Sprintf("got %d column names and %d column types", len(names), len(types)))
+	}
+
+	decoders := make([]*columnDecoder, 0, len(types))
+	for i := range types {
+		if names[i] == nil || types[i] == nil {
+			return errors.New("invalid NULL column name or type")
+		}
+
+		decoder, err := newColumnDecoder(*types[i])
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("cannot decode column %q", *names[i]))
+		}
+		decoders = append(decoders, decoder)
+		s.names = append(s.names, *names[i])
+	}
+	s.decoders = decoders
+
+	return nil
+}
+
+// readLine decodes the next array of values. Errors reported by the server in the middle of the output, as a
+// {"exception": "..."} object, are returned as a ServerError.
+func (s *rowStream) readLine() ([]*string, error) {
+	var raw json.RawMessage
+	err := s.decoder.Decode(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		var exception struct {
+			Exception string `json:"exception"`
+		}
+		if json.Unmarshal(raw, &exception) == nil && exception.Exception != "" {
+			return nil, &ServerError{
+				Code:       exceptionCode(http.Header{}, []byte(exception.Exception)),
+				StatusCode: http.StatusOK,
+				Message:    exception.Exception,
+			}
+		}
+	}
+
+	var values []*string
+	err = json.Unmarshal(raw, &values)
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// columnDecoder converts values of a ClickHouse type from their text representation to a Go value of type typ.
+type columnDecoder struct {
+	typ reflect.Type
+	// decode receives nil for NULL values.
+	decode func(value *string) (any, error)
+}
+
+// newColumnDecoder returns a decoder for chType. The supported types, and the Go types they are decoded to, are:
+//   - String, FixedString, UUID, Enum8, Enum16, IPv4 and IPv6 as string;
+//   - Bool as bool;
+//   - UInt8 to UInt64 and Int8 to Int64 as the Go integer type of the same size;
+//   - Float32 and Float64 as float64;
+//   - Date, Date32, DateTime and DateTime64 as time.Time, in the time zone of the column or UTC;
+//   - Nullable(T) as a pointer to the type of T;
+//   - Array(T) as a slice of the type of T;
+//   - LowCardinality(T) as T.
+func newColumnDecoder(chType string) (*columnDecoder, error) {
+	chType = strings.TrimSpace(chType)
+
+	if inner, ok := typeArgs(chType, "LowCardinality"); ok {
+		return newColumnDecoder(inner)
+	}
+
+	if inner, ok := typeArgs(chType, "Nullable"); ok {
+		elem, err := newColumnDecoder(inner)
+		if err != nil {
+			return nil, err
+		}
+		return nullableDecoder(elem), nil
+	}
+
+	if inner, ok := typeArgs(chType, "Array"); ok {
+		elem, err := newColumnDecoder(inner)
+		if err != nil {
+			return nil, err
+		}
+		return arrayDecoder(elem), nil
+	}
+
+	switch name, args, _ := strings.Cut(chType, "("); name {
+	case "String", "FixedString", "UUID", "Enum8", "Enum16", "IPv4", "IPv6":
+		return scalarDecoder(func(s string) (string, error) { return s, nil }), nil
+	case "Bool":
+		return scalarDecoder(strconv.ParseBool), nil
+	case "UInt8":
+		return scalarDecoder(parseUint[uint8](8)), nil
+	case "UInt16":
+		return scalarDecoder(parseUint[uint16](16)), nil
+	case "UInt32":
+		return scalarDecoder(parseUint[uint32](32)), nil
+	case "UInt64":
+		return scalarDecoder(parseUint[uint64](64)), nil
+	case "Int8":
+		return scalarDecoder(parseInt[int8](8)), nil
+	case "Int16":
+		return scalarDecoder(parseInt[int16](16)), nil
+	case "Int32":
+		return scalarDecoder(parseInt[int32](32)), nil
+	case "Int64":
+		return scalarDecoder(parseInt[int64](64)), nil
+	case "Float32", "Float64":
+		return scalarDecoder(func(s string) (float64, error) { return strconv.ParseFloat(s, 64) }), nil
+	case "Date", "Date32", "DateTime", "DateTime64":
+		location, err := timeZone(args)
+		if err != nil {
+			return nil, err
+		}
+		return scalarDecoder(func(s string) (time.Time, error) {
+			return time.ParseInLocation(dateTimeLayout(s), s, location)
+		}), nil
+	}
+
+	return nil, errors.New(fmt.Sprintf("unsupported data type %q", chType))
+}
+
+// typeArgs returns the arguments of chType if it is the parametric type name, like Nullable(String).
+func typeArgs(chType string, name string) (string, bool) {
+	if !strings.HasPrefix(chType, name+"(") || !strings.HasSuffix(chType, ")") {
+		return "", false
+	}
+
+	return chType[len(name)+1 : len(chType)-1], true
+}
+
+func scalarDecoder[T any](parse func(string) (T, error)) *columnDecoder {
+	var zero T
+	return &columnDecoder{
+		typ: reflect.TypeOf(zero),
+		decode: func(value *string) (any, error) {
+			if value == nil {
+				return nil, errors.New("unexpected NULL value in non nullable column")
+			}
+			return parse(*value)
+		},
+	}
+}
+
+func nullableDecoder(elem *columnDecoder) *columnDecoder {
+	typ := reflect.PointerTo(elem.typ)
+	return &columnDecoder{
+		typ: typ,
+		decode: func(value *string) (any, error) {
+			if value == nil {
+				return reflect.Zero(typ).Interface(), nil
+			}
+
+			val, err := elem.decode(value)
+			if err != nil {
+				return nil, err
+			}
+
+			ptr := reflect.New(elem.typ)
+			ptr.Elem().Set(reflect.ValueOf(val))
+
+			return ptr.Interface(), nil
+		},
+	}
+}
+
+func arrayDecoder(elem *columnDecoder) *columnDecoder {
+	typ := reflect.SliceOf(elem.typ)
+	return &columnDecoder{
+		typ: typ,
+		decode: func(value *string) (any, error) {
+			if value == nil {
+				return nil, errors.New("unexpected NULL value in non nullable column")
+			}
+
+			items, err := parseArray(*value)
+			if err != nil {
+				return nil, err
+			}
+
+			ret := reflect.MakeSlice(typ, 0, len(items))
+			for _, item := range items {
+				val, err := elem.decode(item)
 				if err != nil {
-					// Failed parsing as number, return value as-is.
-					data.Set(colNames[i], field)
-					break
-				} else {
-					data.Set(colNames[i], val)
+					return nil, err
 				}
-			default:
-				panic(fmt.Sprintf("unknown data type %q", colTypes[i]))
+				ret = reflect.Append(ret, reflect.ValueOf(val))
+			}
+
+			return ret.Interface(), nil
+		},
+	}
+}
+
+func parseUint[T uint8 | uint16 | uint32 | uint64](bitSize int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		val, err := strconv.ParseUint(s, 10, bitSize)
+		return T(val), err
+	}
+}
+
+func parseInt[T int8 | int16 | int32 | int64](bitSize int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		val, err := strconv.ParseInt(s, 10, bitSize)
+		return T(val), err
+	}
+}
+
+// timeZone returns the location named in the arguments of a DateTime or DateTime64 type, like 3, 'Europe/Rome'.
+// Values without a time zone are assumed to be UTC.
+func timeZone(args string) (*time.Location, error) {
+	args = strings.TrimSuffix(args, ")")
+	for _, arg := range strings.Split(args, ",") {
+		arg = strings.TrimSpace(arg)
+		if strings.HasPrefix(arg, "'") && strings.HasSuffix(arg, "'") && len(arg) > 1 {
+			location, err := time.LoadLocation(arg[1 : len(arg)-1])
+			if err != nil {
+				return nil, errors.WithMessage(err, "invalid time zone")
 			}
+			return location, nil
 		}
+	}
+
+	return time.UTC, nil
+}
+
+func dateTimeLayout(value string) string {
+	if len(value) == len(time.DateOnly) {
+		return time.DateOnly
+	}
+
+	// Accepts values with and without fractional seconds.
+	return "2006-01-02 15:04:05.999999999"
+}
+
+// parseArray splits the text representation of an array, like ['a','b\'c'] or [1,NULL], into its items.
+// NULL items are returned as nil. Nested arrays and tuples are not supported.
+func parseArray(value string) ([]*string, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil, errors.New(fmt.Sprintf("invalid array %q", value))
+	}
+
+	items := make([]*string, 0)
+	rest := strings.TrimSpace(value[1 : len(value)-1])
+	for rest != "" {
+		var item *string
+		if rest[0] == '\'' {
+			unquoted, n, err := unquote(rest)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("invalid array %q", value))
+			}
+			item = &unquoted
+			rest = rest[n:]
+		} else {
+			token := rest
+			if i := strings.IndexByte(rest, ','); i >= 0 {
+				token = rest[:i]
+			}
+			rest = rest[len(token):]
 
-		ret = append(ret, data)
+			token = strings.TrimSpace(token)
+			if strings.ContainsAny(token, "[('") {
+				return nil, errors.New(fmt.Sprintf("unsupported nested value in array %q", value))
+			}
+			if token != "NULL" {
+				item = &token
+			}
+		}
+		items = append(items, item)
+
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			break
+		}
+		if rest[0] != ',' {
+			return nil, errors.New(fmt.Sprintf("invalid array %q", value))
+		}
+		rest = strings.TrimSpace(rest[1:])
+	}
+
+	return items, nil
+}
+
+var escapedChars = map[byte]byte{
+	'b': '\b',
+	'f': '\f',
+	'r': '\r',
+	'n': '\n',
+	't': '\t',
+	'0': 0,
+	'a': '\a',
+	'v': '\v',
+}
+
+// unquote reads the single quoted string at the beginning of s, and returns its value and the number of bytes read.
+func unquote(s string) (string, int, error) {
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\'':
+			return sb.String(), i + 1, nil
+		case '\\':
+			i++
+			if i == len(s) {
+				return "", 0, errors.New("unterminated escape sequence")
+			}
+			if c, ok := escapedChars[s[i]]; ok {
+				sb.WriteByte(c)
+			} else {
+				sb.WriteByte(s[i])
+			}
+		default:
+			sb.WriteByte(s[i])
+		}
 	}
 
-	return ret
+	return "", 0, errors.New("unterminated string")
 }
 
 func nilPtr[T any]() *T {
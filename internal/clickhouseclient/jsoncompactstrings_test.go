@@ -1,59 +1,223 @@
 package clickhouseclient
 
 import (
+	"io"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
-func Test_jsonCompatStrings_Rows(t *testing.T) {
+func Test_rowStream_Next(t *testing.T) {
 	tests := []struct {
-		name              string
-		jsonCompatStrings jsonCompatStrings
-		want              []Row
+		name    string
+		body    string
+		want    []Row
+		wantErr bool
 	}{
 		{
 			name: "Basic test",
-			jsonCompatStrings: jsonCompatStrings{
-				Meta: []struct {
-					Name string
-					Type string
-				}{
-					{
-						Name: "name",
-						Type: "String",
-					},
-				},
-				Data: [][]string{
-					{
-						"john",
-					},
-					{
-						"frank",
-					},
-				},
-			},
+			body: `["name"]
+["String"]
+["john"]
+["frank"]
+`,
 			want: []Row{
-				rowFromMap(map[string]string{
+				rowFromMap(map[string]any{
 					"name": "john",
 				}),
-				rowFromMap(map[string]string{
+				rowFromMap(map[string]any{
 					"name": "frank",
 				}),
 			},
 		},
+		{
+			name: "Nullable values",
+			body: `["profile", "count"]
+["Nullable(String)", "Nullable(UInt64)"]
+["default", "3"]
+[null, "ᴺᵁᴸᴸ"]
+`,
+			want: []Row{
+				rowFromMap(map[string]any{
+					"profile": ptr("default"),
+					"count":   ptr(uint64(3)),
+				}),
+				rowFromMap(map[string]any{
+					"profile": nilPtr[string](),
+					"count":   nilPtr[uint64](),
+				}),
+			},
+		},
+		{
+			name: "Scalar types",
+			body: `["id", "storage", "kind", "is_partial_revoke", "port", "status", "ratio"]
+["UUID", "LowCardinality(String)", "Enum8('user' = 1, 'role' = 2)", "Bool", "UInt16", "Int64", "Float32"]
+["f47ac10b-58cc-4372-a567-0e02b2c3d479", "local_directory", "role", "true", "9000", "-1", "0.5"]
+`,
+			want: []Row{
+				rowFromMap(map[string]any{
+					"id":                "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+					"storage":           "local_directory",
+					"kind":              "role",
+					"is_partial_revoke": true,
+					"port":              uint16(9000),
+					"status":            int64(-1),
+					"ratio":             float64(0.5),
+				}),
+			},
+		},
+		{
+			name: "Date and time",
+			body: `["date", "valid_until", "event_time"]
+["Date", "DateTime('Europe/Rome')", "DateTime64(3)"]
+["2025-03-01", "2030-01-01 00:00:00", "2025-03-01 10:20:30.123"]
+`,
+			want: []Row{
+				rowFromMap(map[string]any{
+					"date":        time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+					"valid_until": time.Date(2030, 1, 1, 0, 0, 0, 0, mustLoadLocation(t, "Europe/Rome")),
+					"event_time":  time.Date(2025, 3, 1, 10, 20, 30, 123000000, time.UTC),
+				}),
+			},
+		},
+		{
+			name: "Arrays",
+			body: `["auth_type", "host_ip", "ids", "empty"]
+["Array(Enum8('no_password' = 0, 'sha256_password' = 2))", "Array(String)", "Array(Nullable(UInt64))", "Array(LowCardinality(String))"]
+["['sha256_password']", "['::/0','it\\'s, \\\\ok']", "[1,NULL]", "[]"]
+`,
+			want: []Row{
+				rowFromMap(map[string]any{
+					"auth_type": []string{"sha256_password"},
+					"host_ip":   []string{"::/0", `it's, \ok`},
+					"ids":       []*uint64{ptr(uint64(1)), nil},
+					"empty":     []string{},
+				}),
+			},
+		},
+		{
+			name: "Empty response",
+			body: ``,
+			want: []Row{},
+		},
+		{
+			name: "No rows",
+			body: "[\"name\"]\n[\"String\"]\n",
+			want: []Row{},
+		},
+		{
+			name:    "Exception after some rows",
+			body:    "[\"name\"]\n[\"String\"]\n[\"john\"]\n{\"exception\": \"Code: 241. DB::Exception: Memory limit exceeded. (MEMORY_LIMIT_EXCEEDED)\"}\n",
+			wantErr: true,
+		},
+		{
+			name: "Unsupported type",
+			body: `["settings"]
+["Map(String, String)"]
+["{}"]
+`,
+			wantErr: true,
+		},
+		{
+			name: "Invalid number",
+			body: `["count"]
+["UInt64"]
+["-1"]
+`,
+			wantErr: true,
+		},
+		{
+			name: "NULL in non nullable column",
+			body: `["name"]
+["String"]
+[null]
+`,
+			wantErr: true,
+		},
+		{
+			name: "Invalid array",
+			body: `["names"]
+["Array(String)"]
+["['unterminated]"]
+`,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.jsonCompatStrings.Rows()
+			stream := newRowStream(strings.NewReader(tt.body))
+
+			got := make([]Row, 0)
+			var err error
+			for {
+				var row Row
+				row, err = stream.Next()
+				if err != nil {
+					break
+				}
+				got = append(got, row)
+			}
+			if err == io.EOF {
+				err = nil
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rowStream.Next() error = %v, wantErr %v", err, tt.wantErr)
+			}
 
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("jsonCompatStrings.Rows() want = %v, got %v", tt.want, got)
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rowStream.Next() want = %v, got %v", tt.want, got)
 			}
 		})
 	}
 }
 
-func rowFromMap(data map[string]string) Row {
+func TestRow_Getters(t *testing.T) {
+	row := rowFromMap(map[string]any{
+		"port":      uint16(9000),
+		"count":     ptr(uint64(3)),
+		"missing":   nilPtr[uint64](),
+		"auth_type": []string{"sha256_password"},
+		"status":    int32(-1),
+		"max_time":  ptr(float64(0.5)),
+	})
+
+	port, err := row.GetUInt64("port")
+	if err != nil || port != 9000 {
+		t.Errorf("GetUInt64() = %v, %v, want 9000", port, err)
+	}
+
+	count, err := row.GetNullableUInt64("count")
+	if err != nil || count == nil || *count != 3 {
+		t.Errorf("GetNullableUInt64() = %v, %v, want 3", count, err)
+	}
+
+	missing, err := row.GetNullableUInt64("missing")
+	if err != nil || missing != nil {
+		t.Errorf("GetNullableUInt64() = %v, %v, want nil", missing, err)
+	}
+
+	authTypes, err := row.GetStringSlice("auth_type")
+	if err != nil || !reflect.DeepEqual(authTypes, []string{"sha256_password"}) {
+		t.Errorf("GetStringSlice() = %v, %v, want [sha256_password]", authTypes, err)
+	}
+
+	status, err := row.GetInt64("status")
+	if err != nil || status != -1 {
+		t.Errorf("GetInt64() = %v, %v, want -1", status, err)
+	}
+
+	maxTime, err := row.GetNullableFloat64("max_time")
+	if err != nil || maxTime == nil || *maxTime != 0.5 {
+		t.Errorf("GetNullableFloat64() = %v, %v, want 0.5", maxTime, err)
+	}
+
+	if _, err := row.GetStringSlice("port"); err == nil {
+		t.Errorf("GetStringSlice() on a number should fail")
+	}
+}
+
+func rowFromMap(data map[string]any) Row {
 	row := Row{}
 
 	for k, v := range data {
@@ -62,3 +226,16 @@ func rowFromMap(data map[string]string) Row {
 
 	return row
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return location
+}
@@ -17,29 +17,55 @@ import (
 const defaultDatabase = "default"
 
 type nativeClient struct {
-	connection driver.Conn
+	connection   driver.Conn
+	queryTimeout time.Duration
 }
 
 type NativeClientConfig struct {
-	Host             string
-	Port             uint16
-	UserPasswordAuth *UserPasswordAuth
-	EnableTLS        bool
+	Host string
+	Port uint16
+	// Endpoints lists the servers to connect to, as an alternative to Host and Port.
+	// When a server can't be reached, the next one is tried according to HostSelectionStrategy.
+	Endpoints             []Endpoint
+	HostSelectionStrategy HostSelectionStrategy
+	UserPasswordAuth      *UserPasswordAuth
+	CertificateAuth       *CertificateAuth
+	// TLSConfig enables TLS when set.
+	TLSConfig *tls.Config
+	// QueryTimeout bounds the time each query can take, including reading the results. Zero means no timeout.
+	QueryTimeout time.Duration
+	// ConnectTimeout bounds the time to establish a connection to a server. Defaults to 30 seconds.
+	ConnectTimeout time.Duration
+	// Settings are ClickHouse settings sent along with every query.
+	Settings map[string]string
 }
 
 func NewNativeClient(config NativeClientConfig) (ClickhouseClient, error) {
-	if config.Host == "" {
-		return nil, errors.New("Host is required")
-	}
-	if config.Port == 0 {
-		return nil, errors.New("Port is required")
+	endpoints, err := resolveEndpoints(config.Host, config.Port, config.Endpoints)
+	if err != nil {
+		return nil, err
 	}
-	if config.UserPasswordAuth == nil {
+	if (config.UserPasswordAuth == nil) == (config.CertificateAuth == nil) {
 		return nil, errors.New("Exactly one authentication method is required")
 	}
+	if config.CertificateAuth != nil && (config.TLSConfig == nil || len(config.TLSConfig.Certificates) == 0) {
+		return nil, errors.New("Certificate authentication requires a TLS client certificate")
+	}
 
-	options := clickhouse.Options{
-		Addr: []string{fmt.Sprintf("%s:%d", config.Host, config.Port)},
+	options := clickhouse.Options{}
+	for _, e := range endpoints {
+		options.Addr = append(options.Addr, e.String())
+	}
+
+	switch config.HostSelectionStrategy {
+	case "", HostSelectionInOrder:
+		options.ConnOpenStrategy = clickhouse.ConnOpenInOrder
+	case HostSelectionRandom:
+		options.ConnOpenStrategy = clickhouse.ConnOpenRandom
+	case HostSelectionRoundRobin:
+		options.ConnOpenStrategy = clickhouse.ConnOpenRoundRobin
+	default:
+		return nil, errors.New(fmt.Sprintf("invalid host selection strategy %q", config.HostSelectionStrategy))
 	}
 
 	if config.UserPasswordAuth != nil {
@@ -55,8 +81,35 @@ func NewNativeClient(config NativeClientConfig) (ClickhouseClient, error) {
 		options.Auth = auth
 	}
 
-	if config.EnableTLS {
-		options.TLS = &tls.Config{} //nolint:gosec
+	if config.CertificateAuth != nil {
+		// The server identifies the user by the certificate, so no password is sent.
+		auth := clickhouse.Auth{}
+		auth.Database = config.CertificateAuth.Database
+		auth.Username = config.CertificateAuth.Username
+
+		if auth.Database == "" {
+			auth.Database = defaultDatabase
+		}
+
+		options.Auth = auth
+	}
+
+	if config.TLSConfig != nil {
+		options.TLS = config.TLSConfig
+	}
+
+	// Default timeout of native client is 30 seconds.
+	connectTimeout := 30 * time.Second
+	if config.ConnectTimeout > 0 {
+		connectTimeout = config.ConnectTimeout
+	}
+	options.DialTimeout = connectTimeout
+
+	if len(config.Settings) > 0 {
+		options.Settings = clickhouse.Settings{}
+		for name, value := range config.Settings {
+			options.Settings[name] = value
+		}
 	}
 
 	conn, err := clickhouse.Open(&options)
@@ -64,8 +117,7 @@ func NewNativeClient(config NativeClientConfig) (ClickhouseClient, error) {
 		return nil, err
 	}
 
-	// Default timeout of native client is 30 seconds.
-	ctx, cancelFunc := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancelFunc := context.WithTimeout(context.Background(), connectTimeout)
 	defer cancelFunc()
 
 	err = conn.Ping(ctx)
@@ -74,12 +126,17 @@ func NewNativeClient(config NativeClientConfig) (ClickhouseClient, error) {
 	}
 
 	return &nativeClient{
-		connection: conn,
+		connection:   conn,
+		queryTimeout: config.QueryTimeout,
 	}, nil
 }
 
 func (i *nativeClient) Select(ctx context.Context, qry string, callback func(Row) error) error {
-	ctx = tflog.SetField(ctx, "Query", qry)
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	ctx = i.queryContext(ctx, nil)
+	ctx = tflog.SetField(ctx, "Query", RedactQuery(qry))
 	tflog.Debug(ctx, "Running Query")
 
 	rows, err := i.connection.Query(ctx, qry)
@@ -101,25 +158,31 @@ func (i *nativeClient) Select(ctx context.Context, qry string, callback func(Row
 			return errors.WithMessage(err, "error scanning row")
 		}
 
-		// Prepare a Row for the callback.
+		// Prepare a Row for the callback, with the same types the HTTP client decodes values to.
 		ret := Row{}
 		for i, v := range vars {
 			switch v := v.(type) {
-			case *string:
-				// Non-nullable string, return string value.
-				ret.Set(rows.Columns()[i], *v)
 			case *uuid.UUID:
 				// Return string representation.
 				ret.Set(rows.Columns()[i], v.String())
-			case **string:
-				// Nullable string, return either nil or a pointer to the string
-				ret.Set(rows.Columns()[i], *v)
-			case *uint8:
-				ret.Set(rows.Columns()[i], *v)
-			case *uint64:
-				ret.Set(rows.Columns()[i], *v)
+			case **uuid.UUID:
+				if *v == nil {
+					ret.Set(rows.Columns()[i], nilPtr[string]())
+				} else {
+					s := (*v).String()
+					ret.Set(rows.Columns()[i], &s)
+				}
+			case *[]uuid.UUID:
+				ids := make([]string, 0, len(*v))
+				for _, id := range *v {
+					ids = append(ids, id.String())
+				}
+				ret.Set(rows.Columns()[i], ids)
+			case *float32:
+				ret.Set(rows.Columns()[i], float64(*v))
 			default:
-				return errors.New(fmt.Sprintf("unsupported column type: %s", reflect.TypeOf(v)))
+				// Scanned into a pointer to the value, e.g. *string for String or **string for Nullable(String).
+				ret.Set(rows.Columns()[i], reflect.ValueOf(v).Elem().Interface())
 			}
 		}
 		err = callback(ret)
@@ -132,13 +195,87 @@ func (i *nativeClient) Select(ctx context.Context, qry string, callback func(Row
 }
 
 func (i *nativeClient) Exec(ctx context.Context, qry string) error {
-	ctx = tflog.SetField(ctx, "Query", qry)
+	ctx, cancel := withQueryTimeout(ctx, i.queryTimeout)
+	defer cancel()
+
+	if !isOnCluster(ctx) {
+		ctx = i.queryContext(ctx, nil)
+		ctx = tflog.SetField(ctx, "Query", RedactQuery(qry))
+		tflog.Debug(ctx, "Running Query")
+
+		err := i.connection.Exec(ctx, qry)
+		if err != nil {
+			return errors.WithMessage(err, "error executing query")
+		}
+
+		return nil
+	}
+
+	ctx = i.queryContext(ctx, distributedDDLSettings)
+	ctx = tflog.SetField(ctx, "Query", RedactQuery(qry))
 	tflog.Debug(ctx, "Running Query")
 
-	err := i.connection.Exec(ctx, qry)
+	// Exec discards the result, read the per-host status rows instead.
+	rows, err := i.connection.Query(ctx, qry)
 	if err != nil {
 		return errors.WithMessage(err, "error executing query")
 	}
+	defer rows.Close()
 
-	return nil
+	columnTypes := rows.ColumnTypes()
+	vars := make([]any, len(columnTypes))
+	for i := range columnTypes {
+		vars[i] = reflect.New(columnTypes[i].ScanType()).Interface()
+	}
+
+	statuses := make([][]*string, 0)
+	for rows.Next() {
+		if err := rows.Scan(vars...); err != nil {
+			return errors.WithMessage(err, "error scanning row")
+		}
+
+		row := make([]*string, len(vars))
+		for i, v := range vars {
+			row[i] = scannedString(reflect.ValueOf(v))
+		}
+		statuses = append(statuses, row)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.WithMessage(err, "error reading ON CLUSTER query status")
+	}
+
+	return checkHostStatuses(rows.Columns(), statuses)
+}
+
+// scannedString returns the value scanned into v formatted as a string, or nil for NULL.
+func scannedString(v reflect.Value) *string {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	s := fmt.Sprint(v.Interface())
+
+	return &s
+}
+
+// queryContext returns a context that makes the driver send the query ID and log comment identifying the provider,
+// along with settings on top of the ones of the connection.
+func (i *nativeClient) queryContext(ctx context.Context, settings map[string]string) context.Context {
+	queryID := newQueryID()
+	ctx = tflog.SetField(ctx, "QueryID", queryID)
+
+	querySettings := clickhouse.Settings{
+		"log_comment": logComment(ctx),
+	}
+	for name, value := range settings {
+		querySettings[name] = value
+	}
+
+	return clickhouse.Context(ctx,
+		clickhouse.WithQueryID(queryID),
+		clickhouse.WithSettings(querySettings),
+	)
 }
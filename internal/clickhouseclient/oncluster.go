@@ -0,0 +1,176 @@
+package clickhouseclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// distributedDDLSettings make ON CLUSTER queries return the outcome on every host, instead of rethrowing the first
+// error or giving up on timeout, so that all the hosts that diverged can be reported.
+var distributedDDLSettings = map[string]string{
+	"distributed_ddl_output_mode": "never_throw",
+}
+
+type onClusterKey struct{}
+
+// WithCluster returns a context making the clients run statements as distributed DDL queries, checking their outcome
+// on every host, when clusterName is set. The statements themselves must be built with an ON CLUSTER clause.
+func WithCluster(ctx context.Context, clusterName *string) context.Context {
+	if clusterName == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, onClusterKey{}, true)
+}
+
+// isOnCluster returns true if the statement run with ctx is a distributed DDL query, run by every host of a cluster.
+func isOnCluster(ctx context.Context) bool {
+	onCluster, _ := ctx.Value(onClusterKey{}).(bool)
+	return onCluster
+}
+
+// HostStatus is the outcome of an ON CLUSTER query on a single host.
+type HostStatus struct {
+	Host string
+	Port string
+	// Status is the ClickHouse error code of the query on the host, 0 on success and nil when the host didn't
+	// finish before distributed_ddl_task_timeout.
+	Status *int64
+	Error  string
+}
+
+func (h HostStatus) address() string {
+	if h.Port == "" {
+		return h.Host
+	}
+
+	return h.Host + ":" + h.Port
+}
+
+// ClusterError is returned when an ON CLUSTER query failed or timed out on some of the hosts.
+// The query might have been applied on the other hosts, leaving the cluster in an inconsistent state.
+type ClusterError struct {
+	// Hosts lists the hosts where the query failed or didn't finish in time.
+	Hosts []HostStatus
+	// Succeeded is the number of hosts where the query succeeded.
+	Succeeded int
+	// Unreported is the number of hosts that didn't report any status before distributed_ddl_task_timeout.
+	Unreported uint64
+}
+
+func (e *ClusterError) Error() string {
+	failures := make([]string, 0, len(e.Hosts)+1)
+	for _, h := range e.Hosts {
+		if h.Status == nil {
+			failures = append(failures, fmt.Sprintf("%s: not finished within distributed_ddl_task_timeout", h.address()))
+		} else {
+			failures = append(failures, fmt.Sprintf("%s: code %d: %s", h.address(), *h.Status, strings.TrimSpace(h.Error)))
+		}
+	}
+
+	if e.Unreported > 0 {
+		failures = append(failures, fmt.Sprintf("%d more host(s) did not report within distributed_ddl_task_timeout", e.Unreported))
+	}
+
+	return fmt.Sprintf("ON CLUSTER query did not succeed on all hosts, the cluster may be left partially changed: %s", strings.Join(failures, "; "))
+}
+
+// checkHostStatuses parses the per-host status rows returned by an ON CLUSTER query, given the column names and the
+// values as strings (nil for NULL), and returns a ClusterError if any host failed.
+// Results without a status column, like the ones of queries not run on a cluster, are ignored.
+func checkHostStatuses(columns []string, rows [][]*string) error {
+	index := make(map[string]int, len(columns))
+	for i, name := range columns {
+		index[name] = i
+	}
+
+	statusIndex, ok := index["status"]
+	if !ok {
+		return nil
+	}
+
+	value := func(row []*string, column string) string {
+		i, ok := index[column]
+		if !ok || i >= len(row) || row[i] == nil {
+			return ""
+		}
+		return *row[i]
+	}
+
+	clusterError := &ClusterError{}
+	for _, row := range rows {
+		host := HostStatus{
+			Host:  value(row, "host"),
+			Port:  value(row, "port"),
+			Error: value(row, "error"),
+		}
+		if host.Host == "" {
+			// Databases with the Replicated engine identify hosts by shard and replica.
+			host.Host = strings.Trim(value(row, "shard")+"|"+value(row, "replica"), "|")
+		}
+
+		if statusIndex < len(row) && row[statusIndex] != nil {
+			status, err := strconv.ParseInt(*row[statusIndex], 10, 64)
+			if err != nil {
+				return errors.New(fmt.Sprintf("invalid status %q for host %s", *row[statusIndex], host.address()))
+			}
+			host.Status = &status
+		}
+
+		if host.Status == nil || *host.Status != 0 {
+			clusterError.Hosts = append(clusterError.Hosts, host)
+		} else {
+			clusterError.Succeeded++
+		}
+
+		// Every row reports how many hosts are still running the query, the last one tells how many never answered.
+		remaining, err := strconv.ParseUint(value(row, "num_hosts_remaining"), 10, 64)
+		if err == nil {
+			clusterError.Unreported = remaining
+		}
+	}
+
+	// Hosts reported with a NULL status are already listed, don't count them twice.
+	if clusterError.Unreported > 0 {
+		for _, h := range clusterError.Hosts {
+			if h.Status == nil && clusterError.Unreported > 0 {
+				clusterError.Unreported--
+			}
+		}
+	}
+
+	if len(clusterError.Hosts) == 0 && clusterError.Unreported == 0 {
+		return nil
+	}
+
+	return clusterError
+}
+
+// hostStatusResult is used to parse the per-host status rows of ON CLUSTER queries run with the HTTP client.
+type hostStatusResult struct {
+	Meta []struct {
+		Name string
+	} `json:"meta"`
+	Data [][]*string `json:"data"`
+}
+
+func (r hostStatusResult) check() error {
+	columns := make([]string, 0, len(r.Meta))
+	for _, entry := range r.Meta {
+		columns = append(columns, entry.Name)
+	}
+
+	for _, row := range r.Data {
+		for i, field := range row {
+			if field != nil && *field == nullString {
+				row[i] = nil
+			}
+		}
+	}
+
+	return checkHostStatuses(columns, r.Data)
+}
@@ -0,0 +1,125 @@
+package clickhouseclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func strRow(values ...string) []*string {
+	row := make([]*string, len(values))
+	for i := range values {
+		if values[i] != nullString {
+			row[i] = &values[i]
+		}
+	}
+	return row
+}
+
+func Test_checkHostStatuses(t *testing.T) {
+	columns := []string{"host", "port", "status", "error", "num_hosts_remaining", "num_hosts_active"}
+
+	tests := []struct {
+		name           string
+		columns        []string
+		rows           [][]*string
+		wantHosts      []string
+		wantUnreported uint64
+	}{
+		{
+			name:    "All hosts succeeded",
+			columns: columns,
+			rows: [][]*string{
+				strRow("ch-1", "9000", "0", "", "1", "0"),
+				strRow("ch-2", "9000", "0", "", "0", "0"),
+			},
+		},
+		{
+			name:    "Not an ON CLUSTER result",
+			columns: []string{"version"},
+			rows: [][]*string{
+				strRow("24.8.1.1"),
+			},
+		},
+		{
+			name:    "One host failed",
+			columns: columns,
+			rows: [][]*string{
+				strRow("ch-1", "9000", "0", "", "1", "0"),
+				strRow("ch-2", "9000", "511", "Code: 511. DB::Exception: Role `reader` not found.", "0", "0"),
+			},
+			wantHosts: []string{"ch-2:9000"},
+		},
+		{
+			name:    "Host timed out",
+			columns: columns,
+			rows: [][]*string{
+				strRow("ch-1", "9000", "0", "", "1", "1"),
+				strRow("ch-2", "9000", nullString, nullString, "1", "1"),
+			},
+			wantHosts: []string{"ch-2:9000"},
+		},
+		{
+			name:    "Hosts never reported",
+			columns: columns,
+			rows: [][]*string{
+				strRow("ch-1", "9000", "0", "", "2", "0"),
+			},
+			wantUnreported: 2,
+		},
+		{
+			name:    "Replicated database",
+			columns: []string{"shard", "replica", "status", "num_hosts_remaining", "num_hosts_active"},
+			rows: [][]*string{
+				strRow("s1", "r1", "0", "1", "0"),
+				strRow("s1", "r2", "60", "0", "0"),
+			},
+			wantHosts: []string{"s1|r2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHostStatuses(tt.columns, tt.rows)
+			if len(tt.wantHosts) == 0 && tt.wantUnreported == 0 {
+				if err != nil {
+					t.Fatalf("checkHostStatuses() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var clusterError *ClusterError
+			if !errors.As(err, &clusterError) {
+				t.Fatalf("checkHostStatuses() error = %v, want a ClusterError", err)
+			}
+			if len(clusterError.Hosts) != len(tt.wantHosts) {
+				t.Fatalf("checkHostStatuses() failed hosts = %v, want %v", clusterError.Hosts, tt.wantHosts)
+			}
+			for i, h := range clusterError.Hosts {
+				if h.address() != tt.wantHosts[i] {
+					t.Errorf("checkHostStatuses() failed host = %s, want %s", h.address(), tt.wantHosts[i])
+				}
+				if !strings.Contains(err.Error(), tt.wantHosts[i]) {
+					t.Errorf("Error() = %q, want it to mention %s", err.Error(), tt.wantHosts[i])
+				}
+			}
+			if clusterError.Unreported != tt.wantUnreported {
+				t.Errorf("checkHostStatuses() unreported = %d, want %d", clusterError.Unreported, tt.wantUnreported)
+			}
+		})
+	}
+}
+
+func TestWithCluster(t *testing.T) {
+	clusterName := "cluster1"
+
+	if isOnCluster(context.Background()) {
+		t.Errorf("isOnCluster() = true without WithCluster, want false")
+	}
+	if isOnCluster(WithCluster(context.Background(), nil)) {
+		t.Errorf("isOnCluster() = true without a cluster name, want false")
+	}
+	if !isOnCluster(WithCluster(context.Background(), &clusterName)) {
+		t.Errorf("isOnCluster() = false with a cluster name, want true")
+	}
+}
@@ -0,0 +1,64 @@
+package clickhouseclient
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/project"
+)
+
+// QueryIDPrefix is prepended to the ID of every query run by the provider, so they can be found in system.query_log.
+const QueryIDPrefix = "terraform-provider-clickhousedbops-"
+
+// Terraform operations queries are run for.
+const (
+	OperationConfigure      = "configure"
+	OperationValidate       = "validate"
+	OperationPlan           = "plan"
+	OperationCreate         = "create"
+	OperationRead           = "read"
+	OperationUpdate         = "update"
+	OperationDelete         = "delete"
+	OperationImport         = "import"
+	OperationReadDataSource = "read_data_source"
+)
+
+type queryTagsKey struct{}
+
+type queryTags struct {
+	TypeName  string
+	Operation string
+}
+
+// WithQueryTags returns a context that makes the clients tag queries with the resource or data source type name and
+// the Terraform operation being run.
+func WithQueryTags(ctx context.Context, typeName string, operation string) context.Context {
+	return context.WithValue(ctx, queryTagsKey{}, queryTags{
+		TypeName:  typeName,
+		Operation: operation,
+	})
+}
+
+// logComment returns the value for the log_comment setting of queries run with ctx, as a JSON object.
+func logComment(ctx context.Context) string {
+	comment := map[string]string{
+		"provider": project.FullName(),
+		"version":  project.Version(),
+	}
+
+	if tags, ok := ctx.Value(queryTagsKey{}).(queryTags); ok {
+		comment["type_name"] = tags.TypeName
+		comment["operation"] = tags.Operation
+	}
+
+	// Marshaling a map of strings can't fail.
+	ret, _ := json.Marshal(comment)
+
+	return string(ret)
+}
+
+func newQueryID() string {
+	return QueryIDPrefix + uuid.NewString()
+}
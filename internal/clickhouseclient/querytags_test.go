@@ -0,0 +1,103 @@
+package clickhouseclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/project"
+)
+
+func TestLogComment(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]string
+	}{
+		{
+			name: "Without tags",
+			ctx:  context.Background(),
+			want: map[string]string{
+				"provider": project.FullName(),
+				"version":  project.Version(),
+			},
+		},
+		{
+			name: "With tags",
+			ctx:  WithQueryTags(context.Background(), "clickhousedbops_role", OperationCreate),
+			want: map[string]string{
+				"provider":  project.FullName(),
+				"version":   project.Version(),
+				"type_name": "clickhousedbops_role",
+				"operation": OperationCreate,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			err := json.Unmarshal([]byte(logComment(tt.ctx)), &got)
+			if err != nil {
+				t.Fatalf("logComment() is not valid JSON: %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Errorf("logComment() = %v, want %v", got, tt.want)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("logComment()[%q] = %q, want %q", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPClient_QueryTags(t *testing.T) {
+	var got url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(serverURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewHTTPClient(HTTPClientConfig{
+		Host:      serverURL.Hostname(),
+		Port:      uint16(port), //nolint:gosec
+		BasicAuth: &BasicAuth{Username: "default"},
+		Settings:  map[string]string{"max_execution_time": "60"},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	ctx := WithQueryTags(context.Background(), "clickhousedbops_grants", OperationUpdate)
+	err = client.Exec(ctx, "GRANT SELECT ON *.* TO john")
+	if err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+
+	if !strings.HasPrefix(got.Get("query_id"), QueryIDPrefix) {
+		t.Errorf("query_id = %q, want prefix %q", got.Get("query_id"), QueryIDPrefix)
+	}
+	if got.Get("log_comment") != logComment(ctx) {
+		t.Errorf("log_comment = %q, want %q", got.Get("log_comment"), logComment(ctx))
+	}
+	if got.Get("max_execution_time") != "60" {
+		t.Errorf("max_execution_time = %q, want %q", got.Get("max_execution_time"), "60")
+	}
+}
@@ -0,0 +1,127 @@
+package clickhouseclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pingcap/errors"
+)
+
+// ErrDryRun is returned when a statement is recorded in dry run mode outside of a create, an update or a plan, like a
+// DROP run while deleting a resource, so that Terraform does not forget about an entity that still exists.
+var ErrDryRun = errors.New("statement recorded and not executed because dry run mode is enabled")
+
+// recordingClient is a ClickhouseClient that writes every statement run with Exec to a file.
+// Select queries are always run on the wrapped client.
+type recordingClient struct {
+	client ClickhouseClient
+	dryRun bool
+
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// NewRecordingClient returns a ClickhouseClient writing all the statements run with Exec to writer, in order.
+// When dryRun is true, the statements are only recorded and not executed on the server.
+func NewRecordingClient(client ClickhouseClient, writer io.Writer, dryRun bool) ClickhouseClient {
+	return &recordingClient{
+		client: client,
+		dryRun: dryRun,
+		writer: writer,
+	}
+}
+
+// sqlLogFile is an io.Writer appending to a file opened for each write, so that it is never left open.
+type sqlLogFile struct {
+	path string
+}
+
+// NewSQLLogFile empties the file at path, creating it if needed, and returns an io.Writer appending to it.
+// The file only holds the statements of the current run.
+func NewSQLLogFile(path string) (io.Writer, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600) //nolint:gosec
+	if err != nil {
+		return nil, errors.WithMessage(err, "error opening file")
+	}
+
+	err = file.Close()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error closing file")
+	}
+
+	return &sqlLogFile{path: path}, nil
+}
+
+func (f *sqlLogFile) Write(p []byte) (int, error) {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := file.Write(p)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	return n, err
+}
+
+// IsDryRun returns true when client only records the statements it is given, without running them.
+func IsDryRun(client ClickhouseClient) bool {
+	r, ok := client.(*recordingClient)
+	return ok && r.dryRun
+}
+
+func (r *recordingClient) Select(ctx context.Context, qry string, callback func(Row) error) error {
+	return r.client.Select(ctx, qry, callback)
+}
+
+func (r *recordingClient) Exec(ctx context.Context, qry string) error {
+	err := r.record(ctx, qry)
+	if err != nil {
+		return errors.WithMessage(err, "error recording statement")
+	}
+
+	if !r.dryRun {
+		return r.client.Exec(ctx, qry)
+	}
+
+	tflog.Info(ctx, "Dry run mode, statement not executed", map[string]any{"Query": RedactQuery(qry)})
+
+	// Succeeding on deletion would make Terraform remove the resource from the state, even if nothing was dropped.
+	// Statements not explicitly run by a create, an update or a plan fail too, so that an untagged deletion cannot succeed.
+	tags, _ := ctx.Value(queryTagsKey{}).(queryTags)
+	switch tags.Operation {
+	case OperationCreate, OperationUpdate, OperationPlan:
+		return nil
+	default:
+		return ErrDryRun
+	}
+}
+
+func (r *recordingClient) record(ctx context.Context, qry string) error {
+	header := fmt.Sprintf("-- %s", time.Now().UTC().Format(time.RFC3339))
+	if tags, ok := ctx.Value(queryTagsKey{}).(queryTags); ok {
+		header = fmt.Sprintf("%s %s %s", header, tags.TypeName, tags.Operation)
+	}
+
+	// Password hashes are kept, like in the Terraform state, but values of named collections can be plain secrets.
+	statement := redact(strings.TrimSpace(qry), namedCollectionClause)
+	if !strings.HasSuffix(statement, ";") {
+		statement += ";"
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err := fmt.Fprintf(r.writer, "%s\n%s\n\n", header, statement)
+
+	return err
+}
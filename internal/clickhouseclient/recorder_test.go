@@ -0,0 +1,140 @@
+package clickhouseclient
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/errors"
+)
+
+func TestRecordingClient(t *testing.T) {
+	tests := []struct {
+		name          string
+		dryRun        bool
+		operation     string
+		wantErr       error
+		wantExecCalls int
+	}{
+		{
+			name:          "Statements are recorded and executed",
+			operation:     OperationCreate,
+			wantExecCalls: 1,
+		},
+		{
+			name:          "Dry run does not execute statements",
+			dryRun:        true,
+			operation:     OperationCreate,
+			wantExecCalls: 0,
+		},
+		{
+			name:          "Dry run fails deletions",
+			dryRun:        true,
+			operation:     OperationDelete,
+			wantErr:       ErrDryRun,
+			wantExecCalls: 0,
+		},
+		{
+			name:          "Dry run fails untagged statements",
+			dryRun:        true,
+			wantErr:       ErrDryRun,
+			wantExecCalls: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeClient{}
+			var buf bytes.Buffer
+			client := NewRecordingClient(fake, &buf, tt.dryRun)
+
+			ctx := context.Background()
+			header := "\n"
+			if tt.operation != "" {
+				ctx = WithQueryTags(ctx, "clickhousedbops_role", tt.operation)
+				header = " clickhousedbops_role " + tt.operation + "\n"
+			}
+
+			err := client.Exec(ctx, "CREATE ROLE `admin`")
+			if errors.Cause(err) != tt.wantErr {
+				t.Fatalf("Exec() error = %v, want %v", err, tt.wantErr)
+			}
+
+			err = client.Select(ctx, "SELECT 1", func(Row) error { return nil })
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+
+			// The fake client counts both Exec and Select calls.
+			if fake.calls != tt.wantExecCalls+1 {
+				t.Errorf("Exec calls = %d, want %d", fake.calls-1, tt.wantExecCalls)
+			}
+
+			got := buf.String()
+			if !strings.Contains(got, header+"CREATE ROLE `admin`;\n") {
+				t.Errorf("recorded = %q, missing statement", got)
+			}
+			if strings.Contains(got, "SELECT 1") {
+				t.Errorf("recorded = %q, Select queries must not be recorded", got)
+			}
+		})
+	}
+}
+
+func TestRecordingClient_redactsNamedCollections(t *testing.T) {
+	var buf bytes.Buffer
+	client := NewRecordingClient(&fakeClient{}, &buf, false)
+
+	ctx := WithQueryTags(context.Background(), "clickhousedbops_named_collection", OperationCreate)
+	err := client.Exec(ctx, "CREATE NAMED COLLECTION `s3_data` AS `secret_access_key` = 's3cr3t';")
+	if err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+
+	got := buf.String()
+	if strings.Contains(got, "s3cr3t") {
+		t.Errorf("recorded = %q, named collection values must be redacted", got)
+	}
+	if !strings.Contains(got, "CREATE NAMED COLLECTION `s3_data` AS `secret_access_key` = '[REDACTED]';") {
+		t.Errorf("recorded = %q, missing statement", got)
+	}
+}
+
+func TestNewSQLLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statements.sql")
+	err := os.WriteFile(path, []byte("-- previous run\n"), 0o600)
+	if err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	writer, err := NewSQLLogFile(path)
+	if err != nil {
+		t.Fatalf("NewSQLLogFile() error = %v", err)
+	}
+
+	client := NewRecordingClient(&fakeClient{}, writer, true)
+	if !IsDryRun(client) {
+		t.Errorf("IsDryRun() = false, want true")
+	}
+
+	ctx := WithQueryTags(context.Background(), "clickhousedbops_role", OperationCreate)
+	for _, qry := range []string{"CREATE ROLE `a`", "CREATE ROLE `b`"} {
+		err = client.Exec(ctx, qry)
+		if err != nil {
+			t.Fatalf("Exec() error = %v", err)
+		}
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(got), "previous run") {
+		t.Errorf("file = %q, statements of a previous run must be removed", got)
+	}
+	if !strings.Contains(string(got), "CREATE ROLE `a`;\n\n") || !strings.Contains(string(got), "CREATE ROLE `b`;\n\n") {
+		t.Errorf("file = %q, missing statements", got)
+	}
+}
@@ -0,0 +1,57 @@
+package clickhouseclient
+
+import (
+	"regexp"
+)
+
+const redacted = "'[REDACTED]'"
+
+// stringLiteral matches a single quoted SQL string, including escaped quotes and backslashes.
+const stringLiteral = `'(?:[^'\\]|\\.)*'`
+
+// secretClause describes a part of a query carrying secrets: every match of secret found after start is masked.
+// The first group of secret is kept, the string literal following it is replaced.
+type secretClause struct {
+	start  *regexp.Regexp
+	secret *regexp.Regexp
+}
+
+// identifiedClause matches CREATE/ALTER USER ... IDENTIFIED [WITH <method>] BY '<secret>' [SALT '<salt>'], including
+// multiple authentication methods.
+var identifiedClause = secretClause{
+	start:  regexp.MustCompile(`(?i)\bIDENTIFIED\b`),
+	secret: regexp.MustCompile(`(?i)(\b(?:BY|SALT)\s+)` + stringLiteral),
+}
+
+// namedCollectionClause matches CREATE NAMED COLLECTION ... AS key = '<value>', ... and ALTER NAMED COLLECTION ...
+// SET key = '<value>', ... Every value is masked, as the provider can't tell which ones are secrets.
+var namedCollectionClause = secretClause{
+	start:  regexp.MustCompile(`(?i)\bNAMED\s+COLLECTION\b`),
+	secret: regexp.MustCompile(`(=\s*)` + stringLiteral),
+}
+
+// secretClauses lists all the clauses with secrets the provider generates.
+// Add an entry here when building queries with a new kind of secret.
+var secretClauses = []secretClause{
+	identifiedClause,
+	namedCollectionClause,
+}
+
+// RedactQuery returns qry with the secrets it contains masked, so that it can be safely logged.
+func RedactQuery(qry string) string {
+	return redact(qry, secretClauses...)
+}
+
+// redact returns qry with the secrets matched by clauses masked.
+func redact(qry string, clauses ...secretClause) string {
+	for _, clause := range clauses {
+		loc := clause.start.FindStringIndex(qry)
+		if loc == nil {
+			continue
+		}
+
+		qry = qry[:loc[1]] + clause.secret.ReplaceAllString(qry[loc[1]:], "${1}"+redacted)
+	}
+
+	return qry
+}
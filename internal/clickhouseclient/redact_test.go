@@ -0,0 +1,95 @@
+package clickhouseclient
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/querybuilder"
+)
+
+func TestRedactQuery(t *testing.T) {
+	clusterName := "cluster1"
+	profileName := "profile1"
+
+	build := func(builder querybuilder.QueryBuilder) string {
+		t.Helper()
+		qry, err := builder.Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return qry
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		secrets []string
+		want    string
+	}{
+		{
+			name:    "Create user",
+			query:   build(querybuilder.NewCreateUser("john").Identified(querybuilder.IdentificationSHA256Hash, "6b86b273ff34fce19d6b804eff5a3f57")),
+			secrets: []string{"6b86b273ff34fce19d6b804eff5a3f57"},
+			want:    "CREATE USER `john` IDENTIFIED WITH sha256_hash BY '[REDACTED]';",
+		},
+		{
+			name:    "Create user on cluster with settings profile",
+			query:   build(querybuilder.NewCreateUser("john").Identified(querybuilder.IdentificationSHA256Hash, "6b86b273ff34fce19d6b804eff5a3f57").WithCluster(&clusterName).WithSettingsProfile(&profileName)),
+			secrets: []string{"6b86b273ff34fce19d6b804eff5a3f57"},
+		},
+		{
+			name:    "Secret with escaped quotes",
+			query:   build(querybuilder.NewCreateUser("john").Identified(querybuilder.IdentificationSHA256Hash, `it's a \secret`)),
+			secrets: []string{"it", "secret"},
+			want:    "CREATE USER `john` IDENTIFIED WITH sha256_hash BY '[REDACTED]';",
+		},
+		{
+			name:    "Plain password, salt and multiple authentication methods",
+			query:   "ALTER USER john IDENTIFIED WITH sha256_hash BY 'hash1' SALT 'salt1', plaintext_password by 'password2' VALID UNTIL '2030-01-01'",
+			secrets: []string{"hash1", "salt1", "password2"},
+			want:    "ALTER USER john IDENTIFIED WITH sha256_hash BY '[REDACTED]' SALT '[REDACTED]', plaintext_password by '[REDACTED]' VALID UNTIL '2030-01-01'",
+		},
+		{
+			name:    "Create named collection on cluster",
+			query:   build(querybuilder.NewCreateNamedCollection("s3_data", map[string]string{"access_key_id": "AKIAEXAMPLE", "secret_access_key": `it's a \s3cr3t`}).WithCluster(&clusterName)),
+			secrets: []string{"AKIAEXAMPLE", "s3cr3t"},
+			want:    "CREATE NAMED COLLECTION `s3_data` ON CLUSTER 'cluster1' AS `access_key_id` = '[REDACTED]', `secret_access_key` = '[REDACTED]';",
+		},
+		{
+			name:    "Alter named collection",
+			query:   build(querybuilder.NewAlterNamedCollection("s3_data").Set(map[string]string{"secret_access_key": "new secret"}).Delete([]string{"format"})),
+			secrets: []string{"new secret"},
+			want:    "ALTER NAMED COLLECTION `s3_data` SET `secret_access_key` = '[REDACTED]' DELETE `format`;",
+		},
+		{
+			name:  "Grant on named collection has no secrets",
+			query: build(querybuilder.GrantPrivilege("NAMED COLLECTION", "john").OnNamedCollection(&profileName)),
+			want:  "GRANT NAMED COLLECTION ON `profile1` TO `john`;",
+		},
+		{
+			name:  "Query without secrets",
+			query: build(querybuilder.NewCreateRole("admin").WithCluster(&clusterName)),
+			want:  build(querybuilder.NewCreateRole("admin").WithCluster(&clusterName)),
+		},
+		{
+			name:  "Order by is not an identification clause",
+			query: "SELECT name FROM system.users WHERE name = 'john' ORDER BY name",
+			want:  "SELECT name FROM system.users WHERE name = 'john' ORDER BY name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RedactQuery(tt.query)
+
+			for _, secret := range tt.secrets {
+				if strings.Contains(got, secret) {
+					t.Errorf("RedactQuery() = %q, contains secret %q", got, secret)
+				}
+			}
+
+			if tt.want != "" && got != tt.want {
+				t.Errorf("RedactQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
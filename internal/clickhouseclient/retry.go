@@ -0,0 +1,225 @@
+package clickhouseclient
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pingcap/errors"
+)
+
+// ServerError is returned when ClickHouse, or a proxy in front of it, replies to a query with an error.
+type ServerError struct {
+	// Code is the ClickHouse error code, 0 when unknown.
+	Code int32
+	// StatusCode is the HTTP status code of the response, 0 for the native protocol.
+	StatusCode int
+	Message    string
+}
+
+func (e *ServerError) Error() string {
+	return e.Message
+}
+
+// retryableErrorCodes are ClickHouse error codes for transient failures, where running the same query again later is
+// expected to succeed.
+// See https://github.com/ClickHouse/ClickHouse/blob/master/src/Common/ErrorCodes.cpp
+var retryableErrorCodes = map[int32]string{
+	32:  "ATTEMPT_TO_READ_AFTER_EOF",
+	159: "TIMEOUT_EXCEEDED",
+	202: "TOO_MANY_SIMULTANEOUS_QUERIES",
+	203: "NO_FREE_CONNECTION",
+	209: "SOCKET_TIMEOUT",
+	210: "NETWORK_ERROR",
+	225: "NO_ZOOKEEPER",
+	236: "ABORTED",
+	242: "TABLE_IS_READ_ONLY",
+	319: "UNKNOWN_STATUS_OF_INSERT",
+	473: "DEADLOCK_AVOIDED",
+	999: "KEEPER_EXCEPTION",
+}
+
+// notStartedErrorCodes are ClickHouse error codes returned when a query is refused before it starts running.
+var notStartedErrorCodes = map[int32]string{
+	202: "TOO_MANY_SIMULTANEOUS_QUERIES",
+}
+
+// retryableStatusCodes are HTTP status codes usually returned by load balancers when the backend is not available.
+var retryableStatusCodes = map[int]bool{
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// IsRetryable returns true if err is caused by a transient condition, such as a network failure or an overloaded
+// server, and false for errors that would happen again, like a syntax error or missing privileges.
+func IsRetryable(err error) bool {
+	for err != nil {
+		// Hitting the query timeout or being cancelled is not transient, running the query again would just wait again.
+		if stderrors.Is(err, context.DeadlineExceeded) || stderrors.Is(err, context.Canceled) {
+			return false
+		}
+
+		var serverError *ServerError
+		if stderrors.As(err, &serverError) {
+			if _, ok := retryableErrorCodes[serverError.Code]; ok {
+				return true
+			}
+
+			return serverError.Code == 0 && retryableStatusCodes[serverError.StatusCode]
+		}
+
+		var exception *clickhouse.Exception
+		if stderrors.As(err, &exception) {
+			_, ok := retryableErrorCodes[exception.Code]
+			return ok
+		}
+
+		if stderrors.Is(err, clickhouse.ErrAcquireConnTimeout) || stderrors.Is(err, io.EOF) || stderrors.Is(err, io.ErrUnexpectedEOF) || stderrors.Is(err, syscall.ECONNRESET) || stderrors.Is(err, syscall.ECONNREFUSED) || stderrors.Is(err, syscall.EPIPE) {
+			return true
+		}
+
+		var netError net.Error
+		if stderrors.As(err, &netError) && netError.Timeout() {
+			return true
+		}
+
+		// pingcap/errors wrappers don't implement Unwrap, so walk the chain of causes manually.
+		causer, ok := err.(interface{ Cause() error })
+		if !ok {
+			return false
+		}
+		err = causer.Cause()
+	}
+
+	return false
+}
+
+// NeverRan returns true if err proves the query was not run by the server, like when the connection was refused or
+// the server was too busy to accept it. Statements that are not idempotent, like CREATE or GRANT, can only be run again
+// safely after such errors: after a timeout or a connection reset the server might have applied them already.
+func NeverRan(err error) bool {
+	for err != nil {
+		var serverError *ServerError
+		if stderrors.As(err, &serverError) {
+			if _, ok := notStartedErrorCodes[serverError.Code]; ok {
+				return true
+			}
+
+			// A load balancer without healthy backend replies 503 without forwarding the query.
+			return serverError.Code == 0 && serverError.StatusCode == http.StatusServiceUnavailable
+		}
+
+		var exception *clickhouse.Exception
+		if stderrors.As(err, &exception) {
+			_, ok := notStartedErrorCodes[exception.Code]
+			return ok
+		}
+
+		if stderrors.Is(err, clickhouse.ErrAcquireConnTimeout) || stderrors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+
+		causer, ok := err.(interface{ Cause() error })
+		if !ok {
+			return false
+		}
+		err = causer.Cause()
+	}
+
+	return false
+}
+
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of times a query is run, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the time waited before the first retry. It doubles on every following retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the time waited between two attempts.
+	MaxBackoff time.Duration
+}
+
+func (c *RetryConfig) ValidateConfig() (bool, []string) {
+	errors := make([]string, 0)
+	if c.MaxAttempts < 1 {
+		errors = append(errors, "MaxAttempts must be at least 1")
+	}
+	if c.InitialBackoff < 0 {
+		errors = append(errors, "InitialBackoff cannot be negative")
+	}
+	if c.MaxBackoff < c.InitialBackoff {
+		errors = append(errors, "MaxBackoff cannot be lower than InitialBackoff")
+	}
+
+	return len(errors) == 0, errors
+}
+
+// retryClient is a ClickhouseClient that runs queries again on the wrapped client when they fail with a retryable error.
+type retryClient struct {
+	client ClickhouseClient
+	config RetryConfig
+}
+
+func NewRetryClient(client ClickhouseClient, config RetryConfig) (ClickhouseClient, error) {
+	valid, errorStrings := config.ValidateConfig()
+	if !valid {
+		return nil, errors.New(errorStrings[0])
+	}
+
+	return &retryClient{
+		client: client,
+		config: config,
+	}, nil
+}
+
+func (r *retryClient) Select(ctx context.Context, qry string, callback func(Row) error) error {
+	return r.retry(ctx, func() (bool, error) {
+		called := false
+		err := r.client.Select(ctx, qry, func(row Row) error {
+			called = true
+			return callback(row)
+		})
+
+		// Once rows were passed to the callback, running the query again would hand them over a second time.
+		return !called, err
+	})
+}
+
+func (r *retryClient) Exec(ctx context.Context, qry string) error {
+	return r.retry(ctx, func() (bool, error) {
+		// Statements like CREATE or GRANT are not idempotent, so they are only run again when they never reached the server.
+		err := r.client.Exec(ctx, qry)
+		return NeverRan(err), err
+	})
+}
+
+// retry runs fn until it succeeds, fails with a non retryable error, or MaxAttempts is reached.
+// fn returns whether it is safe to run the query again and the query error.
+func (r *retryClient) retry(ctx context.Context, fn func() (bool, error)) error {
+	backoff := r.config.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		safe, err := fn()
+		if err == nil || !safe || attempt >= r.config.MaxAttempts || !IsRetryable(err) {
+			return err
+		}
+
+		// Full jitter, so that many resources failing at the same time don't retry all together.
+		wait := time.Duration(rand.Int64N(int64(backoff) + 1)) //nolint:gosec
+		tflog.Warn(ctx, "Query failed with a retryable error", map[string]any{"attempt": attempt, "wait": wait.String(), "error": err.Error()})
+
+		select {
+		case <-ctx.Done():
+			return errors.WithMessage(err, ctx.Err().Error())
+		case <-time.After(wait):
+		}
+
+		backoff = min(backoff*2, r.config.MaxBackoff)
+	}
+}
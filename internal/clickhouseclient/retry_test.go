@@ -0,0 +1,288 @@
+package clickhouseclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/pingcap/errors"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "Too many simultaneous queries over HTTP",
+			err:  errors.WithMessage(&ServerError{Code: 202, StatusCode: http.StatusInternalServerError, Message: "Code: 202. DB::Exception: Too many simultaneous queries"}, "error running query"),
+			want: true,
+		},
+		{
+			name: "Keeper exception over native",
+			err:  errors.WithMessage(&clickhouse.Exception{Code: 999, Message: "Session expired"}, "error executing query"),
+			want: true,
+		},
+		{
+			name: "Syntax error",
+			err:  errors.WithMessage(&ServerError{Code: 62, StatusCode: http.StatusBadRequest, Message: "Code: 62. DB::Exception: Syntax error"}, "error running query"),
+			want: false,
+		},
+		{
+			name: "Access denied over native",
+			err:  &clickhouse.Exception{Code: 497, Message: "Not enough privileges"},
+			want: false,
+		},
+		{
+			name: "Service unavailable from load balancer",
+			err:  &ServerError{StatusCode: http.StatusServiceUnavailable, Message: "no healthy upstream"},
+			want: true,
+		},
+		{
+			name: "ClickHouse error with 503 status",
+			err:  &ServerError{Code: 62, StatusCode: http.StatusServiceUnavailable, Message: "Code: 62. DB::Exception: Syntax error"},
+			want: false,
+		},
+		{
+			name: "Connection reset",
+			err:  errors.WithMessage(&net.OpError{Op: "read", Err: syscall.ECONNRESET}, "error executing query"),
+			want: true,
+		},
+		{
+			name: "Wrapped with fmt",
+			err:  fmt.Errorf("wrapped: %w", syscall.ECONNREFUSED),
+			want: true,
+		},
+		{
+			name: "Query timeout",
+			err:  errors.WithMessage(&url.Error{Op: "Post", URL: "http://localhost:8123/", Err: context.DeadlineExceeded}, "error executing query"),
+			want: false,
+		},
+		{
+			name: "Generic error",
+			err:  errors.New("something went wrong"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeClient struct {
+	errors []error
+	rows   []Row
+	calls  int
+}
+
+func (f *fakeClient) next() error {
+	f.calls++
+	if f.calls <= len(f.errors) {
+		return f.errors[f.calls-1]
+	}
+
+	return nil
+}
+
+func (f *fakeClient) Select(ctx context.Context, qry string, callback func(Row) error) error {
+	for _, row := range f.rows {
+		if err := callback(row); err != nil {
+			return err
+		}
+	}
+
+	return f.next()
+}
+
+func (f *fakeClient) Exec(ctx context.Context, qry string) error {
+	return f.next()
+}
+
+func TestNeverRan(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "Too many simultaneous queries over HTTP",
+			err:  errors.WithMessage(&ServerError{Code: 202, StatusCode: http.StatusInternalServerError, Message: "Code: 202. DB::Exception: Too many simultaneous queries"}, "error running query"),
+			want: true,
+		},
+		{
+			name: "Table is read only",
+			err:  errors.WithMessage(&ServerError{Code: 242, StatusCode: http.StatusInternalServerError, Message: "Code: 242. DB::Exception: Table is in readonly mode"}, "error running query"),
+			want: false,
+		},
+		{
+			name: "Keeper exception over native",
+			err:  errors.WithMessage(&clickhouse.Exception{Code: 999, Message: "Session expired"}, "error executing query"),
+			want: false,
+		},
+		{
+			name: "Service unavailable from load balancer",
+			err:  &ServerError{StatusCode: http.StatusServiceUnavailable, Message: "no healthy upstream"},
+			want: true,
+		},
+		{
+			name: "Gateway timeout from load balancer",
+			err:  &ServerError{StatusCode: http.StatusGatewayTimeout, Message: "upstream request timeout"},
+			want: false,
+		},
+		{
+			name: "Connection refused",
+			err:  errors.WithMessage(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, "error executing query"),
+			want: true,
+		},
+		{
+			name: "Connection reset",
+			err:  errors.WithMessage(&net.OpError{Op: "read", Err: syscall.ECONNRESET}, "error executing query"),
+			want: false,
+		},
+		{
+			name: "No free connection in the native pool",
+			err:  errors.WithMessage(clickhouse.ErrAcquireConnTimeout, "error executing query"),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NeverRan(tt.err); got != tt.want {
+				t.Errorf("NeverRan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryClient(t *testing.T) {
+	retryable := &ServerError{Code: 202, Message: "Too many simultaneous queries"}
+	fatal := &ServerError{Code: 62, Message: "Syntax error"}
+
+	tests := []struct {
+		name      string
+		client    *fakeClient
+		useSelect bool
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name:      "Exec succeeds after retryable errors",
+			client:    &fakeClient{errors: []error{retryable, retryable}},
+			wantCalls: 3,
+		},
+		{
+			name:      "Exec gives up after max attempts",
+			client:    &fakeClient{errors: []error{retryable, retryable, retryable}},
+			wantErr:   true,
+			wantCalls: 3,
+		},
+		{
+			name:      "Exec does not retry errors after the statement reached the server",
+			client:    &fakeClient{errors: []error{&clickhouse.Exception{Code: 999, Message: "Session expired"}}},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:      "Exec does not retry connection resets",
+			client:    &fakeClient{errors: []error{&net.OpError{Op: "read", Err: syscall.ECONNRESET}}},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:      "Exec retries refused connections",
+			client:    &fakeClient{errors: []error{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}},
+			wantCalls: 2,
+		},
+		{
+			name:      "Select retries errors after the query reached the server",
+			client:    &fakeClient{errors: []error{&clickhouse.Exception{Code: 999, Message: "Session expired"}}},
+			useSelect: true,
+			wantCalls: 2,
+		},
+		{
+			name:      "Exec does not retry fatal errors",
+			client:    &fakeClient{errors: []error{fatal}},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:      "Select retries before any row is returned",
+			client:    &fakeClient{errors: []error{retryable}},
+			useSelect: true,
+			wantCalls: 2,
+		},
+		{
+			name:      "Select does not retry after rows were returned",
+			client:    &fakeClient{errors: []error{retryable}, rows: []Row{{}}},
+			useSelect: true,
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewRetryClient(tt.client, RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+			if err != nil {
+				t.Fatalf("NewRetryClient() error = %v", err)
+			}
+
+			if tt.useSelect {
+				err = client.Select(context.Background(), "SELECT 1", func(Row) error { return nil })
+			} else {
+				err = client.Exec(context.Background(), "SELECT 1")
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.client.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", tt.client.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestExceptionCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		body   string
+		want   int32
+	}{
+		{
+			name:   "From header",
+			header: http.Header{"X-Clickhouse-Exception-Code": []string{"202"}},
+			body:   "Code: 202. DB::Exception: Too many simultaneous queries",
+			want:   202,
+		},
+		{
+			name:   "From body",
+			header: http.Header{},
+			body:   "Code: 999. Coordination::Exception: Session expired",
+			want:   999,
+		},
+		{
+			name:   "Not a ClickHouse error",
+			header: http.Header{},
+			body:   "<html>503 Service Unavailable</html>",
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exceptionCode(tt.header, []byte(tt.body)); got != tt.want {
+				t.Errorf("exceptionCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package clickhouseclient
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/pingcap/errors"
 )
@@ -17,11 +18,12 @@ func (r *Row) GetString(fieldName string) (string, error) {
 		return "", errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
 	}
 
-	if reflect.TypeOf(val).Name() != "string" {
-		return "", errors.New(fmt.Sprintf("field %s is not a string", fieldName))
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	default:
+		return "", errors.New(fmt.Sprintf("field %s is not a string (%s)", fieldName, typeName(val)))
 	}
-
-	return val.(string), nil
 }
 
 func (r *Row) GetNullableString(fieldName string) (*string, error) {
@@ -30,11 +32,12 @@ func (r *Row) GetNullableString(fieldName string) (*string, error) {
 		return nil, errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
 	}
 
-	if reflect.TypeOf(val).String() != "*string" {
-		return nil, errors.New(fmt.Sprintf("field %s is not a string pointer (%s)", fieldName, reflect.TypeOf(val).String()))
+	switch v := val.(type) {
+	case *string:
+		return v, nil
+	default:
+		return nil, errors.New(fmt.Sprintf("field %s is not a string pointer (%s)", fieldName, typeName(val)))
 	}
-
-	return val.(*string), nil
 }
 
 func (r *Row) GetBool(fieldName string) (bool, error) {
@@ -43,19 +46,19 @@ func (r *Row) GetBool(fieldName string) (bool, error) {
 		return false, errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
 	}
 
-	switch reflect.TypeOf(val).String() {
-	case "bool":
-		return val.(bool), nil
-	case "uint8":
-		if val.(uint8) == 0 {
+	switch v := val.(type) {
+	case bool:
+		return v, nil
+	case uint8:
+		if v == 0 {
 			return false, nil
 		}
-		if val.(uint8) == 1 {
+		if v == 1 {
 			return true, nil
 		}
 	}
 
-	return false, errors.New(fmt.Sprintf("unable to get field %s as bool: (%s)", fieldName, reflect.TypeOf(val).String()))
+	return false, errors.New(fmt.Sprintf("unable to get field %s as bool: (%s)", fieldName, typeName(val)))
 }
 
 func (r *Row) GetUInt64(fieldName string) (uint64, error) {
@@ -64,11 +67,140 @@ func (r *Row) GetUInt64(fieldName string) (uint64, error) {
 		return 0, errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
 	}
 
-	if reflect.TypeOf(val).Name() != "uint64" {
-		return 0, errors.New(fmt.Sprintf("field %s is not a uint64 (%s)", fieldName, reflect.TypeOf(val).Name()))
+	switch v := val.(type) {
+	case uint64:
+		return v, nil
+	case uint32:
+		return uint64(v), nil
+	case uint16:
+		return uint64(v), nil
+	case uint8:
+		return uint64(v), nil
+	}
+
+	return 0, errors.New(fmt.Sprintf("field %s is not a uint64 (%s)", fieldName, typeName(val)))
+}
+
+func (r *Row) GetNullableUInt64(fieldName string) (*uint64, error) {
+	val, ok := r.data[fieldName]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
+	}
+
+	var ret uint64
+	switch v := val.(type) {
+	case *uint64:
+		return v, nil
+	case *uint32:
+		if v == nil {
+			return nil, nil
+		}
+		ret = uint64(*v)
+	case *uint16:
+		if v == nil {
+			return nil, nil
+		}
+		ret = uint64(*v)
+	case *uint8:
+		if v == nil {
+			return nil, nil
+		}
+		ret = uint64(*v)
+	default:
+		return nil, errors.New(fmt.Sprintf("field %s is not a uint64 pointer (%s)", fieldName, typeName(val)))
+	}
+
+	return &ret, nil
+}
+
+func (r *Row) GetInt64(fieldName string) (int64, error) {
+	val, ok := r.data[fieldName]
+	if !ok {
+		return 0, errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
+	}
+
+	switch v := val.(type) {
+	case int64:
+		return v, nil
+	case int32:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	}
+
+	return 0, errors.New(fmt.Sprintf("field %s is not an int64 (%s)", fieldName, typeName(val)))
+}
+
+func (r *Row) GetFloat64(fieldName string) (float64, error) {
+	val, ok := r.data[fieldName]
+	if !ok {
+		return 0, errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
+	}
+
+	ret, ok := val.(float64)
+	if !ok {
+		return 0, errors.New(fmt.Sprintf("field %s is not a float64 (%s)", fieldName, typeName(val)))
+	}
+
+	return ret, nil
+}
+
+func (r *Row) GetNullableFloat64(fieldName string) (*float64, error) {
+	val, ok := r.data[fieldName]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
+	}
+
+	ret, ok := val.(*float64)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("field %s is not a float64 pointer (%s)", fieldName, typeName(val)))
+	}
+
+	return ret, nil
+}
+
+func (r *Row) GetStringSlice(fieldName string) ([]string, error) {
+	val, ok := r.data[fieldName]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
+	}
+
+	ret, ok := val.([]string)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("field %s is not a string slice (%s)", fieldName, typeName(val)))
+	}
+
+	return ret, nil
+}
+
+func (r *Row) GetTime(fieldName string) (time.Time, error) {
+	val, ok := r.data[fieldName]
+	if !ok {
+		return time.Time{}, errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
+	}
+
+	ret, ok := val.(time.Time)
+	if !ok {
+		return time.Time{}, errors.New(fmt.Sprintf("field %s is not a time (%s)", fieldName, typeName(val)))
 	}
 
-	return val.(uint64), nil
+	return ret, nil
+}
+
+func (r *Row) GetNullableTime(fieldName string) (*time.Time, error) {
+	val, ok := r.data[fieldName]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("field %s was not found in row", fieldName))
+	}
+
+	ret, ok := val.(*time.Time)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("field %s is not a time pointer (%s)", fieldName, typeName(val)))
+	}
+
+	return ret, nil
 }
 
 func (r *Row) Set(fieldName string, val interface{}) {
@@ -77,3 +209,12 @@ func (r *Row) Set(fieldName string, val interface{}) {
 	}
 	r.data[fieldName] = val
 }
+
+// typeName returns the Go type of val for error messages, also when val is an untyped nil.
+func typeName(val interface{}) string {
+	if val == nil {
+		return "nil"
+	}
+
+	return reflect.TypeOf(val).String()
+}
@@ -0,0 +1,37 @@
+package clickhouseclient
+
+import (
+	"testing"
+)
+
+func TestRow_untypedNil(t *testing.T) {
+	row := Row{}
+	row.Set("value", nil)
+
+	if _, err := row.GetString("value"); err == nil {
+		t.Errorf("GetString() error = nil, want an error")
+	}
+	if _, err := row.GetNullableString("value"); err == nil {
+		t.Errorf("GetNullableString() error = nil, want an error")
+	}
+	if _, err := row.GetBool("value"); err == nil {
+		t.Errorf("GetBool() error = nil, want an error")
+	}
+}
+
+func TestRow_GetString(t *testing.T) {
+	s := "foo"
+	row := Row{}
+	row.Set("string", s)
+	row.Set("pointer", &s)
+
+	if got, err := row.GetString("string"); err != nil || got != s {
+		t.Errorf("GetString() = %q, %v, want %q", got, err, s)
+	}
+	if _, err := row.GetString("pointer"); err == nil {
+		t.Errorf("GetString() of a pointer error = nil, want an error")
+	}
+	if got, err := row.GetNullableString("pointer"); err != nil || got != &s {
+		t.Errorf("GetNullableString() = %v, %v, want %v", got, err, &s)
+	}
+}
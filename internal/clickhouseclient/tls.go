@@ -0,0 +1,50 @@
+package clickhouseclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/pingcap/errors"
+)
+
+// TLSOptions holds the user facing TLS settings shared by the native and HTTP clients.
+// Certificates and keys are PEM encoded strings.
+type TLSOptions struct {
+	InsecureSkipVerify bool
+	CACert             string
+	ClientCert         string
+	ClientKey          string
+	ServerName         string
+}
+
+// NewTLSConfig builds a tls.Config out of the given options.
+func NewTLSConfig(options TLSOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ //nolint:gosec
+		InsecureSkipVerify: options.InsecureSkipVerify, //nolint:gosec
+		ServerName:         options.ServerName,
+	}
+
+	if options.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(options.CACert)) {
+			return nil, errors.New("unable to parse CA certificate, no valid PEM encoded certificate found")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if options.ClientCert != "" || options.ClientKey != "" {
+		if options.ClientCert == "" || options.ClientKey == "" {
+			return nil, errors.New("client certificate and client key must be set together")
+		}
+
+		cert, err := tls.X509KeyPair([]byte(options.ClientCert), []byte(options.ClientKey))
+		if err != nil {
+			return nil, errors.WithMessage(err, "unable to parse client certificate and key")
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,141 @@
+package clickhouseclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+
+	tests := []struct {
+		name    string
+		options TLSOptions
+		wantErr bool
+	}{
+		{
+			name:    "Empty options",
+			options: TLSOptions{},
+			wantErr: false,
+		},
+		{
+			name: "Insecure skip verify and server name",
+			options: TLSOptions{
+				InsecureSkipVerify: true,
+				ServerName:         "clickhouse.example.com",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Valid CA certificate",
+			options: TLSOptions{
+				CACert: certPEM,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid CA certificate",
+			options: TLSOptions{
+				CACert: "not a certificate",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid client certificate and key",
+			options: TLSOptions{
+				ClientCert: certPEM,
+				ClientKey:  keyPEM,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Client certificate without key",
+			options: TLSOptions{
+				ClientCert: certPEM,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Client key without certificate",
+			options: TLSOptions{
+				ClientKey: keyPEM,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Mismatched client certificate and key",
+			options: TLSOptions{
+				ClientCert: certPEM,
+				ClientKey:  certPEM,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTLSConfig(tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTLSConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.InsecureSkipVerify != tt.options.InsecureSkipVerify {
+				t.Errorf("NewTLSConfig() InsecureSkipVerify = %v, want %v", got.InsecureSkipVerify, tt.options.InsecureSkipVerify)
+			}
+
+			if got.ServerName != tt.options.ServerName {
+				t.Errorf("NewTLSConfig() ServerName = %q, want %q", got.ServerName, tt.options.ServerName)
+			}
+
+			if (got.RootCAs != nil) != (tt.options.CACert != "") {
+				t.Errorf("NewTLSConfig() RootCAs = %v, want CA cert to be set: %v", got.RootCAs, tt.options.CACert != "")
+			}
+
+			if (len(got.Certificates) == 1) != (tt.options.ClientCert != "") {
+				t.Errorf("NewTLSConfig() Certificates = %d, want client certificate to be set: %v", len(got.Certificates), tt.options.ClientCert != "")
+			}
+		})
+	}
+}
+
+// generateCertificate returns a self-signed PEM encoded certificate and its private key.
+func generateCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	return string(certPEM), string(keyPEM)
+}
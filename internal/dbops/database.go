@@ -25,11 +25,15 @@ func (i *impl) CreateDatabase(ctx context.Context, database Database, clusterNam
 		return nil, errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
-	if err != nil {
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil && !createdOnMissingReplicas(err, errorCodeDatabaseAlreadyExists) {
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	if i.dryRun {
+		return nil, nil
+	}
+
 	return i.FindDatabaseByName(ctx, database.Name, clusterName)
 }
 
@@ -88,7 +92,7 @@ func (i *impl) DeleteDatabase(ctx context.Context, uuid string, clusterName *str
 		return errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return errors.WithMessage(err, "error running query")
 	}
@@ -125,3 +129,51 @@ func (i *impl) FindDatabaseByName(ctx context.Context, name string, clusterName
 
 	return i.GetDatabase(ctx, uuid, clusterName)
 }
+
+// ListDatabases returns all databases defined in system.databases, sorted by name.
+func (i *impl) ListDatabases(ctx context.Context, clusterName *string) ([]Database, error) {
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{querybuilder.NewField("uuid").ToString(), querybuilder.NewField("name"), querybuilder.NewField("comment")},
+		"system.databases",
+	).WithCluster(clusterName).OrderBy(querybuilder.NewField("name"), querybuilder.ASC).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	ret := make([]Database, 0)
+	seen := make(map[string]bool)
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		uuid, err := data.GetString("uuid")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'uuid' field")
+		}
+		name, err := data.GetString("name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'name' field")
+		}
+		comment, err := data.GetString("comment")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'comment' field")
+		}
+
+		// When querying a cluster, the same database is returned once per replica.
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+
+		ret = append(ret, Database{
+			UUID:    uuid,
+			Name:    name,
+			Comment: comment,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,245 @@
+package dbops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/querybuilder"
+)
+
+// functionOriginSQLUserDefined is the origin of functions created with CREATE FUNCTION in system.functions.
+const functionOriginSQLUserDefined = "SQLUserDefined"
+
+// Function is a SQL user defined function, defined as the (Parameters) -> Body lambda.
+type Function struct {
+	Name       string   `json:"name" ch:"name"`
+	Parameters []string `json:"-"`
+	// Body is the expression of the function, formatted by ClickHouse when read from system.functions.
+	Body string `json:"-"`
+}
+
+func (i *impl) CreateFunction(ctx context.Context, function Function, clusterName *string) (*Function, error) {
+	sql, err := querybuilder.NewCreateFunction(function.Name, function.Parameters, function.Body).WithCluster(clusterName).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil && !createdOnMissingReplicas(err, errorCodeFunctionAlreadyExists) {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if i.dryRun {
+		return nil, nil
+	}
+
+	return i.GetFunction(ctx, function.Name, clusterName)
+}
+
+// GetFunction returns the SQL user defined function called name, or nil if there is none.
+func (i *impl) GetFunction(ctx context.Context, name string, clusterName *string) (*Function, error) {
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{querybuilder.NewField("create_query")},
+		"system.functions",
+	).WithCluster(clusterName).Where(querybuilder.AndWhere(
+		querybuilder.WhereEquals("name", name),
+		querybuilder.WhereEquals("origin", functionOriginSQLUserDefined),
+	)).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	var function *Function
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		createQuery, err := data.GetString("create_query")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'create_query' field")
+		}
+
+		parameters, body, err := parseFunctionCreateQuery(createQuery)
+		if err != nil {
+			return errors.WithMessage(err, "error parsing function definition")
+		}
+
+		function = &Function{
+			Name:       name,
+			Parameters: parameters,
+			Body:       body,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	return function, nil
+}
+
+func (i *impl) DeleteFunction(ctx context.Context, name string, clusterName *string) error {
+	function, err := i.GetFunction(ctx, name, clusterName)
+	if err != nil {
+		return errors.WithMessage(err, "error getting function")
+	}
+
+	if function == nil {
+		// That's what we want.
+		return nil
+	}
+
+	sql, err := querybuilder.NewDropFunction(name).WithCluster(clusterName).Build()
+	if err != nil {
+		return errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil {
+		return errors.WithMessage(err, "error running query")
+	}
+
+	return nil
+}
+
+// FormatFunctionBody returns body formatted the way ClickHouse stores it in system.functions, so that it can be compared
+// with the body of an existing function.
+func (i *impl) FormatFunctionBody(ctx context.Context, parameters []string, body string) (string, error) {
+	err := i.CheckFeature(FeatureFormatQuery)
+	if err != nil {
+		return "", err
+	}
+
+	// The name doesn't matter, only the body is extracted from the formatted definition.
+	createQuery, err := querybuilder.NewCreateFunction("f", parameters, body).Build()
+	if err != nil {
+		return "", errors.WithMessage(err, "error building query")
+	}
+
+	sql, err := querybuilder.NewFormatQuery(createQuery).Build()
+	if err != nil {
+		return "", errors.WithMessage(err, "error building query")
+	}
+
+	var formatted string
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		query, err := data.GetString(querybuilder.FormatQueryColumn)
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'query' field")
+		}
+
+		_, formatted, err = parseFunctionCreateQuery(query)
+		if err != nil {
+			return errors.WithMessage(err, "error parsing function definition")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", errors.WithMessage(err, "error running query")
+	}
+
+	return formatted, nil
+}
+
+// parseFunctionCreateQuery extracts the parameters and the body from the create_query column of system.functions,
+// like `CREATE FUNCTION name AS (x, k, b) -> ((k * x) + b)`. Lambdas with a single parameter are formatted without
+// parentheses around it.
+func parseFunctionCreateQuery(createQuery string) ([]string, string, error) {
+	invalid := errors.New(fmt.Sprintf("unexpected function definition %q", createQuery))
+
+	rest := strings.TrimSpace(createQuery)
+	const prefix = "CREATE FUNCTION "
+	if len(rest) < len(prefix) || !strings.EqualFold(rest[:len(prefix)], prefix) {
+		return nil, "", invalid
+	}
+	rest = strings.TrimLeft(rest[len(prefix):], " ")
+
+	// Skip the function name.
+	_, n, ok := readIdentifier(rest)
+	if !ok {
+		return nil, "", invalid
+	}
+	rest = strings.TrimLeft(rest[n:], " ")
+
+	if len(rest) < 3 || !strings.EqualFold(rest[:3], "AS ") {
+		return nil, "", invalid
+	}
+	rest = strings.TrimLeft(rest[3:], " ")
+
+	parameters := make([]string, 0)
+	if strings.HasPrefix(rest, "(") {
+		rest = strings.TrimLeft(rest[1:], " ")
+		for !strings.HasPrefix(rest, ")") {
+			parameter, n, ok := readIdentifier(rest)
+			if !ok {
+				return nil, "", invalid
+			}
+			parameters = append(parameters, parameter)
+			rest = strings.TrimLeft(rest[n:], " ")
+
+			if strings.HasPrefix(rest, ",") {
+				rest = strings.TrimLeft(rest[1:], " ")
+			} else if !strings.HasPrefix(rest, ")") {
+				return nil, "", invalid
+			}
+		}
+		rest = rest[1:]
+	} else {
+		parameter, n, ok := readIdentifier(rest)
+		if !ok {
+			return nil, "", invalid
+		}
+		parameters = append(parameters, parameter)
+		rest = rest[n:]
+	}
+
+	rest = strings.TrimLeft(rest, " ")
+	if !strings.HasPrefix(rest, "->") {
+		return nil, "", invalid
+	}
+
+	body := strings.TrimSpace(rest[2:])
+	if body == "" {
+		return nil, "", invalid
+	}
+
+	return parameters, body, nil
+}
+
+// readIdentifier reads the identifier at the beginning of s, either bare or quoted with backticks or double quotes,
+// and returns its unquoted value and the number of bytes read.
+func readIdentifier(s string) (string, int, bool) {
+	if s == "" {
+		return "", 0, false
+	}
+
+	if s[0] == '`' || s[0] == '"' {
+		var sb strings.Builder
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case s[0]:
+				return sb.String(), i + 1, true
+			case '\\':
+				i++
+				if i == len(s) {
+					return "", 0, false
+				}
+				sb.WriteByte(s[i])
+			default:
+				sb.WriteByte(s[i])
+			}
+		}
+		return "", 0, false
+	}
+
+	n := 0
+	for n < len(s) && (s[n] == '_' || s[n] == '$' || s[n] >= '0' && s[n] <= '9' || s[n] >= 'a' && s[n] <= 'z' || s[n] >= 'A' && s[n] <= 'Z' || s[n] >= 0x80) {
+		n++
+	}
+
+	return s[:n], n, n > 0
+}
@@ -0,0 +1,73 @@
+package dbops
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseFunctionCreateQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		createQuery    string
+		wantParameters []string
+		wantBody       string
+		wantErr        bool
+	}{
+		{
+			name:           "Many parameters",
+			createQuery:    "CREATE FUNCTION linear_equation AS (x, k, b) -> ((k * x) + b)",
+			wantParameters: []string{"x", "k", "b"},
+			wantBody:       "((k * x) + b)",
+		},
+		{
+			name:           "Single parameter without parentheses",
+			createQuery:    "CREATE FUNCTION double AS x -> (x * 2)",
+			wantParameters: []string{"x"},
+			wantBody:       "(x * 2)",
+		},
+		{
+			name:           "No parameters",
+			createQuery:    "CREATE FUNCTION answer AS () -> 42",
+			wantParameters: []string{},
+			wantBody:       "42",
+		},
+		{
+			name:           "Quoted identifiers",
+			createQuery:    "CREATE FUNCTION `my func` AS (`a b`, c) -> concat(`a b`, ' -> ', c)",
+			wantParameters: []string{"a b", "c"},
+			wantBody:       "concat(`a b`, ' -> ', c)",
+		},
+		{
+			name:        "Not a function",
+			createQuery: "CREATE TABLE t (x UInt8) ENGINE = Memory",
+			wantErr:     true,
+		},
+		{
+			name:        "Missing body",
+			createQuery: "CREATE FUNCTION f AS (x) ->",
+			wantErr:     true,
+		},
+		{
+			name:        "Unterminated parameters",
+			createQuery: "CREATE FUNCTION f AS (x, y -> x",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parameters, body, err := parseFunctionCreateQuery(tt.createQuery)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFunctionCreateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(parameters, tt.wantParameters) {
+				t.Errorf("parseFunctionCreateQuery() parameters = %v, want %v", parameters, tt.wantParameters)
+			}
+			if body != tt.wantBody {
+				t.Errorf("parseFunctionCreateQuery() body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"github.com/pingcap/errors"
 
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/privileges"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/querybuilder"
 )
 
+// GrantPrivilege is a privilege granted to a user or a role.
+// For privileges granted on named collections, DatabaseName holds the name of the collection, as in system.grants.
 type GrantPrivilege struct {
 	AccessType      string  `json:"access_type"`
 	DatabaseName    *string `json:"database"`
@@ -17,6 +20,9 @@ type GrantPrivilege struct {
 	GranteeUserName *string `json:"user_name"`
 	GranteeRoleName *string `json:"role_name"`
 	GrantOption     bool    `json:"grant_option"`
+	// PartialRevoke is true for privileges revoked within a broader granted one, like SELECT ON secret.* revoked after
+	// SELECT ON *.* was granted. When GrantOption is true, only the grant option was revoked.
+	PartialRevoke bool `json:"is_partial_revoke"`
 }
 
 func (i *impl) GrantPrivilege(ctx context.Context, grantPrivilege GrantPrivilege, clusterName *string) (*GrantPrivilege, error) {
@@ -31,22 +37,32 @@ func (i *impl) GrantPrivilege(ctx context.Context, grantPrivilege GrantPrivilege
 		}
 	}
 
-	sql, err := querybuilder.GrantPrivilege(grantPrivilege.AccessType, to).
-		WithDatabase(grantPrivilege.DatabaseName).
-		WithTable(grantPrivilege.TableName).
-		WithColumn(grantPrivilege.ColumnName).
+	builder := querybuilder.GrantPrivilege(grantPrivilege.AccessType, to).
 		WithGrantOption(grantPrivilege.GrantOption).
-		WithCluster(clusterName).
-		Build()
+		WithCluster(clusterName)
+	if onNamedCollection(grantPrivilege.AccessType) {
+		builder = builder.OnNamedCollection(grantPrivilege.DatabaseName)
+	} else {
+		builder = builder.
+			WithDatabase(grantPrivilege.DatabaseName).
+			WithTable(grantPrivilege.TableName).
+			WithColumn(grantPrivilege.ColumnName)
+	}
+
+	sql, err := builder.Build()
 	if err != nil {
 		return nil, errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	if i.dryRun {
+		return nil, nil
+	}
+
 	return i.GetGrantPrivilege(ctx, grantPrivilege.AccessType, grantPrivilege.DatabaseName, grantPrivilege.TableName, grantPrivilege.ColumnName, grantPrivilege.GranteeUserName, grantPrivilege.GranteeRoleName, clusterName)
 }
 
@@ -154,6 +170,15 @@ func (i *impl) GetGrantPrivilege(ctx context.Context, accessType string, databas
 }
 
 func (i *impl) RevokeGrantPrivilege(ctx context.Context, accessType string, database *string, table *string, column *string, granteeUserName *string, granteeRoleName *string, clusterName *string) error {
+	return i.revokeGrantPrivilege(ctx, accessType, database, table, column, granteeUserName, granteeRoleName, clusterName, false)
+}
+
+// RevokeGrantOption revokes the grant option of a privilege, which stays granted to the grantee.
+func (i *impl) RevokeGrantOption(ctx context.Context, accessType string, database *string, table *string, column *string, granteeUserName *string, granteeRoleName *string, clusterName *string) error {
+	return i.revokeGrantPrivilege(ctx, accessType, database, table, column, granteeUserName, granteeRoleName, clusterName, true)
+}
+
+func (i *impl) revokeGrantPrivilege(ctx context.Context, accessType string, database *string, table *string, column *string, granteeUserName *string, granteeRoleName *string, clusterName *string, grantOptionOnly bool) error {
 	var from string
 	{
 		if granteeUserName != nil {
@@ -165,17 +190,22 @@ func (i *impl) RevokeGrantPrivilege(ctx context.Context, accessType string, data
 		}
 	}
 
-	sql, err := querybuilder.RevokePrivilege(accessType, from).
-		WithDatabase(database).
-		WithTable(table).
-		WithColumn(column).
-		WithCluster(clusterName).
-		Build()
+	builder := querybuilder.RevokePrivilege(accessType, from).WithCluster(clusterName)
+	if onNamedCollection(accessType) {
+		builder = builder.OnNamedCollection(database)
+	} else {
+		builder = builder.WithDatabase(database).WithTable(table).WithColumn(column)
+	}
+	if grantOptionOnly {
+		builder = builder.GrantOptionOnly()
+	}
+
+	sql, err := builder.Build()
 	if err != nil {
 		return errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return errors.WithMessage(err, "error running query")
 	}
@@ -204,6 +234,7 @@ func (i *impl) GetAllGrantsForGrantee(ctx context.Context, granteeUsername *stri
 		querybuilder.NewField("user_name"),
 		querybuilder.NewField("role_name"),
 		querybuilder.NewField("grant_option"),
+		querybuilder.NewField("is_partial_revoke"),
 	}, "system.grants").WithCluster(clusterName).Where(to).Build()
 	if err != nil {
 		return nil, errors.WithMessage(err, "error building query")
@@ -240,6 +271,10 @@ func (i *impl) GetAllGrantsForGrantee(ctx context.Context, granteeUsername *stri
 		if err != nil {
 			return errors.WithMessage(err, "error scanning query result, missing 'grant_option' field")
 		}
+		partialRevoke, err := data.GetBool("is_partial_revoke")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'is_partial_revoke' field")
+		}
 
 		ret = append(ret, GrantPrivilege{
 			AccessType:      accessType,
@@ -249,6 +284,7 @@ func (i *impl) GetAllGrantsForGrantee(ctx context.Context, granteeUsername *stri
 			GranteeUserName: granteeUserName,
 			GranteeRoleName: granteeRoleName,
 			GrantOption:     grantOption,
+			PartialRevoke:   partialRevoke,
 		})
 
 		return nil
@@ -259,3 +295,8 @@ func (i *impl) GetAllGrantsForGrantee(ctx context.Context, granteeUsername *stri
 
 	return ret, nil
 }
+
+// onNamedCollection tells if accessType is granted on named collections rather than on databases and tables.
+func onNamedCollection(accessType string) bool {
+	return privileges.ParseGrants().Scopes[accessType] == "NAMED_COLLECTION"
+}
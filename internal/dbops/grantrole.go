@@ -33,11 +33,15 @@ func (i *impl) GrantRole(ctx context.Context, grantRole GrantRole, clusterName *
 		return nil, errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	if i.dryRun {
+		return nil, nil
+	}
+
 	return i.GetGrantRole(ctx, grantRole.RoleName, grantRole.GranteeUserName, grantRole.GranteeRoleName, clusterName)
 }
 
@@ -123,10 +127,127 @@ func (i *impl) RevokeGrantRole(ctx context.Context, grantedRoleName string, gran
 		return errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return errors.WithMessage(err, "error running query")
 	}
 
 	return nil
 }
+
+// GetAllGrantRolesForGrantee returns all the roles directly granted to the given user or role, as found in system.role_grants.
+func (i *impl) GetAllGrantRolesForGrantee(ctx context.Context, granteeUserName *string, granteeRoleName *string, clusterName *string) ([]GrantRole, error) {
+	var granteeWhere querybuilder.Where
+	{
+		if granteeUserName != nil {
+			granteeWhere = querybuilder.WhereEquals("user_name", *granteeUserName)
+		} else if granteeRoleName != nil {
+			granteeWhere = querybuilder.WhereEquals("role_name", *granteeRoleName)
+		} else {
+			return nil, errors.New("either GranteeUserName or GranteeRoleName must be set")
+		}
+	}
+
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{
+			querybuilder.NewField("granted_role_name"),
+			querybuilder.NewField("user_name"),
+			querybuilder.NewField("role_name"),
+			querybuilder.NewField("with_admin_option"),
+		},
+		"system.role_grants").
+		WithCluster(clusterName).
+		Where(granteeWhere).
+		Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	ret := make([]GrantRole, 0)
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		roleName, err := data.GetString("granted_role_name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'granted_role_name' field")
+		}
+		granteeUserName, err := data.GetNullableString("user_name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'user_name' field")
+		}
+		granteeRoleName, err := data.GetNullableString("role_name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'role_name' field")
+		}
+		adminOption, err := data.GetBool("with_admin_option")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'with_admin_option' field")
+		}
+
+		ret = append(ret, GrantRole{
+			RoleName:        roleName,
+			GranteeUserName: granteeUserName,
+			GranteeRoleName: granteeRoleName,
+			AdminOption:     adminOption,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	return ret, nil
+}
+
+// GetAllGrantRolesForRole returns all the users and roles the given role is granted to, as found in system.role_grants.
+func (i *impl) GetAllGrantRolesForRole(ctx context.Context, grantedRoleName string, clusterName *string) ([]GrantRole, error) {
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{
+			querybuilder.NewField("granted_role_name"),
+			querybuilder.NewField("user_name"),
+			querybuilder.NewField("role_name"),
+			querybuilder.NewField("with_admin_option"),
+		},
+		"system.role_grants").
+		WithCluster(clusterName).
+		Where(querybuilder.WhereEquals("granted_role_name", grantedRoleName)).
+		Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	ret := make([]GrantRole, 0)
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		roleName, err := data.GetString("granted_role_name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'granted_role_name' field")
+		}
+		granteeUserName, err := data.GetNullableString("user_name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'user_name' field")
+		}
+		granteeRoleName, err := data.GetNullableString("role_name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'role_name' field")
+		}
+		adminOption, err := data.GetBool("with_admin_option")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'with_admin_option' field")
+		}
+
+		ret = append(ret, GrantRole{
+			RoleName:        roleName,
+			GranteeUserName: granteeUserName,
+			GranteeRoleName: granteeRoleName,
+			AdminOption:     adminOption,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	return ret, nil
+}
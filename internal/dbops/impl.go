@@ -1,15 +1,38 @@
 package dbops
 
 import (
+	"context"
+
+	"github.com/pingcap/errors"
+
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
 )
 
 type impl struct {
 	clickhouseClient clickhouseclient.ClickhouseClient
+	serverVersion    *Version
+	// dryRun is true when statements are only recorded, entities created or updated are then not read back.
+	dryRun bool
 }
 
-func NewClient(clickhouseClient clickhouseclient.ClickhouseClient) (Client, error) {
-	return &impl{
+// NewClient returns a Client using clickhouseClient to run queries. The server version is detected right away, so
+// that features not supported by the server can be reported before running any statement.
+func NewClient(ctx context.Context, clickhouseClient clickhouseclient.ClickhouseClient) (Client, error) {
+	i := &impl{
 		clickhouseClient: clickhouseClient,
-	}, nil
+		dryRun:           clickhouseclient.IsDryRun(clickhouseClient),
+	}
+
+	serverVersion, err := i.detectServerVersion(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error detecting ClickHouse server version")
+	}
+
+	i.serverVersion = serverVersion
+
+	return i, nil
+}
+
+func (i *impl) DryRun() bool {
+	return i.dryRun
 }
@@ -9,26 +9,32 @@ type Client interface {
 	GetDatabase(ctx context.Context, uuid string, clusterName *string) (*Database, error)
 	DeleteDatabase(ctx context.Context, uuid string, clusterName *string) error
 	FindDatabaseByName(ctx context.Context, name string, clusterName *string) (*Database, error)
+	ListDatabases(ctx context.Context, clusterName *string) ([]Database, error)
 
 	CreateRole(ctx context.Context, role Role, clusterName *string) (*Role, error)
 	GetRole(ctx context.Context, id string, clusterName *string) (*Role, error)
 	DeleteRole(ctx context.Context, id string, clusterName *string) error
 	FindRoleByName(ctx context.Context, name string, clusterName *string) (*Role, error)
 	UpdateRole(ctx context.Context, role Role, clusterName *string) (*Role, error)
+	ListRoles(ctx context.Context, clusterName *string) ([]Role, error)
 
 	CreateUser(ctx context.Context, user User, clusterName *string) (*User, error)
 	GetUser(ctx context.Context, id string, clusterName *string) (*User, error)
 	DeleteUser(ctx context.Context, id string, clusterName *string) error
 	FindUserByName(ctx context.Context, name string, clusterName *string) (*User, error)
 	UpdateUser(ctx context.Context, user User, clusterName *string) (*User, error)
+	ListUsers(ctx context.Context, clusterName *string) ([]User, error)
 
 	GrantRole(ctx context.Context, grantRole GrantRole, clusterName *string) (*GrantRole, error)
 	GetGrantRole(ctx context.Context, grantedRoleName string, granteeUserName *string, granteeRoleName *string, clusterName *string) (*GrantRole, error)
 	RevokeGrantRole(ctx context.Context, grantedRoleName string, granteeUserName *string, granteeRoleName *string, clusterName *string) error
+	GetAllGrantRolesForGrantee(ctx context.Context, granteeUserName *string, granteeRoleName *string, clusterName *string) ([]GrantRole, error)
+	GetAllGrantRolesForRole(ctx context.Context, grantedRoleName string, clusterName *string) ([]GrantRole, error)
 
 	GrantPrivilege(ctx context.Context, grantPrivilege GrantPrivilege, clusterName *string) (*GrantPrivilege, error)
 	GetGrantPrivilege(ctx context.Context, accessType string, database *string, table *string, column *string, granteeUserName *string, granteeRoleName *string, clusterName *string) (*GrantPrivilege, error)
 	RevokeGrantPrivilege(ctx context.Context, accessType string, database *string, table *string, column *string, granteeUserName *string, granteeRoleName *string, clusterName *string) error
+	RevokeGrantOption(ctx context.Context, accessType string, database *string, table *string, column *string, granteeUserName *string, granteeRoleName *string, clusterName *string) error
 	GetAllGrantsForGrantee(ctx context.Context, granteeUsername *string, granteeRoleName *string, clusterName *string) ([]GrantPrivilege, error)
 
 	CreateSettingsProfile(ctx context.Context, profile SettingsProfile, clusterName *string) (*SettingsProfile, error)
@@ -43,5 +49,40 @@ type Client interface {
 	GetSetting(ctx context.Context, settingsProfileID string, name string, clusterName *string) (*Setting, error)
 	DeleteSetting(ctx context.Context, settingsProfileID string, name string, clusterName *string) error
 
+	CreateRowPolicy(ctx context.Context, rowPolicy RowPolicy, clusterName *string) (*RowPolicy, error)
+	GetRowPolicy(ctx context.Context, id string, clusterName *string) (*RowPolicy, error)
+	DeleteRowPolicy(ctx context.Context, id string, clusterName *string) error
+	FindRowPolicyByName(ctx context.Context, fullName string, clusterName *string) (*RowPolicy, error)
+	UpdateRowPolicy(ctx context.Context, rowPolicy RowPolicy, clusterName *string) (*RowPolicy, error)
+
+	CreateQuota(ctx context.Context, quota Quota, clusterName *string) (*Quota, error)
+	GetQuota(ctx context.Context, id string, clusterName *string) (*Quota, error)
+	DeleteQuota(ctx context.Context, id string, clusterName *string) error
+	FindQuotaByName(ctx context.Context, name string, clusterName *string) (*Quota, error)
+	UpdateQuota(ctx context.Context, quota Quota, clusterName *string) (*Quota, error)
+
+	CreateFunction(ctx context.Context, function Function, clusterName *string) (*Function, error)
+	GetFunction(ctx context.Context, name string, clusterName *string) (*Function, error)
+	DeleteFunction(ctx context.Context, name string, clusterName *string) error
+	FormatFunctionBody(ctx context.Context, parameters []string, body string) (string, error)
+
+	CreateNamedCollection(ctx context.Context, namedCollection NamedCollection, clusterName *string) (*NamedCollection, error)
+	GetNamedCollection(ctx context.Context, name string, clusterName *string) (*NamedCollection, error)
+	UpdateNamedCollection(ctx context.Context, name string, set map[string]string, remove []string, clusterName *string) (*NamedCollection, error)
+	DeleteNamedCollection(ctx context.Context, name string, clusterName *string) error
+
+	CreateTable(ctx context.Context, table Table, clusterName *string) (*Table, error)
+	GetTable(ctx context.Context, database string, name string, clusterName *string) (*Table, error)
+	UpdateTable(ctx context.Context, current Table, desired Table, clusterName *string) (*Table, error)
+	DeleteTable(ctx context.Context, database string, name string, clusterName *string) error
+
 	IsReplicatedStorage(ctx context.Context) (bool, error)
+
+	FindReplicaDrift(ctx context.Context, table string, name string, clusterName string) (*ReplicaDrift, error)
+	FindTableReplicaDrift(ctx context.Context, database string, name string, clusterName string) (*ReplicaDrift, error)
+
+	CheckFeature(feature Feature) error
+
+	// DryRun returns true when statements are recorded without being run, Create and Update methods then return nil.
+	DryRun() bool
 }
@@ -0,0 +1,149 @@
+package dbops
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pingcap/errors"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/querybuilder"
+)
+
+// NamedCollection is a set of key-value pairs, usually connection settings and credentials for integrations.
+type NamedCollection struct {
+	Name string `json:"name" ch:"name"`
+	// Values are the values used to create collections. When read back, only the values ClickHouse shows are set:
+	// values are hidden unless the user has the show_named_collections_secrets privilege and the
+	// format_display_secrets_in_show_and_select setting is enabled.
+	Values map[string]string `json:"-"`
+	// Keys is the sorted list of keys the collection holds, as read from system.named_collections.
+	Keys []string `json:"-"`
+}
+
+// hiddenValue is shown by ClickHouse in place of the values of named collections the user is not allowed to see.
+const hiddenValue = "[HIDDEN]"
+
+func (i *impl) CreateNamedCollection(ctx context.Context, namedCollection NamedCollection, clusterName *string) (*NamedCollection, error) {
+	sql, err := querybuilder.NewCreateNamedCollection(namedCollection.Name, namedCollection.Values).WithCluster(clusterName).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil && !createdOnMissingReplicas(err, errorCodeNamedCollectionAlreadyExists) {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if i.dryRun {
+		return nil, nil
+	}
+
+	return i.GetNamedCollection(ctx, namedCollection.Name, clusterName)
+}
+
+// GetNamedCollection returns the named collection called name, or nil if there is none.
+func (i *impl) GetNamedCollection(ctx context.Context, name string, clusterName *string) (*NamedCollection, error) {
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{querybuilder.NewMapKeysField("collection"), querybuilder.NewMapValuesField("collection", "collection_values")},
+		"system.named_collections",
+	).WithCluster(clusterName).Where(querybuilder.WhereEquals("name", name)).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	var namedCollection *NamedCollection
+	seen := make(map[string]bool)
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		keys, err := data.GetStringSlice("collection")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'collection' field")
+		}
+
+		values, err := data.GetStringSlice("collection_values")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'collection_values' field")
+		}
+
+		if len(values) != len(keys) {
+			return errors.New("error scanning query result, 'collection' and 'collection_values' have different lengths")
+		}
+
+		if namedCollection == nil {
+			namedCollection = &NamedCollection{
+				Name:   name,
+				Values: make(map[string]string),
+				Keys:   make([]string, 0),
+			}
+		}
+
+		// When querying a cluster, the same collection is returned once per replica.
+		for j, key := range keys {
+			if !seen[key] {
+				seen[key] = true
+				namedCollection.Keys = append(namedCollection.Keys, key)
+				if values[j] != hiddenValue {
+					namedCollection.Values[key] = values[j]
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if namedCollection != nil {
+		sort.Strings(namedCollection.Keys)
+	}
+
+	return namedCollection, nil
+}
+
+// UpdateNamedCollection sets the keys in set to their value and removes the keys in remove from the named collection
+// called name.
+func (i *impl) UpdateNamedCollection(ctx context.Context, name string, set map[string]string, remove []string, clusterName *string) (*NamedCollection, error) {
+	if len(set) > 0 || len(remove) > 0 {
+		sql, err := querybuilder.NewAlterNamedCollection(name).Set(set).Delete(remove).WithCluster(clusterName).Build()
+		if err != nil {
+			return nil, errors.WithMessage(err, "error building query")
+		}
+
+		err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error running query")
+		}
+	}
+
+	if i.dryRun {
+		return nil, nil
+	}
+
+	return i.GetNamedCollection(ctx, name, clusterName)
+}
+
+func (i *impl) DeleteNamedCollection(ctx context.Context, name string, clusterName *string) error {
+	namedCollection, err := i.GetNamedCollection(ctx, name, clusterName)
+	if err != nil {
+		return errors.WithMessage(err, "error getting named collection")
+	}
+
+	if namedCollection == nil {
+		// That's what we want.
+		return nil
+	}
+
+	sql, err := querybuilder.NewDropNamedCollection(name).WithCluster(clusterName).Build()
+	if err != nil {
+		return errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil {
+		return errors.WithMessage(err, "error running query")
+	}
+
+	return nil
+}
@@ -0,0 +1,380 @@
+package dbops
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/pingcap/errors"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/querybuilder"
+)
+
+type Quota struct {
+	ID   string `json:"id" ch:"id"`
+	Name string `json:"name" ch:"name"`
+	// KeyedBy lists the keys the quota is tracked by, like user_name or client_key and user_name. Empty when not keyed.
+	KeyedBy   []string        `json:"keys" ch:"keys"`
+	Intervals []QuotaInterval `json:"-"`
+	ApplyTo   []string        `json:"apply_to_list" ch:"apply_to_list"`
+}
+
+// QuotaInterval holds the limits of a quota for a period of time. Nil limits are not enforced.
+type QuotaInterval struct {
+	DurationSeconds  uint64   `json:"duration" ch:"duration"`
+	Randomized       bool     `json:"is_randomized_interval" ch:"is_randomized_interval"`
+	MaxQueries       *uint64  `json:"max_queries" ch:"max_queries"`
+	MaxQuerySelects  *uint64  `json:"max_query_selects" ch:"max_query_selects"`
+	MaxQueryInserts  *uint64  `json:"max_query_inserts" ch:"max_query_inserts"`
+	MaxErrors        *uint64  `json:"max_errors" ch:"max_errors"`
+	MaxResultRows    *uint64  `json:"max_result_rows" ch:"max_result_rows"`
+	MaxResultBytes   *uint64  `json:"max_result_bytes" ch:"max_result_bytes"`
+	MaxReadRows      *uint64  `json:"max_read_rows" ch:"max_read_rows"`
+	MaxReadBytes     *uint64  `json:"max_read_bytes" ch:"max_read_bytes"`
+	MaxExecutionTime *float64 `json:"max_execution_time" ch:"max_execution_time"`
+}
+
+// quotaUInt64Limits maps the integer limits of a quota interval to the name of the resource they limit.
+// Each of them is stored in the max_<resource> column of system.quota_limits.
+var quotaUInt64Limits = []struct {
+	resource string
+	field    func(interval *QuotaInterval) **uint64
+}{
+	{resource: "queries", field: func(i *QuotaInterval) **uint64 { return &i.MaxQueries }},
+	{resource: "query_selects", field: func(i *QuotaInterval) **uint64 { return &i.MaxQuerySelects }},
+	{resource: "query_inserts", field: func(i *QuotaInterval) **uint64 { return &i.MaxQueryInserts }},
+	{resource: "errors", field: func(i *QuotaInterval) **uint64 { return &i.MaxErrors }},
+	{resource: "result_rows", field: func(i *QuotaInterval) **uint64 { return &i.MaxResultRows }},
+	{resource: "result_bytes", field: func(i *QuotaInterval) **uint64 { return &i.MaxResultBytes }},
+	{resource: "read_rows", field: func(i *QuotaInterval) **uint64 { return &i.MaxReadRows }},
+	{resource: "read_bytes", field: func(i *QuotaInterval) **uint64 { return &i.MaxReadBytes }},
+}
+
+const quotaExecutionTimeLimit = "execution_time"
+
+func (q *QuotaInterval) toQueryBuilder() querybuilder.QuotaInterval {
+	limits := make([]querybuilder.QuotaLimit, 0)
+	for _, limit := range quotaUInt64Limits {
+		if value := *limit.field(q); value != nil {
+			limits = append(limits, querybuilder.QuotaLimit{Resource: limit.resource, Max: strconv.FormatUint(*value, 10)})
+		}
+	}
+	if q.MaxExecutionTime != nil {
+		limits = append(limits, querybuilder.QuotaLimit{Resource: quotaExecutionTimeLimit, Max: strconv.FormatFloat(*q.MaxExecutionTime, 'f', -1, 64)})
+	}
+
+	return querybuilder.QuotaInterval{
+		DurationSeconds: q.DurationSeconds,
+		Randomized:      q.Randomized,
+		Limits:          limits,
+	}
+}
+
+func quotaIntervalsToQueryBuilder(intervals []QuotaInterval) []querybuilder.QuotaInterval {
+	ret := make([]querybuilder.QuotaInterval, 0, len(intervals))
+	for i := range intervals {
+		ret = append(ret, intervals[i].toQueryBuilder())
+	}
+
+	return ret
+}
+
+func (i *impl) CreateQuota(ctx context.Context, quota Quota, clusterName *string) (*Quota, error) {
+	sql, err := querybuilder.
+		NewCreateQuota(quota.Name).
+		WithCluster(clusterName).
+		KeyedBy(quota.KeyedBy).
+		ForIntervals(quotaIntervalsToQueryBuilder(quota.Intervals)).
+		To(quota.ApplyTo).
+		Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil && !createdOnMissingReplicas(err, errorCodeAccessEntityAlreadyExists) {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if i.dryRun {
+		return nil, nil
+	}
+
+	return i.FindQuotaByName(ctx, quota.Name, clusterName)
+}
+
+func (i *impl) GetQuota(ctx context.Context, id string, clusterName *string) (*Quota, error) {
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{
+			querybuilder.NewField("name"),
+			querybuilder.NewField("keys"),
+			querybuilder.NewField("apply_to_list"),
+		},
+		"system.quotas",
+	).WithCluster(clusterName).Where(querybuilder.WhereEquals("id", id)).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	var quota *Quota
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		name, err := data.GetString("name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'name' field")
+		}
+		keys, err := data.GetStringSlice("keys")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'keys' field")
+		}
+		applyTo, err := data.GetStringSlice("apply_to_list")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'apply_to_list' field")
+		}
+
+		quota = &Quota{
+			ID:      id,
+			Name:    name,
+			KeyedBy: keys,
+			ApplyTo: applyTo,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if quota == nil {
+		// Quota not found
+		return nil, nil
+	}
+
+	quota.Intervals, err = i.getQuotaIntervals(ctx, quota.Name, clusterName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting quota intervals")
+	}
+
+	return quota, nil
+}
+
+// getQuotaIntervals returns the intervals of the quota called quotaName, sorted by duration.
+func (i *impl) getQuotaIntervals(ctx context.Context, quotaName string, clusterName *string) ([]QuotaInterval, error) {
+	fields := []querybuilder.Field{
+		querybuilder.NewField("duration"),
+		querybuilder.NewField("is_randomized_interval"),
+		querybuilder.NewField("max_" + quotaExecutionTimeLimit),
+	}
+	for _, limit := range quotaUInt64Limits {
+		fields = append(fields, querybuilder.NewField("max_"+limit.resource))
+	}
+
+	sql, err := querybuilder.NewSelect(fields, "system.quota_limits").
+		WithCluster(clusterName).
+		Where(querybuilder.WhereEquals("quota_name", quotaName)).
+		OrderBy(querybuilder.NewField("duration"), querybuilder.ASC).
+		Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	intervals := make([]QuotaInterval, 0)
+	seen := make(map[uint64]bool)
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		duration, err := data.GetUInt64("duration")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'duration' field")
+		}
+
+		// When querying a cluster, the same interval is returned once per replica.
+		if seen[duration] {
+			return nil
+		}
+		seen[duration] = true
+
+		randomized, err := data.GetBool("is_randomized_interval")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'is_randomized_interval' field")
+		}
+
+		interval := QuotaInterval{
+			DurationSeconds: duration,
+			Randomized:      randomized,
+		}
+
+		for _, limit := range quotaUInt64Limits {
+			value, err := data.GetNullableUInt64("max_" + limit.resource)
+			if err != nil {
+				return errors.WithMessage(err, "error scanning query result, missing 'max_"+limit.resource+"' field")
+			}
+			*limit.field(&interval) = value
+		}
+
+		interval.MaxExecutionTime, err = data.GetNullableFloat64("max_" + quotaExecutionTimeLimit)
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'max_execution_time' field")
+		}
+
+		intervals = append(intervals, interval)
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	return intervals, nil
+}
+
+func (i *impl) DeleteQuota(ctx context.Context, id string, clusterName *string) error {
+	quota, err := i.GetQuota(ctx, id, clusterName)
+	if err != nil {
+		return errors.WithMessage(err, "error getting quota")
+	}
+
+	if quota == nil {
+		// That's what we want.
+		return nil
+	}
+
+	sql, err := querybuilder.NewDropQuota(quota.Name).WithCluster(clusterName).Build()
+	if err != nil {
+		return errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil {
+		return errors.WithMessage(err, "error running query")
+	}
+
+	return nil
+}
+
+func (i *impl) FindQuotaByName(ctx context.Context, name string, clusterName *string) (*Quota, error) {
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{querybuilder.NewField("id").ToString()},
+		"system.quotas",
+	).Where(querybuilder.WhereEquals("name", name)).WithCluster(clusterName).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	var uuid string
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		uuid, err = data.GetString("id")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'id' field")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	// No quota with such name found.
+	if uuid == "" {
+		return nil, nil
+	}
+
+	return i.GetQuota(ctx, uuid, clusterName)
+}
+
+func (i *impl) UpdateQuota(ctx context.Context, quota Quota, clusterName *string) (*Quota, error) {
+	existing, err := i.GetQuota(ctx, quota.ID, clusterName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Unable to get existing quota")
+	}
+
+	if existing == nil {
+		return nil, errors.New("quota not found")
+	}
+
+	builder := querybuilder.
+		NewAlterQuota(existing.Name).
+		WithCluster(clusterName).
+		RenameTo(&quota.Name)
+
+	changed := quota.Name != existing.Name
+
+	if !reflect.DeepEqual(quota.KeyedBy, existing.KeyedBy) && (len(quota.KeyedBy) > 0 || len(existing.KeyedBy) > 0) {
+		changed = true
+		keys := quota.KeyedBy
+		if keys == nil {
+			keys = []string{}
+		}
+		builder = builder.KeyedBy(keys)
+	}
+
+	intervals := make([]QuotaInterval, len(quota.Intervals))
+	copy(intervals, quota.Intervals)
+	sort.Slice(intervals, func(a, b int) bool { return intervals[a].DurationSeconds < intervals[b].DurationSeconds })
+
+	current := make(map[uint64]QuotaInterval, len(existing.Intervals))
+	for _, interval := range existing.Intervals {
+		current[interval.DurationSeconds] = interval
+	}
+
+	// Only the intervals that are new or differ from the existing ones are sent, as they are replaced as a whole.
+	wanted := make(map[uint64]bool, len(intervals))
+	replace := make([]QuotaInterval, 0)
+	for _, interval := range intervals {
+		wanted[interval.DurationSeconds] = true
+		if existingInterval, ok := current[interval.DurationSeconds]; !ok || !reflect.DeepEqual(interval, existingInterval) {
+			replace = append(replace, interval)
+		}
+	}
+
+	// Replaced intervals are removed before being added again, but an interval switching between randomized and not
+	// is a different one for ClickHouse, so the existing one must be dropped explicitly.
+	randomized := make(map[uint64]bool, len(replace))
+	for _, interval := range replace {
+		randomized[interval.DurationSeconds] = interval.Randomized
+	}
+
+	drop := make([]QuotaInterval, 0)
+	for _, interval := range existing.Intervals {
+		if r, ok := randomized[interval.DurationSeconds]; !wanted[interval.DurationSeconds] || ok && r != interval.Randomized {
+			drop = append(drop, interval)
+		}
+	}
+
+	if len(replace) > 0 || len(drop) > 0 {
+		changed = true
+		builder = builder.ForIntervals(quotaIntervalsToQueryBuilder(replace)).DropIntervals(quotaIntervalsToQueryBuilder(drop))
+	}
+
+	if !sameElements(quota.ApplyTo, existing.ApplyTo) {
+		changed = true
+		applyTo := quota.ApplyTo
+		if applyTo == nil {
+			applyTo = []string{}
+		}
+		builder = builder.To(applyTo)
+	}
+
+	// The configuration can change without any effect on the quota, like when an interval is written with another unit.
+	if !changed {
+		return existing, nil
+	}
+
+	sql, err := builder.Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if i.dryRun {
+		return nil, nil
+	}
+
+	return i.GetQuota(ctx, quota.ID, clusterName)
+}
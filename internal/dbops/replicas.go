@@ -0,0 +1,179 @@
+package dbops
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pingcap/errors"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/querybuilder"
+)
+
+// Tables of the entities that can be checked for drift between replicas with FindReplicaDrift.
+const (
+	UsersTable            = "system.users"
+	RolesTable            = "system.roles"
+	SettingsProfilesTable = "system.settings_profiles"
+	DatabasesTable        = "system.databases"
+	RowPoliciesTable      = "system.row_policies"
+	QuotasTable           = "system.quotas"
+	FunctionsTable        = "system.functions"
+	NamedCollectionsTable = "system.named_collections"
+	TablesTable           = "system.tables"
+)
+
+// definitionColumns are the columns of each table compared across replicas to find entities defined differently.
+// Roles only have a name, so they can only be missing.
+var definitionColumns = map[string][]string{
+	UsersTable:            {"host_ip", "host_names", "host_names_regexp", "host_names_like", "default_roles_all", "default_roles_list", "default_roles_except", "grantees_any", "grantees_list", "grantees_except", "default_database"},
+	SettingsProfilesTable: {"num_elements", "apply_to_all", "apply_to_list", "apply_to_except"},
+	DatabasesTable:        {"engine", "comment"},
+	RowPoliciesTable:      {"select_filter", "is_restrictive", "apply_to_all", "apply_to_list", "apply_to_except"},
+	QuotasTable:           {"keys", "durations", "apply_to_all", "apply_to_list", "apply_to_except"},
+	FunctionsTable:        {"create_query"},
+	NamedCollectionsTable: {"collection"},
+	TablesTable:           {"engine_full", "partition_key", "sorting_key", "comment"},
+}
+
+// ReplicaDrift lists the replicas of a cluster out of sync with the others for an entity.
+type ReplicaDrift struct {
+	// Missing are the replicas where the entity is not found.
+	Missing []string
+	// Different are the replicas where the entity is defined differently than on most of the others.
+	Different []string
+}
+
+// ClickHouse error codes returned by hosts where the entity being created is already there.
+const (
+	errorCodeTableAlreadyExists           = 57
+	errorCodeDatabaseAlreadyExists        = 82
+	errorCodeAccessEntityAlreadyExists    = 493
+	errorCodeFunctionAlreadyExists        = 609
+	errorCodeNamedCollectionAlreadyExists = 670
+)
+
+// FindReplicaDrift returns the hosts of clusterName where the entity called name is missing from table or is defined
+// differently than on most of the other hosts.
+// When the storage of the entities is not replicated, a statement run ON CLUSTER can fail on some hosts or an entity
+// can be dropped or changed on a single host, leaving replicas out of sync.
+func (i *impl) FindReplicaDrift(ctx context.Context, table string, name string, clusterName string) (*ReplicaDrift, error) {
+	return i.findReplicaDrift(ctx, table, querybuilder.WhereEquals("name", name), clusterName)
+}
+
+// FindTableReplicaDrift returns the hosts of clusterName where the table called name is missing from database or is
+// defined differently than on most of the other hosts.
+func (i *impl) FindTableReplicaDrift(ctx context.Context, database string, name string, clusterName string) (*ReplicaDrift, error) {
+	return i.findReplicaDrift(ctx, TablesTable, querybuilder.AndWhere(
+		querybuilder.WhereEquals("database", database),
+		querybuilder.WhereEquals("name", name),
+	), clusterName)
+}
+
+func (i *impl) findReplicaDrift(ctx context.Context, table string, where querybuilder.Where, clusterName string) (*ReplicaDrift, error) {
+	replicas, err := i.selectDefinitions(ctx, "system.one", nil, nil, clusterName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error listing cluster replicas")
+	}
+
+	found, err := i.selectDefinitions(ctx, table, where, definitionColumns[table], clusterName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error listing replicas having the entity")
+	}
+
+	hosts := make([]string, 0, len(replicas))
+	for host := range replicas {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	// The definition found on most replicas is considered the right one, ties go to the first replica in name order.
+	counts := make(map[string]int)
+	for _, definition := range found {
+		counts[definition]++
+	}
+	expected := ""
+	for _, host := range hosts {
+		if definition, ok := found[host]; ok && counts[definition] > counts[expected] {
+			expected = definition
+		}
+	}
+
+	drift := &ReplicaDrift{
+		Missing:   make([]string, 0),
+		Different: make([]string, 0),
+	}
+	for _, host := range hosts {
+		definition, ok := found[host]
+		if !ok {
+			drift.Missing = append(drift.Missing, host)
+		} else if definition != expected {
+			drift.Different = append(drift.Different, host)
+		}
+	}
+
+	return drift, nil
+}
+
+// selectDefinitions returns the definition of the rows in table matching where, made of the given columns, by name of
+// the replica of clusterName they come from.
+func (i *impl) selectDefinitions(ctx context.Context, table string, where querybuilder.Where, columns []string, clusterName string) (map[string]string, error) {
+	fields := []querybuilder.Field{querybuilder.NewHostNameField()}
+	if len(columns) > 0 {
+		fields = append(fields, querybuilder.NewDefinitionField(columns))
+	}
+
+	// Read from every replica, not just one per shard, so that replicas out of sync can be found.
+	builder := querybuilder.NewSelect(fields, table).WithCluster(&clusterName).FromAllReplicas()
+	if where != nil {
+		builder = builder.Where(where)
+	}
+
+	sql, err := builder.Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	definitions := make(map[string]string)
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		host, err := data.GetString(querybuilder.HostNameColumn)
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'host_name' field")
+		}
+
+		definition := ""
+		if len(columns) > 0 {
+			definition, err = data.GetString(querybuilder.DefinitionColumn)
+			if err != nil {
+				return errors.WithMessage(err, "error scanning query result, missing 'definition' field")
+			}
+		}
+
+		definitions[host] = definition
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	return definitions, nil
+}
+
+// createdOnMissingReplicas returns true if err comes from a CREATE query run ON CLUSTER that only failed on hosts
+// where the entity already exists, while it was created on the others. This is what happens when recreating an entity
+// that drifted, so that it is back on all replicas.
+func createdOnMissingReplicas(err error, alreadyExistsCode int64) bool {
+	clusterError, ok := errors.Cause(err).(*clickhouseclient.ClusterError)
+	if !ok || clusterError.Succeeded == 0 || clusterError.Unreported > 0 {
+		return false
+	}
+
+	for _, host := range clusterError.Hosts {
+		if host.Status == nil || *host.Status != alreadyExistsCode {
+			return false
+		}
+	}
+
+	return true
+}
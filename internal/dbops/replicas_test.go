@@ -0,0 +1,163 @@
+package dbops
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/errors"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+)
+
+// hostsClient answers every query with one row per host, depending on the table being read, with the definition of
+// the entity on that host.
+type hostsClient struct {
+	hosts       map[string][]string
+	definitions map[string]string
+}
+
+func (c *hostsClient) Select(_ context.Context, qry string, callback func(clickhouseclient.Row) error) error {
+	for table, hosts := range c.hosts {
+		if !strings.Contains(qry, table) {
+			continue
+		}
+		for _, host := range hosts {
+			row := clickhouseclient.Row{}
+			row.Set("host_name", host)
+			if strings.Contains(qry, "`definition`") {
+				row.Set("definition", c.definitions[host])
+			}
+			if err := callback(row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *hostsClient) Exec(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestFindReplicaDrift(t *testing.T) {
+	tests := []struct {
+		name        string
+		table       string
+		found       []string
+		definitions map[string]string
+		want        *ReplicaDrift
+	}{
+		{
+			name:        "Same on all replicas",
+			table:       UsersTable,
+			found:       []string{"ch-1", "ch-2", "ch-3"},
+			definitions: map[string]string{"ch-1": "a", "ch-2": "a", "ch-3": "a"},
+			want:        &ReplicaDrift{Missing: []string{}, Different: []string{}},
+		},
+		{
+			name:        "Missing on one replica",
+			table:       UsersTable,
+			found:       []string{"ch-1", "ch-3"},
+			definitions: map[string]string{"ch-1": "a", "ch-3": "a"},
+			want:        &ReplicaDrift{Missing: []string{"ch-2"}, Different: []string{}},
+		},
+		{
+			name:        "Different on one replica",
+			table:       UsersTable,
+			found:       []string{"ch-1", "ch-2", "ch-3"},
+			definitions: map[string]string{"ch-1": "b", "ch-2": "a", "ch-3": "a"},
+			want:        &ReplicaDrift{Missing: []string{}, Different: []string{"ch-1"}},
+		},
+		{
+			name:        "Tie goes to the first replica",
+			table:       UsersTable,
+			found:       []string{"ch-2", "ch-3"},
+			definitions: map[string]string{"ch-2": "b", "ch-3": "a"},
+			want:        &ReplicaDrift{Missing: []string{"ch-1"}, Different: []string{"ch-3"}},
+		},
+		{
+			name:  "Roles are only compared by name",
+			table: RolesTable,
+			found: []string{"ch-1", "ch-2", "ch-3"},
+			want:  &ReplicaDrift{Missing: []string{}, Different: []string{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &impl{clickhouseClient: &hostsClient{
+				hosts: map[string][]string{
+					"`system`.`one`":   {"ch-3", "ch-1", "ch-2"},
+					"`system`.`users`": tt.found,
+					"`system`.`roles`": tt.found,
+				},
+				definitions: tt.definitions,
+			}}
+
+			got, err := i.FindReplicaDrift(context.Background(), tt.table, "john", "cluster1")
+			if err != nil {
+				t.Fatalf("FindReplicaDrift() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindReplicaDrift() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_createdOnMissingReplicas(t *testing.T) {
+	status := func(code int64) *int64 {
+		return &code
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "Not a cluster error",
+			err:  errors.New("syntax error"),
+			want: false,
+		},
+		{
+			name: "Already exists on some hosts",
+			err: errors.WithMessage(&clickhouseclient.ClusterError{
+				Hosts:     []clickhouseclient.HostStatus{{Host: "ch-1", Status: status(errorCodeAccessEntityAlreadyExists)}},
+				Succeeded: 1,
+			}, "error running query"),
+			want: true,
+		},
+		{
+			name: "Already exists on all hosts",
+			err: &clickhouseclient.ClusterError{
+				Hosts: []clickhouseclient.HostStatus{{Host: "ch-1", Status: status(errorCodeAccessEntityAlreadyExists)}},
+			},
+			want: false,
+		},
+		{
+			name: "Other failure",
+			err: &clickhouseclient.ClusterError{
+				Hosts:     []clickhouseclient.HostStatus{{Host: "ch-1", Status: status(497)}},
+				Succeeded: 1,
+			},
+			want: false,
+		},
+		{
+			name: "Timed out host",
+			err: &clickhouseclient.ClusterError{
+				Hosts:     []clickhouseclient.HostStatus{{Host: "ch-1"}},
+				Succeeded: 1,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := createdOnMissingReplicas(tt.err, errorCodeAccessEntityAlreadyExists); got != tt.want {
+				t.Errorf("createdOnMissingReplicas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
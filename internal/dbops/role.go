@@ -31,11 +31,15 @@ func (i *impl) CreateRole(ctx context.Context, role Role, clusterName *string) (
 		return nil, errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
-	if err != nil {
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil && !createdOnMissingReplicas(err, errorCodeAccessEntityAlreadyExists) {
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	if i.dryRun {
+		return nil, nil
+	}
+
 	return i.FindRoleByName(ctx, role.Name, clusterName)
 }
 
@@ -119,7 +123,7 @@ func (i *impl) DeleteRole(ctx context.Context, id string, clusterName *string) e
 		return errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return errors.WithMessage(err, "error running query")
 	}
@@ -174,10 +178,57 @@ func (i *impl) UpdateRole(ctx context.Context, role Role, clusterName *string) (
 		return nil, errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	if i.dryRun {
+		return nil, nil
+	}
+
 	return i.GetRole(ctx, role.ID, clusterName)
 }
+
+// ListRoles returns all roles defined in system.roles, sorted by name.
+func (i *impl) ListRoles(ctx context.Context, clusterName *string) ([]Role, error) {
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{querybuilder.NewField("id").ToString(), querybuilder.NewField("name")},
+		"system.roles",
+	).WithCluster(clusterName).OrderBy(querybuilder.NewField("name"), querybuilder.ASC).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	ret := make([]Role, 0)
+	seen := make(map[string]bool)
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		id, err := data.GetString("id")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'id' field")
+		}
+		name, err := data.GetString("name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'name' field")
+		}
+
+		// When querying a cluster, the same role is returned once per replica.
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+
+		ret = append(ret, Role{
+			ID:   id,
+			Name: name,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,234 @@
+package dbops
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/querybuilder"
+)
+
+type RowPolicy struct {
+	ID string `json:"id" ch:"id"`
+	// Name is the name of the policy, unique for each table.
+	Name string `json:"name" ch:"short_name"`
+	// FullName is the name of the policy in system.row_policies, like `name ON db.table`.
+	FullName     string   `json:"-" ch:"name"`
+	Database     string   `json:"database" ch:"database"`
+	Table        string   `json:"table" ch:"table"`
+	SelectFilter string   `json:"select_filter" ch:"select_filter"`
+	Restrictive  bool     `json:"is_restrictive" ch:"is_restrictive"`
+	ApplyTo      []string `json:"apply_to_list" ch:"apply_to_list"`
+}
+
+func (i *impl) CreateRowPolicy(ctx context.Context, rowPolicy RowPolicy, clusterName *string) (*RowPolicy, error) {
+	sql, err := querybuilder.
+		NewCreateRowPolicy(rowPolicy.Name, rowPolicy.Database, rowPolicy.Table).
+		WithCluster(clusterName).
+		Using(rowPolicy.SelectFilter).
+		Restrictive(rowPolicy.Restrictive).
+		To(rowPolicy.ApplyTo).
+		Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil && !createdOnMissingReplicas(err, errorCodeAccessEntityAlreadyExists) {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if i.dryRun {
+		return nil, nil
+	}
+
+	return i.findRowPolicy(ctx, querybuilder.AndWhere(
+		querybuilder.WhereEquals("short_name", rowPolicy.Name),
+		querybuilder.WhereEquals("database", rowPolicy.Database),
+		querybuilder.WhereEquals("table", rowPolicy.Table),
+	), clusterName)
+}
+
+func (i *impl) GetRowPolicy(ctx context.Context, id string, clusterName *string) (*RowPolicy, error) {
+	return i.findRowPolicy(ctx, querybuilder.WhereEquals("id", id), clusterName)
+}
+
+// FindRowPolicyByName looks up a row policy by its full name, like `name ON db.table`.
+func (i *impl) FindRowPolicyByName(ctx context.Context, fullName string, clusterName *string) (*RowPolicy, error) {
+	return i.findRowPolicy(ctx, querybuilder.WhereEquals("name", fullName), clusterName)
+}
+
+func (i *impl) UpdateRowPolicy(ctx context.Context, rowPolicy RowPolicy, clusterName *string) (*RowPolicy, error) {
+	existing, err := i.GetRowPolicy(ctx, rowPolicy.ID, clusterName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Unable to get existing row policy")
+	}
+
+	if existing == nil {
+		return nil, errors.New("row policy not found")
+	}
+
+	builder := querybuilder.
+		NewAlterRowPolicy(existing.Name, existing.Database, existing.Table).
+		WithCluster(clusterName).
+		RenameTo(&rowPolicy.Name)
+
+	changed := rowPolicy.Name != existing.Name
+
+	if rowPolicy.SelectFilter != existing.SelectFilter {
+		changed = true
+		builder = builder.Using(&rowPolicy.SelectFilter)
+	}
+
+	if rowPolicy.Restrictive != existing.Restrictive {
+		changed = true
+		builder = builder.Restrictive(&rowPolicy.Restrictive)
+	}
+
+	if !sameElements(rowPolicy.ApplyTo, existing.ApplyTo) {
+		changed = true
+		builder = builder.To(rowPolicy.ApplyTo)
+	}
+
+	// The configuration can change without any effect on the row policy, like when only the filter formatting differs.
+	if !changed {
+		return existing, nil
+	}
+
+	sql, err := builder.Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if i.dryRun {
+		return nil, nil
+	}
+
+	return i.GetRowPolicy(ctx, rowPolicy.ID, clusterName)
+}
+
+func (i *impl) DeleteRowPolicy(ctx context.Context, id string, clusterName *string) error {
+	rowPolicy, err := i.GetRowPolicy(ctx, id, clusterName)
+	if err != nil {
+		return errors.WithMessage(err, "error getting row policy")
+	}
+
+	if rowPolicy == nil {
+		// That's what we want.
+		return nil
+	}
+
+	sql, err := querybuilder.NewDropRowPolicy(rowPolicy.Name, rowPolicy.Database, rowPolicy.Table).WithCluster(clusterName).Build()
+	if err != nil {
+		return errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil {
+		return errors.WithMessage(err, "error running query")
+	}
+
+	return nil
+}
+
+func (i *impl) findRowPolicy(ctx context.Context, where querybuilder.Where, clusterName *string) (*RowPolicy, error) {
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{
+			querybuilder.NewField("id").ToString(),
+			querybuilder.NewField("short_name"),
+			querybuilder.NewField("name"),
+			querybuilder.NewField("database"),
+			querybuilder.NewField("table"),
+			querybuilder.NewField("select_filter"),
+			querybuilder.NewField("is_restrictive"),
+			querybuilder.NewField("apply_to_list"),
+		},
+		"system.row_policies",
+	).WithCluster(clusterName).Where(where).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	var rowPolicy *RowPolicy
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		id, err := data.GetString("id")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'id' field")
+		}
+		shortName, err := data.GetString("short_name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'short_name' field")
+		}
+		fullName, err := data.GetString("name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'name' field")
+		}
+		database, err := data.GetString("database")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'database' field")
+		}
+		table, err := data.GetString("table")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'table' field")
+		}
+		selectFilter, err := data.GetNullableString("select_filter")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'select_filter' field")
+		}
+		restrictive, err := data.GetBool("is_restrictive")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'is_restrictive' field")
+		}
+		applyTo, err := data.GetStringSlice("apply_to_list")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'apply_to_list' field")
+		}
+
+		rowPolicy = &RowPolicy{
+			ID:          id,
+			Name:        shortName,
+			FullName:    fullName,
+			Database:    database,
+			Table:       table,
+			Restrictive: restrictive,
+			ApplyTo:     applyTo,
+		}
+		if selectFilter != nil {
+			rowPolicy.SelectFilter = *selectFilter
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	return rowPolicy, nil
+}
+
+// sameElements returns true if a and b contain the same strings, regardless of their order.
+func sameElements(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+		if counts[s] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -36,11 +36,15 @@ func (i *impl) CreateSetting(ctx context.Context, settingsProfileID string, sett
 		return nil, errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	if i.dryRun {
+		return nil, nil
+	}
+
 	return i.GetSetting(ctx, settingsProfileID, setting.Name, clusterName)
 }
 
@@ -131,7 +135,7 @@ func (i *impl) DeleteSetting(ctx context.Context, settingsProfileID string, name
 		return errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return errors.WithMessage(err, "error running query")
 	}
@@ -26,11 +26,15 @@ func (i *impl) CreateSettingsProfile(ctx context.Context, profile SettingsProfil
 		return nil, errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
-	if err != nil {
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil && !createdOnMissingReplicas(err, errorCodeAccessEntityAlreadyExists) {
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	if i.dryRun {
+		return nil, nil
+	}
+
 	return i.FindSettingsProfileByName(ctx, profile.Name, clusterName)
 }
 
@@ -121,7 +125,7 @@ func (i *impl) DeleteSettingsProfile(ctx context.Context, id string, clusterName
 		return errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return errors.WithMessage(err, "error running query")
 	}
@@ -150,11 +154,15 @@ func (i *impl) UpdateSettingsProfile(ctx context.Context, settingsProfile Settin
 		return nil, errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	if i.dryRun {
+		return nil, nil
+	}
+
 	return i.GetSettingsProfile(ctx, settingsProfile.ID, clusterName)
 }
 
@@ -186,7 +194,7 @@ func (i *impl) AssociateSettingsProfile(ctx context.Context, id string, roleId *
 			return errors.WithMessage(err, "Error building query")
 		}
 
-		err = i.clickhouseClient.Exec(ctx, sql)
+		err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 		if err != nil {
 			return errors.WithMessage(err, "error running query")
 		}
@@ -211,7 +219,7 @@ func (i *impl) AssociateSettingsProfile(ctx context.Context, id string, roleId *
 			return errors.WithMessage(err, "Error building query")
 		}
 
-		err = i.clickhouseClient.Exec(ctx, sql)
+		err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 		if err != nil {
 			return errors.WithMessage(err, "error running query")
 		}
@@ -251,7 +259,7 @@ func (i *impl) DisassociateSettingsProfile(ctx context.Context, id string, roleI
 			return errors.WithMessage(err, "Error building query")
 		}
 
-		err = i.clickhouseClient.Exec(ctx, sql)
+		err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 		if err != nil {
 			return errors.WithMessage(err, "error running query")
 		}
@@ -276,7 +284,7 @@ func (i *impl) DisassociateSettingsProfile(ctx context.Context, id string, roleI
 			return errors.WithMessage(err, "Error building query")
 		}
 
-		err = i.clickhouseClient.Exec(ctx, sql)
+		err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 		if err != nil {
 			return errors.WithMessage(err, "error running query")
 		}
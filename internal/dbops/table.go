@@ -0,0 +1,619 @@
+package dbops
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/querybuilder"
+)
+
+// Table is a table of a database. The engine and the expressions are SQL, formatted by ClickHouse when read from
+// system.tables and system.columns. Nil expressions are not set.
+type Table struct {
+	Database    string            `json:"database"`
+	Name        string            `json:"name"`
+	Columns     []TableColumn     `json:"columns"`
+	Engine      string            `json:"engine"`
+	PartitionBy *string           `json:"partition_by"`
+	PrimaryKey  *string           `json:"primary_key"`
+	OrderBy     *string           `json:"order_by"`
+	TTL         *string           `json:"ttl"`
+	Settings    map[string]string `json:"settings"`
+	Comment     string            `json:"comment"`
+}
+
+type TableColumn struct {
+	Name    string  `json:"name"`
+	Type    string  `json:"type"`
+	Default *string `json:"default"`
+	Codec   *string `json:"codec"`
+	Comment *string `json:"comment"`
+	// PreviousName is the name the column had before being renamed, nil when it was not. It is only used when
+	// updating a table and never read from ClickHouse.
+	PreviousName *string `json:"-"`
+}
+
+// Clauses following the engine in the engine_full column of system.tables.
+const (
+	tableClausePartitionBy = "PARTITION BY"
+	tableClausePrimaryKey  = "PRIMARY KEY"
+	tableClauseOrderBy     = "ORDER BY"
+	tableClauseSampleBy    = "SAMPLE BY"
+	tableClauseTTL         = "TTL"
+	tableClauseSettings    = "SETTINGS"
+)
+
+// columnDefaultKind is the default_kind of columns with a DEFAULT expression in system.columns.
+const columnDefaultKind = "DEFAULT"
+
+func (c *TableColumn) toQueryBuilder() querybuilder.TableColumn {
+	return querybuilder.TableColumn{
+		Name:    c.Name,
+		Type:    c.Type,
+		Default: c.Default,
+		Codec:   c.Codec,
+		Comment: c.Comment,
+	}
+}
+
+func tableColumnsToQueryBuilder(columns []TableColumn) []querybuilder.TableColumn {
+	ret := make([]querybuilder.TableColumn, 0, len(columns))
+	for i := range columns {
+		ret = append(ret, columns[i].toQueryBuilder())
+	}
+
+	return ret
+}
+
+func (i *impl) CreateTable(ctx context.Context, table Table, clusterName *string) (*Table, error) {
+	builder := querybuilder.
+		NewCreateTable(table.Database, table.Name, tableColumnsToQueryBuilder(table.Columns), table.Engine).
+		WithCluster(clusterName).
+		PartitionBy(table.PartitionBy).
+		PrimaryKey(table.PrimaryKey).
+		OrderBy(table.OrderBy).
+		TTL(table.TTL).
+		Settings(table.Settings)
+	if table.Comment != "" {
+		builder.WithComment(&table.Comment)
+	}
+	sql, err := builder.Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil && !createdOnMissingReplicas(err, errorCodeTableAlreadyExists) {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if i.dryRun {
+		return nil, nil
+	}
+
+	return i.GetTable(ctx, table.Database, table.Name, clusterName)
+}
+
+// GetTable returns the table called name in database, or nil if there is none.
+func (i *impl) GetTable(ctx context.Context, database string, name string, clusterName *string) (*Table, error) {
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{querybuilder.NewField("engine_full"), querybuilder.NewField("comment")},
+		"system.tables",
+	).WithCluster(clusterName).Where(querybuilder.AndWhere(
+		querybuilder.WhereEquals("database", database),
+		querybuilder.WhereEquals("name", name),
+	)).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	var table *Table
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		// When querying a cluster, the table is returned once per replica.
+		if table != nil {
+			return nil
+		}
+
+		engineFull, err := data.GetString("engine_full")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'engine_full' field")
+		}
+		comment, err := data.GetString("comment")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'comment' field")
+		}
+
+		engine, clauses := parseEngineFull(engineFull)
+		table = &Table{
+			Database:    database,
+			Name:        name,
+			Columns:     make([]TableColumn, 0),
+			Engine:      engine,
+			PartitionBy: clauseOrNil(clauses, tableClausePartitionBy),
+			PrimaryKey:  clauseOrNil(clauses, tableClausePrimaryKey),
+			OrderBy:     clauseOrNil(clauses, tableClauseOrderBy),
+			TTL:         clauseOrNil(clauses, tableClauseTTL),
+			Settings:    parseTableSettings(clauses[tableClauseSettings]),
+			Comment:     comment,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if table == nil {
+		// Table not found
+		return nil, nil
+	}
+
+	table.Columns, err = i.getTableColumns(ctx, database, name, clusterName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting table columns")
+	}
+
+	return table, nil
+}
+
+// getTableColumns returns the columns of the table called name in database, in order.
+func (i *impl) getTableColumns(ctx context.Context, database string, name string, clusterName *string) ([]TableColumn, error) {
+	sql, err := querybuilder.NewSelect(
+		[]querybuilder.Field{
+			querybuilder.NewField("name"),
+			querybuilder.NewField("type"),
+			querybuilder.NewField("default_kind"),
+			querybuilder.NewField("default_expression"),
+			querybuilder.NewField("compression_codec"),
+			querybuilder.NewField("comment"),
+		},
+		"system.columns",
+	).WithCluster(clusterName).Where(querybuilder.AndWhere(
+		querybuilder.WhereEquals("database", database),
+		querybuilder.WhereEquals("table", name),
+	)).OrderBy(querybuilder.NewField("position"), querybuilder.ASC).Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	columns := make([]TableColumn, 0)
+	seen := make(map[string]bool)
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		columnName, err := data.GetString("name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'name' field")
+		}
+		columnType, err := data.GetString("type")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'type' field")
+		}
+		defaultKind, err := data.GetString("default_kind")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'default_kind' field")
+		}
+		defaultExpression, err := data.GetString("default_expression")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'default_expression' field")
+		}
+		codec, err := data.GetString("compression_codec")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'compression_codec' field")
+		}
+		comment, err := data.GetString("comment")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'comment' field")
+		}
+
+		// When querying a cluster, the same column is returned once per replica.
+		if seen[columnName] {
+			return nil
+		}
+		seen[columnName] = true
+
+		column := TableColumn{
+			Name: columnName,
+			Type: columnType,
+		}
+		if defaultKind == columnDefaultKind {
+			column.Default = &defaultExpression
+		}
+		if codec != "" {
+			// The codec is read as CODEC(...), while it is set with the arguments only.
+			codec = strings.TrimSuffix(strings.TrimPrefix(codec, "CODEC("), ")")
+			column.Codec = &codec
+		}
+		if comment != "" {
+			column.Comment = &comment
+		}
+
+		columns = append(columns, column)
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	return columns, nil
+}
+
+// UpdateTable changes the table described by current so that it matches desired, with RENAME TABLE and ALTER TABLE
+// queries. The engine, the partition key and the primary key cannot be changed.
+func (i *impl) UpdateTable(ctx context.Context, current Table, desired Table, clusterName *string) (*Table, error) {
+	queries, err := tableAlterQueries(current, desired, clusterName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	for _, sql := range queries {
+		err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error running query")
+		}
+	}
+
+	if i.dryRun {
+		return nil, nil
+	}
+
+	return i.GetTable(ctx, desired.Database, desired.Name, clusterName)
+}
+
+func (i *impl) DeleteTable(ctx context.Context, database string, name string, clusterName *string) error {
+	table, err := i.GetTable(ctx, database, name, clusterName)
+	if err != nil {
+		return errors.WithMessage(err, "error getting table")
+	}
+
+	if table == nil {
+		// That's what we want.
+		return nil
+	}
+
+	sql, err := querybuilder.NewDropTable(database, name).WithCluster(clusterName).Build()
+	if err != nil {
+		return errors.WithMessage(err, "error building query")
+	}
+
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil {
+		return errors.WithMessage(err, "error running query")
+	}
+
+	return nil
+}
+
+// tableAlterQueries returns the RENAME TABLE and ALTER TABLE queries changing current into desired, to be run in order.
+// Changes that can fail when combined, like removing properties of columns or modifying the TTL, get their own query.
+func tableAlterQueries(current Table, desired Table, clusterName *string) ([]string, error) {
+	if current.Database != desired.Database {
+		return nil, errors.New("the database of a table cannot be changed")
+	}
+	if current.Engine != desired.Engine || !equalStringPtr(current.PartitionBy, desired.PartitionBy) || !equalStringPtr(current.PrimaryKey, desired.PrimaryKey) {
+		return nil, errors.New("the engine, partition key and primary key of a table cannot be changed")
+	}
+
+	queries := make([]string, 0)
+	if current.Name != desired.Name {
+		sql, err := querybuilder.NewRenameTable(desired.Database, current.Name, desired.Name).WithCluster(clusterName).Build()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, sql)
+	}
+
+	newAlter := func() querybuilder.AlterTableQueryBuilder {
+		return querybuilder.NewAlterTable(desired.Database, desired.Name).WithCluster(clusterName)
+	}
+	builders := make([]querybuilder.AlterTableQueryBuilder, 0)
+
+	// Columns are renamed first, the following changes then find them under their new name.
+	{
+		builder := newAlter()
+		changed := false
+		renamed := append([]TableColumn{}, current.Columns...)
+		for _, c := range desired.Columns {
+			if c.PreviousName == nil || indexOfColumn(renamed, c.Name) >= 0 {
+				continue
+			}
+			if i := indexOfColumn(renamed, *c.PreviousName); i >= 0 {
+				builder.RenameColumn(*c.PreviousName, c.Name)
+				changed = true
+				renamed[i].Name = c.Name
+			}
+		}
+		if changed {
+			builders = append(builders, builder)
+		}
+		current.Columns = renamed
+	}
+
+	currentColumns := make(map[string]TableColumn)
+	for _, c := range current.Columns {
+		currentColumns[c.Name] = c
+	}
+	desiredColumns := make(map[string]TableColumn)
+	for _, c := range desired.Columns {
+		desiredColumns[c.Name] = c
+	}
+
+	// Columns are changed along with the sorting key, as the columns it is extended with must be added by the same query.
+	{
+		builder := newAlter()
+		changed := false
+		for _, c := range current.Columns {
+			if _, ok := desiredColumns[c.Name]; !ok {
+				builder.DropColumn(c.Name)
+				changed = true
+			}
+		}
+		var after *string
+		for _, c := range desired.Columns {
+			old, ok := currentColumns[c.Name]
+			if !ok {
+				builder.AddColumn(c.toQueryBuilder(), after)
+				changed = true
+			} else if old.Type != c.Type ||
+				c.Default != nil && !equalStringPtr(old.Default, c.Default) ||
+				c.Codec != nil && !equalStringPtr(old.Codec, c.Codec) ||
+				c.Comment != nil && !equalStringPtr(old.Comment, c.Comment) {
+				builder.ModifyColumn(c.toQueryBuilder())
+				changed = true
+			}
+			name := c.Name
+			after = &name
+		}
+		if !equalStringPtr(current.OrderBy, desired.OrderBy) {
+			if desired.OrderBy == nil {
+				return nil, errors.New("the sorting key of a table cannot be removed")
+			}
+			builder.ModifyOrderBy(*desired.OrderBy)
+			changed = true
+		}
+		if changed {
+			builders = append(builders, builder)
+		}
+	}
+
+	for _, c := range desired.Columns {
+		old, ok := currentColumns[c.Name]
+		if !ok {
+			continue
+		}
+		if old.Default != nil && c.Default == nil {
+			builders = append(builders, newAlter().RemoveColumnProperty(c.Name, querybuilder.ColumnPropertyDefault))
+		}
+		if old.Codec != nil && c.Codec == nil {
+			builders = append(builders, newAlter().RemoveColumnProperty(c.Name, querybuilder.ColumnPropertyCodec))
+		}
+		if old.Comment != nil && c.Comment == nil {
+			builders = append(builders, newAlter().RemoveColumnProperty(c.Name, querybuilder.ColumnPropertyComment))
+		}
+	}
+
+	// Kept columns stay where they are and added ones follow the column preceding them, move columns that end up
+	// elsewhere than desired.
+	{
+		order := make([]string, 0, len(desired.Columns))
+		for _, c := range current.Columns {
+			if _, ok := desiredColumns[c.Name]; ok {
+				order = append(order, c.Name)
+			}
+		}
+		for i, c := range desired.Columns {
+			if _, ok := currentColumns[c.Name]; ok {
+				continue
+			}
+			position := 0
+			if i > 0 {
+				position = indexOf(order, desired.Columns[i-1].Name) + 1
+			}
+			order = append(order[:position], append([]string{c.Name}, order[position:]...)...)
+		}
+
+		builder := newAlter()
+		changed := false
+		var after *string
+		for i, c := range desired.Columns {
+			if order[i] != c.Name {
+				builder.MoveColumn(c.Name, after)
+				changed = true
+				position := indexOf(order, c.Name)
+				order = append(order[:position], order[position+1:]...)
+				order = append(order[:i], append([]string{c.Name}, order[i:]...)...)
+			}
+			name := c.Name
+			after = &name
+		}
+		if changed {
+			builders = append(builders, builder)
+		}
+	}
+
+	if !equalStringPtr(current.TTL, desired.TTL) {
+		builders = append(builders, newAlter().ModifyTTL(desired.TTL))
+	}
+
+	{
+		modified := make(map[string]string)
+		for name, value := range desired.Settings {
+			if old, ok := current.Settings[name]; !ok || old != value {
+				modified[name] = value
+			}
+		}
+		if len(modified) > 0 {
+			builders = append(builders, newAlter().ModifySettings(modified))
+		}
+
+		reset := make([]string, 0)
+		for name := range current.Settings {
+			if _, ok := desired.Settings[name]; !ok {
+				reset = append(reset, name)
+			}
+		}
+		if len(reset) > 0 {
+			builders = append(builders, newAlter().ResetSettings(reset))
+		}
+	}
+
+	if current.Comment != desired.Comment {
+		builders = append(builders, newAlter().ModifyComment(desired.Comment))
+	}
+
+	for _, builder := range builders {
+		sql, err := builder.Build()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, sql)
+	}
+
+	return queries, nil
+}
+
+// parseEngineFull splits the engine_full column of system.tables, like
+// `MergeTree PARTITION BY toYYYYMM(d) ORDER BY (id, d) SETTINGS index_granularity = 8192`, into the engine and the
+// clauses following it, keyed by their keyword.
+func parseEngineFull(engineFull string) (string, map[string]string) {
+	type clause struct {
+		keyword string
+		start   int
+	}
+
+	found := make([]clause, 0)
+	for _, keyword := range []string{tableClausePartitionBy, tableClausePrimaryKey, tableClauseOrderBy, tableClauseSampleBy, tableClauseTTL, tableClauseSettings} {
+		if start := topLevelIndex(engineFull, " "+keyword+" "); start >= 0 {
+			found = append(found, clause{keyword: keyword, start: start})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].start < found[j].start
+	})
+
+	engine := engineFull
+	if len(found) > 0 {
+		engine = engineFull[:found[0].start]
+	}
+
+	clauses := make(map[string]string)
+	for n, c := range found {
+		end := len(engineFull)
+		if n+1 < len(found) {
+			end = found[n+1].start
+		}
+		clauses[c.keyword] = strings.TrimSpace(engineFull[c.start+len(c.keyword)+2 : end])
+	}
+
+	return strings.TrimSpace(engine), clauses
+}
+
+// parseTableSettings parses the SETTINGS clause of a table, like `index_granularity = 8192, storage_policy = 'hot'`.
+// String values are unquoted.
+func parseTableSettings(s string) map[string]string {
+	settings := make(map[string]string)
+
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		setting := rest
+		rest = ""
+		if end := topLevelIndex(setting, ","); end >= 0 {
+			setting, rest = setting[:end], strings.TrimSpace(setting[end+1:])
+		}
+
+		name, value, ok := strings.Cut(setting, "=")
+		if !ok {
+			continue
+		}
+		settings[strings.TrimSpace(name)] = unquoteString(strings.TrimSpace(value))
+	}
+
+	return settings
+}
+
+// topLevelIndex returns the index of the first occurrence of token in s that is neither quoted nor between brackets,
+// or -1 if there is none.
+func topLevelIndex(s string, token string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case depth == 0 && strings.HasPrefix(s[i:], token):
+			return i
+		}
+	}
+
+	return -1
+}
+
+// unquoteString returns the value of s if it is a string literal, or s itself otherwise.
+func unquoteString(s string) string {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 1; i < len(s)-1; i++ {
+		if s[i] == '\\' && i+1 < len(s)-1 {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+
+	return sb.String()
+}
+
+func clauseOrNil(clauses map[string]string, keyword string) *string {
+	value, ok := clauses[keyword]
+	if !ok || value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func equalStringPtr(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return *a == *b
+}
+
+func indexOfColumn(columns []TableColumn, name string) int {
+	for i, c := range columns {
+		if c.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,249 @@
+package dbops
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseEngineFull(t *testing.T) {
+	tests := []struct {
+		name        string
+		engineFull  string
+		wantEngine  string
+		wantClauses map[string]string
+	}{
+		{
+			name:        "Engine only",
+			engineFull:  "Memory",
+			wantEngine:  "Memory",
+			wantClauses: map[string]string{},
+		},
+		{
+			name:       "All clauses",
+			engineFull: "MergeTree PARTITION BY toYYYYMM(d) PRIMARY KEY id ORDER BY (id, d) SAMPLE BY id TTL d + toIntervalDay(7) SETTINGS index_granularity = 8192",
+			wantEngine: "MergeTree",
+			wantClauses: map[string]string{
+				"PARTITION BY": "toYYYYMM(d)",
+				"PRIMARY KEY":  "id",
+				"ORDER BY":     "(id, d)",
+				"SAMPLE BY":    "id",
+				"TTL":          "d + toIntervalDay(7)",
+				"SETTINGS":     "index_granularity = 8192",
+			},
+		},
+		{
+			name:       "Keywords in engine parameters",
+			engineFull: "ReplicatedMergeTree('/clickhouse/tables/ ORDER BY /{shard}', '{replica}') ORDER BY id",
+			wantEngine: "ReplicatedMergeTree('/clickhouse/tables/ ORDER BY /{shard}', '{replica}')",
+			wantClauses: map[string]string{
+				"ORDER BY": "id",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, clauses := parseEngineFull(tt.engineFull)
+			if engine != tt.wantEngine {
+				t.Errorf("parseEngineFull() engine = %q, want %q", engine, tt.wantEngine)
+			}
+			if !reflect.DeepEqual(clauses, tt.wantClauses) {
+				t.Errorf("parseEngineFull() clauses = %v, want %v", clauses, tt.wantClauses)
+			}
+		})
+	}
+}
+
+func Test_parseTableSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		want     map[string]string
+	}{
+		{
+			name:     "Empty",
+			settings: "",
+			want:     map[string]string{},
+		},
+		{
+			name:     "Numbers and strings",
+			settings: "index_granularity = 8192, storage_policy = 'hot, cold', merge_with_ttl_timeout = 3600",
+			want: map[string]string{
+				"index_granularity":      "8192",
+				"storage_policy":         "hot, cold",
+				"merge_with_ttl_timeout": "3600",
+			},
+		},
+		{
+			name:     "Escaped quote",
+			settings: "storage_policy = 'it\\'s'",
+			want: map[string]string{
+				"storage_policy": "it's",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTableSettings(tt.settings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTableSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tableAlterQueries(t *testing.T) {
+	strptr := func(s string) *string {
+		return &s
+	}
+	table := func(columns []TableColumn, orderBy *string, ttl *string, settings map[string]string, comment string) Table {
+		return Table{
+			Database: "db",
+			Name:     "tbl",
+			Columns:  columns,
+			Engine:   "MergeTree",
+			OrderBy:  orderBy,
+			TTL:      ttl,
+			Settings: settings,
+			Comment:  comment,
+		}
+	}
+	columns := []TableColumn{
+		{Name: "id", Type: "UInt64"},
+		{Name: "d", Type: "Date", Default: strptr("today()")},
+	}
+
+	tests := []struct {
+		name        string
+		current     Table
+		desired     Table
+		clusterName *string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:    "No change",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: table(columns, strptr("id"), nil, nil, ""),
+			want:    []string{},
+		},
+		{
+			name:    "Add column to sorting key",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: table(append(append([]TableColumn{}, columns...), TableColumn{Name: "v", Type: "String"}), strptr("(id, v)"), nil, nil, ""),
+			want: []string{
+				"ALTER TABLE `db`.`tbl` ADD COLUMN `v` String AFTER `d`, MODIFY ORDER BY (id, v);",
+			},
+		},
+		{
+			name:    "Drop and modify columns on cluster",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: table([]TableColumn{
+				{Name: "id", Type: "UInt64", Codec: strptr("ZSTD(1)"), Comment: strptr("identifier")},
+			}, strptr("id"), nil, nil, ""),
+			clusterName: strptr("cluster1"),
+			want: []string{
+				"ALTER TABLE `db`.`tbl` ON CLUSTER 'cluster1' DROP COLUMN `d`, MODIFY COLUMN `id` UInt64 CODEC(ZSTD(1)) COMMENT 'identifier';",
+			},
+		},
+		{
+			name:    "Remove default",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: table([]TableColumn{
+				{Name: "id", Type: "UInt64"},
+				{Name: "d", Type: "Date"},
+			}, strptr("id"), nil, nil, ""),
+			want: []string{
+				"ALTER TABLE `db`.`tbl` MODIFY COLUMN `d` REMOVE DEFAULT;",
+			},
+		},
+		{
+			name:    "Reorder columns",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: table([]TableColumn{columns[1], columns[0]}, strptr("id"), nil, nil, ""),
+			want: []string{
+				"ALTER TABLE `db`.`tbl` MODIFY COLUMN `d` FIRST;",
+			},
+		},
+		{
+			name:    "Add first column",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: table(append([]TableColumn{{Name: "v", Type: "String"}}, columns...), strptr("id"), nil, nil, ""),
+			want: []string{
+				"ALTER TABLE `db`.`tbl` ADD COLUMN `v` String FIRST;",
+			},
+		},
+		{
+			name:    "TTL, settings and comment",
+			current: table(columns, strptr("id"), strptr("d + INTERVAL 1 DAY"), map[string]string{"index_granularity": "8192", "merge_with_ttl_timeout": "3600"}, ""),
+			desired: table(columns, strptr("id"), nil, map[string]string{"index_granularity": "1024"}, "events"),
+			want: []string{
+				"ALTER TABLE `db`.`tbl` REMOVE TTL;",
+				"ALTER TABLE `db`.`tbl` MODIFY SETTING `index_granularity` = '1024';",
+				"ALTER TABLE `db`.`tbl` RESET SETTING `merge_with_ttl_timeout`;",
+				"ALTER TABLE `db`.`tbl` MODIFY COMMENT 'events';",
+			},
+		},
+		{
+			name:    "Rename column",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: table([]TableColumn{
+				{Name: "id", Type: "UInt64"},
+				{Name: "day", Type: "Date", Default: strptr("today()"), PreviousName: strptr("d")},
+			}, strptr("id"), nil, nil, ""),
+			want: []string{
+				"ALTER TABLE `db`.`tbl` RENAME COLUMN `d` TO `day`;",
+			},
+		},
+		{
+			name:    "Previous name of an already renamed column",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: table([]TableColumn{
+				{Name: "id", Type: "UInt64", PreviousName: strptr("uid")},
+				{Name: "d", Type: "Date", Default: strptr("today()")},
+			}, strptr("id"), nil, nil, ""),
+			want: []string{},
+		},
+		{
+			name:    "Rename table and column on cluster",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: Table{
+				Database: "db",
+				Name:     "tbl_v2",
+				Columns: []TableColumn{
+					{Name: "id", Type: "UInt64"},
+					{Name: "day", Type: "Date", PreviousName: strptr("d")},
+				},
+				Engine:  "MergeTree",
+				OrderBy: strptr("id"),
+			},
+			clusterName: strptr("cluster1"),
+			want: []string{
+				"RENAME TABLE `db`.`tbl` TO `db`.`tbl_v2` ON CLUSTER 'cluster1';",
+				"ALTER TABLE `db`.`tbl_v2` ON CLUSTER 'cluster1' RENAME COLUMN `d` TO `day`;",
+				"ALTER TABLE `db`.`tbl_v2` ON CLUSTER 'cluster1' MODIFY COLUMN `day` REMOVE DEFAULT;",
+			},
+		},
+		{
+			name:    "Engine change",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: Table{Database: "db", Name: "tbl", Columns: columns, Engine: "ReplacingMergeTree", OrderBy: strptr("id")},
+			wantErr: true,
+		},
+		{
+			name:    "Sorting key removal",
+			current: table(columns, strptr("id"), nil, nil, ""),
+			desired: table(columns, nil, nil, nil, ""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tableAlterQueries(tt.current, tt.desired, tt.clusterName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tableAlterQueries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tableAlterQueries() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,6 +14,11 @@ type User struct {
 	Name               string   `json:"name"`
 	PasswordSha256Hash string   `json:"-"`
 	SettingsProfiles   []string `json:"-"`
+	// ValidUntil is the expiration date of the user credentials, 'infinity' for no expiration.
+	// When read from ClickHouse, it is formatted as 'YYYY-MM-DD hh:mm:ss' in the server time zone, and nil for no expiration.
+	ValidUntil *string `json:"-"`
+	// ValidUntilUTC is the expiration date read from ClickHouse, formatted as 'YYYY-MM-DD hh:mm:ss' in UTC.
+	ValidUntilUTC *string `json:"-"`
 }
 
 func (u *User) HasSettingProfile(profileName string) bool {
@@ -27,26 +32,46 @@ func (u *User) HasSettingProfile(profileName string) bool {
 }
 
 func (i *impl) CreateUser(ctx context.Context, user User, clusterName *string) (*User, error) {
+	if user.ValidUntil != nil {
+		err := i.CheckFeature(FeatureUserValidUntil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	sql, err := querybuilder.
 		NewCreateUser(user.Name).
 		Identified(querybuilder.IdentificationSHA256Hash, user.PasswordSha256Hash).
+		ValidUntil(user.ValidUntil).
 		WithCluster(clusterName).
 		Build()
 	if err != nil {
 		return nil, errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
-	if err != nil {
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
+	if err != nil && !createdOnMissingReplicas(err, errorCodeAccessEntityAlreadyExists) {
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	if i.dryRun {
+		return nil, nil
+	}
+
 	return i.FindUserByName(ctx, user.Name, clusterName)
 }
 
-func (i *impl) GetUser(ctx context.Context, id string, clusterName *string) (*User, error) { // nolint:dupl
+func (i *impl) GetUser(ctx context.Context, id string, clusterName *string) (*User, error) {
+	fields := []querybuilder.Field{querybuilder.NewField("name")}
+
+	// Older servers have no expiration date for users.
+	readValidUntil := i.CheckFeature(FeatureUserValidUntil) == nil
+	if readValidUntil {
+		fields = append(fields, querybuilder.NewField("valid_until").ToString(), querybuilder.NewUTCDateTimeField("valid_until", "valid_until_utc"))
+	}
+
 	sql, err := querybuilder.
-		NewSelect([]querybuilder.Field{querybuilder.NewField("name")}, "system.users").
+		NewSelect(fields, "system.users").
 		WithCluster(clusterName).
 		Where(querybuilder.WhereEquals("id", id)).
 		Build()
@@ -65,6 +90,18 @@ func (i *impl) GetUser(ctx context.Context, id string, clusterName *string) (*Us
 			ID:   id,
 			Name: n,
 		}
+
+		if readValidUntil {
+			user.ValidUntil, err = data.GetNullableString("valid_until")
+			if err != nil {
+				return errors.WithMessage(err, "error scanning query result, missing 'valid_until' field")
+			}
+			user.ValidUntilUTC, err = data.GetNullableString("valid_until_utc")
+			if err != nil {
+				return errors.WithMessage(err, "error scanning query result, missing 'valid_until_utc' field")
+			}
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -125,7 +162,7 @@ func (i *impl) DeleteUser(ctx context.Context, id string, clusterName *string) e
 		return errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return errors.WithMessage(err, "error running query")
 	}
@@ -157,10 +194,22 @@ func (i *impl) FindUserByName(ctx context.Context, name string, clusterName *str
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	// No user with such name found.
+	if uuid == "" {
+		return nil, nil
+	}
+
 	return i.GetUser(ctx, uuid, clusterName)
 }
 
 func (i *impl) UpdateUser(ctx context.Context, user User, clusterName *string) (*User, error) {
+	if user.ValidUntil != nil {
+		err := i.CheckFeature(FeatureUserValidUntil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Retrieve current user
 	existing, err := i.GetUser(ctx, user.ID, clusterName)
 	if err != nil {
@@ -171,15 +220,64 @@ func (i *impl) UpdateUser(ctx context.Context, user User, clusterName *string) (
 		NewAlterUser(existing.Name).
 		WithCluster(clusterName).
 		RenameTo(&user.Name).
+		ValidUntil(user.ValidUntil).
 		Build()
 	if err != nil {
 		return nil, errors.WithMessage(err, "error building query")
 	}
 
-	err = i.clickhouseClient.Exec(ctx, sql)
+	err = i.clickhouseClient.Exec(clickhouseclient.WithCluster(ctx, clusterName), sql)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error running query")
 	}
 
+	if i.dryRun {
+		return nil, nil
+	}
+
 	return i.GetUser(ctx, user.ID, clusterName)
 }
+
+// ListUsers returns all users defined in system.users, sorted by name.
+func (i *impl) ListUsers(ctx context.Context, clusterName *string) ([]User, error) {
+	sql, err := querybuilder.
+		NewSelect([]querybuilder.Field{querybuilder.NewField("id").ToString(), querybuilder.NewField("name")}, "system.users").
+		WithCluster(clusterName).
+		OrderBy(querybuilder.NewField("name"), querybuilder.ASC).
+		Build()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error building query")
+	}
+
+	ret := make([]User, 0)
+	seen := make(map[string]bool)
+
+	err = i.clickhouseClient.Select(ctx, sql, func(data clickhouseclient.Row) error {
+		id, err := data.GetString("id")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'id' field")
+		}
+		name, err := data.GetString("name")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'name' field")
+		}
+
+		// When querying a cluster, the same user is returned once per replica.
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+
+		ret = append(ret, User{
+			ID:   id,
+			Name: name,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,106 @@
+package dbops
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+)
+
+// Version is a ClickHouse server version, like 24.8.4.13.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	Build int
+}
+
+// ParseVersion parses the output of the version() function.
+func ParseVersion(s string) (*Version, error) {
+	parts := strings.Split(strings.TrimSpace(s), ".")
+	if len(parts) < 2 {
+		return nil, errors.New(fmt.Sprintf("invalid version %q", s))
+	}
+
+	numbers := make([]int, 4)
+	for i := 0; i < len(parts) && i < len(numbers); i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid version %q", s))
+		}
+		numbers[i] = n
+	}
+
+	return &Version{
+		Major: numbers[0],
+		Minor: numbers[1],
+		Patch: numbers[2],
+		Build: numbers[3],
+	}, nil
+}
+
+// AtLeast returns true if v is the same or a later release than major.minor.
+func (v *Version) AtLeast(major int, minor int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+
+	return v.Minor >= minor
+}
+
+func (v *Version) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.Patch, v.Build)
+}
+
+// Feature is a ClickHouse functionality only available starting from a given release.
+type Feature struct {
+	Name     string
+	MinMajor int
+	MinMinor int
+}
+
+var (
+	FeatureUserValidUntil                = Feature{Name: "VALID UNTIL clause for users", MinMajor: 23, MinMinor: 9}
+	FeatureFormatQuery                   = Feature{Name: "formatQuerySingleLine function", MinMajor: 23, MinMinor: 10}
+	FeatureMultipleAuthenticationMethods = Feature{Name: "multiple authentication methods for users", MinMajor: 24, MinMinor: 9}
+)
+
+// CheckFeature returns an error explaining the minimum version required if the server doesn't support feature.
+// The server version is always known, as NewClient fails when it can't be detected.
+func (i *impl) CheckFeature(feature Feature) error {
+	if i.serverVersion.AtLeast(feature.MinMajor, feature.MinMinor) {
+		return nil
+	}
+
+	return errors.New(fmt.Sprintf("%s requires ClickHouse %d.%d or later, but server version is %s", feature.Name, feature.MinMajor, feature.MinMinor, i.serverVersion.String()))
+}
+
+// versionQuery returns the version of the server answering the query.
+const versionQuery = "SELECT version() AS version;"
+
+func (i *impl) detectServerVersion(ctx context.Context) (*Version, error) {
+	var version *Version
+	err := i.clickhouseClient.Select(ctx, versionQuery, func(data clickhouseclient.Row) error {
+		v, err := data.GetString("version")
+		if err != nil {
+			return errors.WithMessage(err, "error scanning query result, missing 'version' field")
+		}
+
+		version, err = ParseVersion(v)
+
+		return err
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error running query")
+	}
+
+	if version == nil {
+		return nil, errors.New("version() returned no rows")
+	}
+
+	return version, nil
+}
@@ -0,0 +1,82 @@
+package dbops
+
+import (
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    Version
+		wantErr bool
+	}{
+		{
+			name:    "Full version",
+			version: "24.8.4.13",
+			want:    Version{Major: 24, Minor: 8, Patch: 4, Build: 13},
+		},
+		{
+			name:    "Major and minor only",
+			version: "25.3",
+			want:    Version{Major: 25, Minor: 3},
+		},
+		{
+			name:    "Trailing newline",
+			version: "23.8.16.40\n",
+			want:    Version{Major: 23, Minor: 8, Patch: 16, Build: 40},
+		},
+		{
+			name:    "Missing minor",
+			version: "24",
+			wantErr: true,
+		},
+		{
+			name:    "Not a number",
+			version: "24.x.1.2",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("ParseVersion() got = %v, want %v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckFeature(t *testing.T) {
+	tests := []struct {
+		name    string
+		version *Version
+		wantErr bool
+	}{
+		{
+			name:    "Older minor",
+			version: &Version{Major: 23, Minor: 8},
+			wantErr: true,
+		},
+		{
+			name:    "Same minor",
+			version: &Version{Major: 23, Minor: 9},
+		},
+		{
+			name:    "Newer major",
+			version: &Version{Major: 24, Minor: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &impl{serverVersion: tt.version}
+			err := i.CheckFeature(FeatureUserValidUntil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckFeature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
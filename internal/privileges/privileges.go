@@ -0,0 +1,119 @@
+package privileges
+
+import (
+	"bufio"
+	_ "embed"
+	"log"
+	"strings"
+	"sync"
+)
+
+//go:generate curl -so grants.tsv https://raw.githubusercontent.com/ClickHouse/ClickHouse/master/tests/queries/0_stateless/01271_show_privileges.reference
+//go:embed grants.tsv
+var grants string
+
+type AvailableGrants struct {
+	Aliases map[string]string   `json:"aliases"`
+	Groups  map[string][]string `json:"groups"`
+	Scopes  map[string]string   `json:"scopes"`
+}
+
+var parsed = sync.OnceValue(parseGrants)
+
+// ParseGrants returns information about all available permissions users can grant, as read from the grants.tsv file.
+// The .tsv file comes from clickhouse core code and should be updated every time there is a change in permissions upstream.
+// information returned by this function is used for validation of user inputs.
+func ParseGrants() AvailableGrants {
+	return parsed()
+}
+
+func parseGrants() AvailableGrants {
+	aliases := make(map[string]string)
+	groups := make(map[string][]string)
+	scopes := make(map[string]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(grants))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		splitted := strings.Split(line, "\t")
+
+		clean := strings.ReplaceAll(strings.Trim(splitted[1], "[]"), "'", "")
+		if clean != "" {
+			for _, a := range strings.Split(clean, ",") {
+				if a != splitted[0] {
+					aliases[a] = splitted[0]
+				}
+			}
+		}
+
+		if splitted[3] != "\\N" {
+			if groups[splitted[3]] == nil {
+				groups[splitted[3]] = make([]string, 0)
+			}
+			groups[splitted[3]] = append(groups[splitted[3]], splitted[0])
+		}
+
+		if splitted[2] != "\\N" {
+			scopes[splitted[0]] = splitted[2]
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	ret := AvailableGrants{
+		Aliases: aliases,
+		Groups:  groups,
+		Scopes:  scopes,
+	}
+
+	return ret
+}
+
+// Canonical returns the privilege name the given alias refers to, or the given name itself if it is not an alias.
+func (a AvailableGrants) Canonical(privilege string) string {
+	if name := a.Aliases[privilege]; name != "" {
+		return name
+	}
+
+	return privilege
+}
+
+// Includes returns true if granting `broader` implicitly grants `narrower` as well,
+// either because they are the same privilege or because `broader` is a group containing `narrower`, at any depth.
+func (a AvailableGrants) Includes(broader string, narrower string) bool {
+	broader = a.Canonical(broader)
+	narrower = a.Canonical(narrower)
+
+	if broader == narrower {
+		return true
+	}
+
+	for _, member := range a.Groups[broader] {
+		if a.Includes(member, narrower) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Leaves returns all the privileges that are not groups and are included in the given privilege.
+// If the given privilege is not a group, the returned slice only contains the privilege itself.
+func (a AvailableGrants) Leaves(privilege string) []string {
+	privilege = a.Canonical(privilege)
+
+	members := a.Groups[privilege]
+	if len(members) == 0 {
+		return []string{privilege}
+	}
+
+	ret := make([]string, 0)
+	for _, member := range members {
+		ret = append(ret, a.Leaves(member)...)
+	}
+
+	return ret
+}
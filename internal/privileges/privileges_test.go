@@ -0,0 +1,136 @@
+package privileges
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestAvailableGrants_Canonical(t *testing.T) {
+	tests := []struct {
+		name      string
+		privilege string
+		want      string
+	}{
+		{
+			name:      "Alias",
+			privilege: "UPDATE",
+			want:      "ALTER UPDATE",
+		},
+		{
+			name:      "Alias of a group",
+			privilege: "ALL PRIVILEGES",
+			want:      "ALL",
+		},
+		{
+			name:      "Not an alias",
+			privilege: "SELECT",
+			want:      "SELECT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseGrants().Canonical(tt.privilege); got != tt.want {
+				t.Errorf("Canonical() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvailableGrants_Includes(t *testing.T) {
+	tests := []struct {
+		name     string
+		broader  string
+		narrower string
+		want     bool
+	}{
+		{
+			name:     "Same privilege",
+			broader:  "SELECT",
+			narrower: "SELECT",
+			want:     true,
+		},
+		{
+			name:     "Direct member of group",
+			broader:  "ALL",
+			narrower: "SELECT",
+			want:     true,
+		},
+		{
+			name:     "Nested member of group",
+			broader:  "ALL",
+			narrower: "ALTER UPDATE",
+			want:     true,
+		},
+		{
+			name:     "Alias of nested member of group",
+			broader:  "ALTER TABLE",
+			narrower: "UPDATE",
+			want:     true,
+		},
+		{
+			name:     "Alias of group",
+			broader:  "ALL PRIVILEGES",
+			narrower: "INSERT",
+			want:     true,
+		},
+		{
+			name:     "Member does not include group",
+			broader:  "SELECT",
+			narrower: "ALL",
+			want:     false,
+		},
+		{
+			name:     "Unrelated privileges",
+			broader:  "SELECT",
+			narrower: "INSERT",
+			want:     false,
+		},
+		{
+			name:     "Sibling groups",
+			broader:  "ALTER COLUMN",
+			narrower: "ALTER INDEX",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseGrants().Includes(tt.broader, tt.narrower); got != tt.want {
+				t.Errorf("Includes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvailableGrants_Leaves(t *testing.T) {
+	tests := []struct {
+		name      string
+		privilege string
+		want      []string
+	}{
+		{
+			name:      "Not a group",
+			privilege: "SELECT",
+			want:      []string{"SELECT"},
+		},
+		{
+			name:      "Group",
+			privilege: "SHOW",
+			want:      []string{"SHOW COLUMNS", "SHOW DATABASES", "SHOW DICTIONARIES", "SHOW TABLES"},
+		},
+		{
+			name:      "Alias of a member",
+			privilege: "UPDATE",
+			want:      []string{"ALTER UPDATE"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseGrants().Leaves(tt.privilege)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Leaves() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
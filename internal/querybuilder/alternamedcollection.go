@@ -0,0 +1,82 @@
+package querybuilder
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// AlterNamedCollectionQueryBuilder is an interface to build ALTER NAMED COLLECTION SQL queries (already interpolated).
+// Only the keys that are set or deleted are changed.
+type AlterNamedCollectionQueryBuilder interface {
+	QueryBuilder
+	WithCluster(clusterName *string) AlterNamedCollectionQueryBuilder
+	Set(values map[string]string) AlterNamedCollectionQueryBuilder
+	Delete(keys []string) AlterNamedCollectionQueryBuilder
+}
+
+type alterNamedCollectionQueryBuilder struct {
+	resourceName string
+	clusterName  *string
+	set          map[string]string
+	delete       []string
+}
+
+func NewAlterNamedCollection(resourceName string) AlterNamedCollectionQueryBuilder {
+	return &alterNamedCollectionQueryBuilder{
+		resourceName: resourceName,
+	}
+}
+
+func (q *alterNamedCollectionQueryBuilder) WithCluster(clusterName *string) AlterNamedCollectionQueryBuilder {
+	q.clusterName = clusterName
+	return q
+}
+
+// Set adds the given keys to the collection, or changes their value if they are already there.
+func (q *alterNamedCollectionQueryBuilder) Set(values map[string]string) AlterNamedCollectionQueryBuilder {
+	q.set = values
+	return q
+}
+
+// Delete removes the given keys from the collection.
+func (q *alterNamedCollectionQueryBuilder) Delete(keys []string) AlterNamedCollectionQueryBuilder {
+	q.delete = keys
+	return q
+}
+
+func (q *alterNamedCollectionQueryBuilder) Build() (string, error) {
+	if q.resourceName == "" {
+		return "", errors.New("resourceName cannot be empty for ALTER NAMED COLLECTION queries")
+	}
+
+	anyChanges := false
+
+	tokens := []string{
+		"ALTER",
+		"NAMED",
+		"COLLECTION",
+		backtick(q.resourceName),
+	}
+	if q.clusterName != nil {
+		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
+	}
+
+	if len(q.set) > 0 {
+		anyChanges = true
+		tokens = append(tokens, "SET", namedCollectionValuesSQLDef(q.set))
+	}
+	if len(q.delete) > 0 {
+		anyChanges = true
+		keys := append([]string{}, q.delete...)
+		sort.Strings(keys)
+		tokens = append(tokens, "DELETE", strings.Join(backtickAll(keys), ", "))
+	}
+
+	if !anyChanges {
+		return "", errors.New("no change to be made")
+	}
+
+	return strings.Join(tokens, " ") + ";", nil
+}
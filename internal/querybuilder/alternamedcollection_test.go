@@ -0,0 +1,76 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_alternamedcollection(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceName string
+		set          map[string]string
+		delete       []string
+		clusterName  string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Set values",
+			resourceName: "s3_data",
+			set:          map[string]string{"url": "https://bucket.s3.amazonaws.com/data/", "format": "Parquet"},
+			want:         "ALTER NAMED COLLECTION `s3_data` SET `format` = 'Parquet', `url` = 'https://bucket.s3.amazonaws.com/data/';",
+			wantErr:      false,
+		},
+		{
+			name:         "Delete keys on cluster",
+			resourceName: "s3_data",
+			delete:       []string{"url", "format"},
+			clusterName:  "cluster1",
+			want:         "ALTER NAMED COLLECTION `s3_data` ON CLUSTER 'cluster1' DELETE `format`, `url`;",
+			wantErr:      false,
+		},
+		{
+			name:         "Set and delete",
+			resourceName: "s3_data",
+			set:          map[string]string{"secret_access_key": "new"},
+			delete:       []string{"format"},
+			want:         "ALTER NAMED COLLECTION `s3_data` SET `secret_access_key` = 'new' DELETE `format`;",
+			wantErr:      false,
+		},
+		{
+			name:         "No changes",
+			resourceName: "s3_data",
+			set:          map[string]string{},
+			delete:       []string{},
+			wantErr:      true,
+		},
+		{
+			name:    "Fails without name",
+			set:     map[string]string{"key": "value"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q AlterNamedCollectionQueryBuilder
+			q = &alterNamedCollectionQueryBuilder{
+				resourceName: tt.resourceName,
+				set:          tt.set,
+				delete:       tt.delete,
+			}
+
+			if tt.clusterName != "" {
+				q = q.WithCluster(&tt.clusterName)
+			}
+
+			got, err := q.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package querybuilder
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// AlterQuotaQueryBuilder is an interface to build ALTER QUOTA SQL queries (already interpolated).
+// Only the parts that are set are changed.
+type AlterQuotaQueryBuilder interface {
+	QueryBuilder
+	WithCluster(clusterName *string) AlterQuotaQueryBuilder
+	RenameTo(newName *string) AlterQuotaQueryBuilder
+	KeyedBy(keys []string) AlterQuotaQueryBuilder
+	ForIntervals(intervals []QuotaInterval) AlterQuotaQueryBuilder
+	DropIntervals(intervals []QuotaInterval) AlterQuotaQueryBuilder
+	To(rolesOrUsers []string) AlterQuotaQueryBuilder
+}
+
+type alterQuotaQueryBuilder struct {
+	resourceName  string
+	clusterName   *string
+	newName       *string
+	keys          []string
+	intervals     []QuotaInterval
+	dropIntervals []QuotaInterval
+	to            []string
+}
+
+func NewAlterQuota(resourceName string) AlterQuotaQueryBuilder {
+	return &alterQuotaQueryBuilder{
+		resourceName: resourceName,
+	}
+}
+
+func (q *alterQuotaQueryBuilder) WithCluster(clusterName *string) AlterQuotaQueryBuilder {
+	q.clusterName = clusterName
+	return q
+}
+
+func (q *alterQuotaQueryBuilder) RenameTo(newName *string) AlterQuotaQueryBuilder {
+	q.newName = newName
+	return q
+}
+
+// KeyedBy replaces the keys the quota is tracked by. A nil slice leaves them unchanged, an empty one means NOT KEYED.
+func (q *alterQuotaQueryBuilder) KeyedBy(keys []string) AlterQuotaQueryBuilder {
+	q.keys = keys
+	return q
+}
+
+// ForIntervals adds the given intervals, or replaces the existing ones with the same duration. Existing intervals are
+// removed first, as ALTER QUOTA only overwrites the limits it lists and would keep the other ones.
+func (q *alterQuotaQueryBuilder) ForIntervals(intervals []QuotaInterval) AlterQuotaQueryBuilder {
+	q.intervals = intervals
+	return q
+}
+
+// DropIntervals removes the given intervals, only their duration and whether they are randomized are used.
+func (q *alterQuotaQueryBuilder) DropIntervals(intervals []QuotaInterval) AlterQuotaQueryBuilder {
+	q.dropIntervals = intervals
+	return q
+}
+
+// To replaces the roles and users the quota applies to. A nil slice leaves them unchanged, an empty one removes all.
+func (q *alterQuotaQueryBuilder) To(rolesOrUsers []string) AlterQuotaQueryBuilder {
+	q.to = rolesOrUsers
+	return q
+}
+
+func (q *alterQuotaQueryBuilder) Build() (string, error) {
+	if q.resourceName == "" {
+		return "", errors.New("resourceName cannot be empty for ALTER QUOTA queries")
+	}
+
+	anyChanges := false
+
+	tokens := []string{
+		"ALTER",
+		"QUOTA",
+		backtick(q.resourceName),
+	}
+	if q.clusterName != nil {
+		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
+	}
+	if q.newName != nil && *q.newName != q.resourceName {
+		anyChanges = true
+		tokens = append(tokens, "RENAME", "TO", backtick(*q.newName))
+	}
+	if q.keys != nil {
+		anyChanges = true
+		tokens = append(tokens, keyedBySQLDef(q.keys))
+	}
+
+	intervals := make([]string, 0, 2*len(q.intervals)+len(q.dropIntervals))
+	for _, interval := range q.dropIntervals {
+		intervals = append(intervals, dropQuotaIntervalSQLDef(interval))
+	}
+	for _, interval := range q.intervals {
+		def, err := interval.SQLDef()
+		if err != nil {
+			return "", errors.WithMessage(err, "invalid quota interval")
+		}
+		intervals = append(intervals, dropQuotaIntervalSQLDef(interval), def)
+	}
+	if len(intervals) > 0 {
+		anyChanges = true
+		tokens = append(tokens, strings.Join(intervals, ", "))
+	}
+
+	if q.to != nil {
+		anyChanges = true
+		if len(q.to) == 0 {
+			tokens = append(tokens, "TO", "NONE")
+		} else {
+			tokens = append(tokens, "TO", strings.Join(backtickAll(q.to), ", "))
+		}
+	}
+
+	if !anyChanges {
+		return "", errors.New("no change to be made")
+	}
+
+	return strings.Join(tokens, " ") + ";", nil
+}
@@ -0,0 +1,125 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_alterquota(t *testing.T) {
+	tests := []struct {
+		name          string
+		resourceName  string
+		clusterName   string
+		newName       *string
+		keys          []string
+		intervals     []QuotaInterval
+		dropIntervals []QuotaInterval
+		to            []string
+		want          string
+		wantErr       bool
+	}{
+		{
+			name:         "Rename on cluster",
+			resourceName: "limited",
+			clusterName:  "cluster1",
+			newName:      strPtr("capped"),
+			want:         "ALTER QUOTA `limited` ON CLUSTER 'cluster1' RENAME TO `capped`;",
+			wantErr:      false,
+		},
+		{
+			name:         "Remove keys",
+			resourceName: "limited",
+			keys:         []string{},
+			want:         "ALTER QUOTA `limited` NOT KEYED;",
+			wantErr:      false,
+		},
+		{
+			name:         "Replace and drop intervals",
+			resourceName: "limited",
+			intervals: []QuotaInterval{
+				{DurationSeconds: 60, Limits: []QuotaLimit{{Resource: "errors", Max: "10"}}},
+			},
+			dropIntervals: []QuotaInterval{{DurationSeconds: 3600}},
+			want:          "ALTER QUOTA `limited` FOR INTERVAL 3600 second NO LIMITS, FOR INTERVAL 60 second NO LIMITS, FOR INTERVAL 60 second MAX errors = 10;",
+			wantErr:       false,
+		},
+		{
+			name:         "Replaced intervals lose the limits not listed anymore",
+			resourceName: "limited",
+			intervals: []QuotaInterval{
+				{DurationSeconds: 60, Limits: []QuotaLimit{{Resource: "queries", Max: "100"}}},
+				{DurationSeconds: 3600},
+			},
+			want:    "ALTER QUOTA `limited` FOR INTERVAL 60 second NO LIMITS, FOR INTERVAL 60 second MAX queries = 100, FOR INTERVAL 3600 second NO LIMITS, FOR INTERVAL 3600 second TRACKING ONLY;",
+			wantErr: false,
+		},
+		{
+			name:         "Drop randomized interval",
+			resourceName: "limited",
+			dropIntervals: []QuotaInterval{
+				{DurationSeconds: 3600, Randomized: true},
+			},
+			want:    "ALTER QUOTA `limited` FOR RANDOMIZED INTERVAL 3600 second NO LIMITS;",
+			wantErr: false,
+		},
+		{
+			name:         "Replace randomized interval",
+			resourceName: "limited",
+			intervals: []QuotaInterval{
+				{DurationSeconds: 60, Randomized: true, Limits: []QuotaLimit{{Resource: "queries", Max: "100"}}},
+			},
+			want:    "ALTER QUOTA `limited` FOR RANDOMIZED INTERVAL 60 second NO LIMITS, FOR RANDOMIZED INTERVAL 60 second MAX queries = 100;",
+			wantErr: false,
+		},
+		{
+			name:         "Change roles",
+			resourceName: "limited",
+			to:           []string{"reader"},
+			want:         "ALTER QUOTA `limited` TO `reader`;",
+			wantErr:      false,
+		},
+		{
+			name:         "Remove all roles",
+			resourceName: "limited",
+			to:           []string{},
+			want:         "ALTER QUOTA `limited` TO NONE;",
+			wantErr:      false,
+		},
+		{
+			name:         "Rename to the same name is no change",
+			resourceName: "limited",
+			newName:      strPtr("limited"),
+			wantErr:      true,
+		},
+		{
+			name:         "No changes",
+			resourceName: "limited",
+			wantErr:      true,
+		},
+		{
+			name:    "Empty name",
+			keys:    []string{"user_name"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q AlterQuotaQueryBuilder
+			q = &alterQuotaQueryBuilder{
+				resourceName: tt.resourceName,
+			}
+
+			if tt.clusterName != "" {
+				q = q.WithCluster(&tt.clusterName)
+			}
+
+			got, err := q.RenameTo(tt.newName).KeyedBy(tt.keys).ForIntervals(tt.intervals).DropIntervals(tt.dropIntervals).To(tt.to).Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
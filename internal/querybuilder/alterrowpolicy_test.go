@@ -0,0 +1,95 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_alterrowpolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceName string
+		clusterName  string
+		newName      *string
+		condition    *string
+		restrictive  *bool
+		to           []string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Change condition",
+			resourceName: "tenant",
+			condition:    strPtr("tenant_id = 2"),
+			want:         "ALTER ROW POLICY `tenant` ON `db`.`events` FOR SELECT USING tenant_id = 2;",
+			wantErr:      false,
+		},
+		{
+			name:         "Change roles",
+			resourceName: "tenant",
+			to:           []string{"reader", "writer"},
+			want:         "ALTER ROW POLICY `tenant` ON `db`.`events` TO `reader`, `writer`;",
+			wantErr:      false,
+		},
+		{
+			name:         "Remove all roles",
+			resourceName: "tenant",
+			to:           []string{},
+			want:         "ALTER ROW POLICY `tenant` ON `db`.`events` TO NONE;",
+			wantErr:      false,
+		},
+		{
+			name:         "Rename and change kind on cluster",
+			resourceName: "tenant",
+			clusterName:  "cluster1",
+			newName:      strPtr("tenant2"),
+			restrictive:  func() *bool { b := true; return &b }(),
+			want:         "ALTER ROW POLICY `tenant` ON CLUSTER 'cluster1' ON `db`.`events` RENAME TO `tenant2` AS RESTRICTIVE;",
+			wantErr:      false,
+		},
+		{
+			name:         "Rename to the same name is no change",
+			resourceName: "tenant",
+			newName:      strPtr("tenant"),
+			wantErr:      true,
+		},
+		{
+			name:         "Empty condition",
+			resourceName: "tenant",
+			condition:    strPtr(""),
+			wantErr:      true,
+		},
+		{
+			name:         "No changes",
+			resourceName: "tenant",
+			wantErr:      true,
+		},
+		{
+			name:      "Empty name",
+			condition: strPtr("1"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q AlterRowPolicyQueryBuilder
+			q = &alterRowPolicyQueryBuilder{
+				resourceName: tt.resourceName,
+				databaseName: "db",
+				tableName:    "events",
+			}
+
+			if tt.clusterName != "" {
+				q = q.WithCluster(&tt.clusterName)
+			}
+
+			got, err := q.RenameTo(tt.newName).Using(tt.condition).Restrictive(tt.restrictive).To(tt.to).Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
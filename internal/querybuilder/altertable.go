@@ -0,0 +1,167 @@
+package querybuilder
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// Column properties that can be removed with ALTER TABLE ... MODIFY COLUMN ... REMOVE.
+const (
+	ColumnPropertyDefault = "DEFAULT"
+	ColumnPropertyCodec   = "CODEC"
+	ColumnPropertyComment = "COMMENT"
+)
+
+// AlterTableQueryBuilder is an interface to build ALTER TABLE SQL queries (already interpolated).
+// Commands are run in the order they are added.
+type AlterTableQueryBuilder interface {
+	QueryBuilder
+	WithCluster(clusterName *string) AlterTableQueryBuilder
+	AddColumn(column TableColumn, after *string) AlterTableQueryBuilder
+	DropColumn(name string) AlterTableQueryBuilder
+	RenameColumn(from string, to string) AlterTableQueryBuilder
+	ModifyColumn(column TableColumn) AlterTableQueryBuilder
+	MoveColumn(name string, after *string) AlterTableQueryBuilder
+	RemoveColumnProperty(name string, property string) AlterTableQueryBuilder
+	ModifyOrderBy(expression string) AlterTableQueryBuilder
+	ModifyTTL(expression *string) AlterTableQueryBuilder
+	ModifySettings(settings map[string]string) AlterTableQueryBuilder
+	ResetSettings(names []string) AlterTableQueryBuilder
+	ModifyComment(comment string) AlterTableQueryBuilder
+}
+
+type alterTableQueryBuilder struct {
+	databaseName string
+	tableName    string
+	clusterName  *string
+	commands     []string
+	// err is the first error found while adding commands, returned by Build.
+	err error
+}
+
+func NewAlterTable(databaseName string, tableName string) AlterTableQueryBuilder {
+	return &alterTableQueryBuilder{
+		databaseName: databaseName,
+		tableName:    tableName,
+	}
+}
+
+func (q *alterTableQueryBuilder) WithCluster(clusterName *string) AlterTableQueryBuilder {
+	q.clusterName = clusterName
+	return q
+}
+
+func (q *alterTableQueryBuilder) add(command string, err error) AlterTableQueryBuilder {
+	if err != nil {
+		if q.err == nil {
+			q.err = err
+		}
+		return q
+	}
+	q.commands = append(q.commands, command)
+	return q
+}
+
+// AddColumn adds column after the one called after, or as the first column when after is nil.
+func (q *alterTableQueryBuilder) AddColumn(column TableColumn, after *string) AlterTableQueryBuilder {
+	def, err := column.SQLDef()
+	return q.add("ADD COLUMN "+def+" "+columnPosition(after), err)
+}
+
+func (q *alterTableQueryBuilder) DropColumn(name string) AlterTableQueryBuilder {
+	return q.add("DROP COLUMN "+backtick(name), nil)
+}
+
+// RenameColumn renames the column called from to to, keeping its data.
+func (q *alterTableQueryBuilder) RenameColumn(from string, to string) AlterTableQueryBuilder {
+	return q.add("RENAME COLUMN "+backtick(from)+" TO "+backtick(to), nil)
+}
+
+// ModifyColumn changes the type of column and sets its default, codec and comment when not nil.
+// Properties left nil are not changed, use RemoveColumnProperty to remove them.
+func (q *alterTableQueryBuilder) ModifyColumn(column TableColumn) AlterTableQueryBuilder {
+	def, err := column.SQLDef()
+	return q.add("MODIFY COLUMN "+def, err)
+}
+
+// MoveColumn moves the column called name after the one called after, or first when after is nil.
+func (q *alterTableQueryBuilder) MoveColumn(name string, after *string) AlterTableQueryBuilder {
+	return q.add("MODIFY COLUMN "+backtick(name)+" "+columnPosition(after), nil)
+}
+
+func (q *alterTableQueryBuilder) RemoveColumnProperty(name string, property string) AlterTableQueryBuilder {
+	switch property {
+	case ColumnPropertyDefault, ColumnPropertyCodec, ColumnPropertyComment:
+		return q.add("MODIFY COLUMN "+backtick(name)+" REMOVE "+property, nil)
+	default:
+		return q.add("", errors.Errorf("unsupported column property %q", property))
+	}
+}
+
+func (q *alterTableQueryBuilder) ModifyOrderBy(expression string) AlterTableQueryBuilder {
+	if expression == "" {
+		return q.add("", errors.New("expression cannot be empty for MODIFY ORDER BY"))
+	}
+	return q.add("MODIFY ORDER BY "+expression, nil)
+}
+
+// ModifyTTL sets the TTL of the table, or removes it when expression is nil.
+func (q *alterTableQueryBuilder) ModifyTTL(expression *string) AlterTableQueryBuilder {
+	if expression == nil {
+		return q.add("REMOVE TTL", nil)
+	}
+	return q.add("MODIFY TTL "+*expression, nil)
+}
+
+func (q *alterTableQueryBuilder) ModifySettings(settings map[string]string) AlterTableQueryBuilder {
+	if len(settings) == 0 {
+		return q.add("", errors.New("settings cannot be empty for MODIFY SETTING"))
+	}
+	return q.add("MODIFY SETTING "+tableSettingsSQLDef(settings), nil)
+}
+
+func (q *alterTableQueryBuilder) ResetSettings(names []string) AlterTableQueryBuilder {
+	if len(names) == 0 {
+		return q.add("", errors.New("names cannot be empty for RESET SETTING"))
+	}
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	return q.add("RESET SETTING "+strings.Join(backtickAll(sorted), ", "), nil)
+}
+
+func (q *alterTableQueryBuilder) ModifyComment(comment string) AlterTableQueryBuilder {
+	return q.add("MODIFY COMMENT "+quote(comment), nil)
+}
+
+func (q *alterTableQueryBuilder) Build() (string, error) {
+	if q.databaseName == "" || q.tableName == "" {
+		return "", errors.New("databaseName and tableName cannot be empty for ALTER TABLE queries")
+	}
+	if q.err != nil {
+		return "", errors.WithMessage(q.err, "error building ALTER TABLE command")
+	}
+	if len(q.commands) == 0 {
+		return "", errors.New("no change to be made")
+	}
+
+	tokens := []string{
+		"ALTER",
+		"TABLE",
+		backtick(q.databaseName) + "." + backtick(q.tableName),
+	}
+	if q.clusterName != nil {
+		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
+	}
+	tokens = append(tokens, strings.Join(q.commands, ", "))
+
+	return strings.Join(tokens, " ") + ";", nil
+}
+
+func columnPosition(after *string) string {
+	if after == nil {
+		return "FIRST"
+	}
+	return "AFTER " + backtick(*after)
+}
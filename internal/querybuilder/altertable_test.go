@@ -0,0 +1,109 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_altertable(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder AlterTableQueryBuilder
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Columns and sorting key",
+			builder: NewAlterTable("db", "events").
+				AddColumn(TableColumn{Name: "user_id", Type: "UInt64", Default: strptr("0")}, strptr("id")).
+				AddColumn(TableColumn{Name: "tenant", Type: "LowCardinality(String)"}, nil).
+				DropColumn("legacy").
+				ModifyColumn(TableColumn{Name: "payload", Type: "String", Codec: strptr("ZSTD(3)")}).
+				ModifyOrderBy("(id, user_id)"),
+			want:    "ALTER TABLE `db`.`events` ADD COLUMN `user_id` UInt64 DEFAULT 0 AFTER `id`, ADD COLUMN `tenant` LowCardinality(String) FIRST, DROP COLUMN `legacy`, MODIFY COLUMN `payload` String CODEC(ZSTD(3)), MODIFY ORDER BY (id, user_id);",
+			wantErr: false,
+		},
+		{
+			name: "Move columns on cluster",
+			builder: NewAlterTable("db", "events").
+				WithCluster(strptr("cluster1")).
+				MoveColumn("id", nil).
+				MoveColumn("payload", strptr("id")),
+			want:    "ALTER TABLE `db`.`events` ON CLUSTER 'cluster1' MODIFY COLUMN `id` FIRST, MODIFY COLUMN `payload` AFTER `id`;",
+			wantErr: false,
+		},
+		{
+			name:    "Rename columns",
+			builder: NewAlterTable("db", "events").RenameColumn("uid", "user_id").RenameColumn("ts", "created_at"),
+			want:    "ALTER TABLE `db`.`events` RENAME COLUMN `uid` TO `user_id`, RENAME COLUMN `ts` TO `created_at`;",
+			wantErr: false,
+		},
+		{
+			name:    "Remove column property",
+			builder: NewAlterTable("db", "events").RemoveColumnProperty("payload", ColumnPropertyCodec),
+			want:    "ALTER TABLE `db`.`events` MODIFY COLUMN `payload` REMOVE CODEC;",
+			wantErr: false,
+		},
+		{
+			name:    "Modify TTL",
+			builder: NewAlterTable("db", "events").ModifyTTL(strptr("created_at + INTERVAL 1 DAY")),
+			want:    "ALTER TABLE `db`.`events` MODIFY TTL created_at + INTERVAL 1 DAY;",
+			wantErr: false,
+		},
+		{
+			name:    "Remove TTL",
+			builder: NewAlterTable("db", "events").ModifyTTL(nil),
+			want:    "ALTER TABLE `db`.`events` REMOVE TTL;",
+			wantErr: false,
+		},
+		{
+			name:    "Modify settings",
+			builder: NewAlterTable("db", "events").ModifySettings(map[string]string{"ttl_only_drop_parts": "1", "merge_with_ttl_timeout": "3600"}),
+			want:    "ALTER TABLE `db`.`events` MODIFY SETTING `merge_with_ttl_timeout` = '3600', `ttl_only_drop_parts` = '1';",
+			wantErr: false,
+		},
+		{
+			name:    "Reset settings",
+			builder: NewAlterTable("db", "events").ResetSettings([]string{"ttl_only_drop_parts", "merge_with_ttl_timeout"}),
+			want:    "ALTER TABLE `db`.`events` RESET SETTING `merge_with_ttl_timeout`, `ttl_only_drop_parts`;",
+			wantErr: false,
+		},
+		{
+			name:    "Modify comment",
+			builder: NewAlterTable("db", "events").ModifyComment("It's events"),
+			want:    "ALTER TABLE `db`.`events` MODIFY COMMENT 'It\\'s events';",
+			wantErr: false,
+		},
+		{
+			name:    "Fails with invalid column",
+			builder: NewAlterTable("db", "events").ModifyComment("ok").AddColumn(TableColumn{Name: "id"}, nil),
+			wantErr: true,
+		},
+		{
+			name:    "Fails with unsupported column property",
+			builder: NewAlterTable("db", "events").RemoveColumnProperty("payload", "TTL"),
+			wantErr: true,
+		},
+		{
+			name:    "No changes",
+			builder: NewAlterTable("db", "events"),
+			wantErr: true,
+		},
+		{
+			name:    "Fails without table",
+			builder: NewAlterTable("db", "").ModifyComment("ok"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
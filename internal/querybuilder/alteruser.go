@@ -10,6 +10,7 @@ import (
 type AlterUserQueryBuilder interface {
 	QueryBuilder
 	RenameTo(newName *string) AlterUserQueryBuilder
+	ValidUntil(validUntil *string) AlterUserQueryBuilder
 	DropSettingsProfile(profileName *string) AlterUserQueryBuilder
 	AddSettingsProfile(profileName *string) AlterUserQueryBuilder
 	WithCluster(clusterName *string) AlterUserQueryBuilder
@@ -20,6 +21,7 @@ type alterUserQueryBuilder struct {
 	oldSettingsProfile *string
 	newSettingsProfile *string
 	newName            *string
+	validUntil         *string
 	clusterName        *string
 }
 
@@ -35,6 +37,12 @@ func (q *alterUserQueryBuilder) RenameTo(newName *string) AlterUserQueryBuilder
 	return q
 }
 
+// ValidUntil sets the expiration date of the user credentials. Use 'infinity' to remove it.
+func (q *alterUserQueryBuilder) ValidUntil(validUntil *string) AlterUserQueryBuilder {
+	q.validUntil = validUntil
+	return q
+}
+
 func (q *alterUserQueryBuilder) DropSettingsProfile(profileName *string) AlterUserQueryBuilder {
 	q.oldSettingsProfile = profileName
 	return q
@@ -71,6 +79,11 @@ func (q *alterUserQueryBuilder) Build() (string, error) {
 		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
 	}
 
+	if q.validUntil != nil {
+		anyChanges = true
+		tokens = append(tokens, "VALID", "UNTIL", quote(*q.validUntil))
+	}
+
 	if (q.oldSettingsProfile != nil && q.newSettingsProfile != nil && *q.oldSettingsProfile != *q.newSettingsProfile) ||
 		(q.oldSettingsProfile == nil && q.newSettingsProfile != nil) ||
 		(q.oldSettingsProfile != nil && q.newSettingsProfile == nil) {
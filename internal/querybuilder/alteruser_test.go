@@ -10,6 +10,7 @@ func Test_alterUserQueryBuilder_Build(t *testing.T) {
 		oldSettingsProfile *string
 		newSettingsProfile *string
 		newName            *string
+		validUntil         *string
 		clusterName        *string
 		want               string
 		wantErr            bool
@@ -55,6 +56,20 @@ func Test_alterUserQueryBuilder_Build(t *testing.T) {
 			want:               "ALTER USER `foo` ON CLUSTER 'cluster1' DROP PROFILES 'old' ADD PROFILES 'profile1';",
 			wantErr:            false,
 		},
+		{
+			name:       "Set expiration",
+			validUntil: strPtr("2030-01-01 00:00:00"),
+			want:       "ALTER USER `foo` VALID UNTIL '2030-01-01 00:00:00';",
+			wantErr:    false,
+		},
+		{
+			name:        "Change name and remove expiration on cluster",
+			newName:     strPtr("test"),
+			validUntil:  strPtr("infinity"),
+			clusterName: strPtr("cluster1"),
+			want:        "ALTER USER `foo` RENAME TO `test` ON CLUSTER 'cluster1' VALID UNTIL 'infinity';",
+			wantErr:     false,
+		},
 		{
 			name:    "No profile set",
 			want:    "",
@@ -81,6 +96,7 @@ func Test_alterUserQueryBuilder_Build(t *testing.T) {
 				oldSettingsProfile: tt.oldSettingsProfile,
 				newSettingsProfile: tt.newSettingsProfile,
 				newName:            tt.newName,
+				validUntil:         tt.validUntil,
 				clusterName:        tt.clusterName,
 			}
 			got, err := q.Build()
@@ -0,0 +1,56 @@
+package querybuilder
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// CreateFunctionQueryBuilder is an interface to build CREATE FUNCTION SQL queries (already interpolated).
+type CreateFunctionQueryBuilder interface {
+	QueryBuilder
+	WithCluster(clusterName *string) CreateFunctionQueryBuilder
+}
+
+type createFunctionQueryBuilder struct {
+	resourceName string
+	parameters   []string
+	body         string
+	clusterName  *string
+}
+
+// NewCreateFunction returns a builder for a SQL user defined function, defined as the (parameters) -> body lambda.
+// The body is a SQL expression added as is to the query.
+func NewCreateFunction(resourceName string, parameters []string, body string) CreateFunctionQueryBuilder {
+	return &createFunctionQueryBuilder{
+		resourceName: resourceName,
+		parameters:   parameters,
+		body:         body,
+	}
+}
+
+func (q *createFunctionQueryBuilder) WithCluster(clusterName *string) CreateFunctionQueryBuilder {
+	q.clusterName = clusterName
+	return q
+}
+
+func (q *createFunctionQueryBuilder) Build() (string, error) {
+	if q.resourceName == "" {
+		return "", errors.New("resourceName cannot be empty for CREATE FUNCTION queries")
+	}
+	if strings.TrimSpace(q.body) == "" {
+		return "", errors.New("body cannot be empty for CREATE FUNCTION queries")
+	}
+
+	tokens := []string{
+		"CREATE",
+		"FUNCTION",
+		backtick(q.resourceName),
+	}
+	if q.clusterName != nil {
+		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
+	}
+	tokens = append(tokens, "AS", "("+strings.Join(backtickAll(q.parameters), ", ")+")", "->", strings.TrimSpace(q.body))
+
+	return strings.Join(tokens, " ") + ";", nil
+}
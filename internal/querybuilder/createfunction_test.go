@@ -0,0 +1,77 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_createfunction(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceName string
+		parameters   []string
+		body         string
+		clusterName  string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Create function",
+			resourceName: "linear_equation",
+			parameters:   []string{"x", "k", "b"},
+			body:         "k*x + b",
+			want:         "CREATE FUNCTION `linear_equation` AS (`x`, `k`, `b`) -> k*x + b;",
+			wantErr:      false,
+		},
+		{
+			name:         "Create function without parameters on cluster",
+			resourceName: "answer",
+			body:         " 42\n",
+			clusterName:  "cluster1",
+			want:         "CREATE FUNCTION `answer` ON CLUSTER 'cluster1' AS () -> 42;",
+			wantErr:      false,
+		},
+		{
+			name:         "Create function with funky name",
+			resourceName: "my`func",
+			parameters:   []string{"x"},
+			body:         "x",
+			want:         "CREATE FUNCTION `my\\`func` AS (`x`) -> x;",
+			wantErr:      false,
+		},
+		{
+			name:         "Create function fails without body",
+			resourceName: "empty",
+			parameters:   []string{"x"},
+			body:         " ",
+			wantErr:      true,
+		},
+		{
+			name:    "Create function fails without name",
+			body:    "1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q CreateFunctionQueryBuilder
+			q = &createFunctionQueryBuilder{
+				resourceName: tt.resourceName,
+				parameters:   tt.parameters,
+				body:         tt.body,
+			}
+
+			if tt.clusterName != "" {
+				q = q.WithCluster(&tt.clusterName)
+			}
+
+			got, err := q.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
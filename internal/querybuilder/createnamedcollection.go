@@ -0,0 +1,72 @@
+package querybuilder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// CreateNamedCollectionQueryBuilder is an interface to build CREATE NAMED COLLECTION SQL queries (already interpolated).
+type CreateNamedCollectionQueryBuilder interface {
+	QueryBuilder
+	WithCluster(clusterName *string) CreateNamedCollectionQueryBuilder
+}
+
+type createNamedCollectionQueryBuilder struct {
+	resourceName string
+	values       map[string]string
+	clusterName  *string
+}
+
+// NewCreateNamedCollection returns a builder for a named collection holding values, keyed by their name.
+func NewCreateNamedCollection(resourceName string, values map[string]string) CreateNamedCollectionQueryBuilder {
+	return &createNamedCollectionQueryBuilder{
+		resourceName: resourceName,
+		values:       values,
+	}
+}
+
+func (q *createNamedCollectionQueryBuilder) WithCluster(clusterName *string) CreateNamedCollectionQueryBuilder {
+	q.clusterName = clusterName
+	return q
+}
+
+func (q *createNamedCollectionQueryBuilder) Build() (string, error) {
+	if q.resourceName == "" {
+		return "", errors.New("resourceName cannot be empty for CREATE NAMED COLLECTION queries")
+	}
+	if len(q.values) == 0 {
+		return "", errors.New("values cannot be empty for CREATE NAMED COLLECTION queries")
+	}
+
+	tokens := []string{
+		"CREATE",
+		"NAMED",
+		"COLLECTION",
+		backtick(q.resourceName),
+	}
+	if q.clusterName != nil {
+		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
+	}
+	tokens = append(tokens, "AS", namedCollectionValuesSQLDef(q.values))
+
+	return strings.Join(tokens, " ") + ";", nil
+}
+
+// namedCollectionValuesSQLDef renders values as a list of key = 'value' pairs, sorted by key.
+func namedCollectionValuesSQLDef(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s = %s", backtick(key), quote(values[key])))
+	}
+
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,75 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_createnamedcollection(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceName string
+		values       map[string]string
+		clusterName  string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Create named collection",
+			resourceName: "s3_data",
+			values: map[string]string{
+				"url":               "https://bucket.s3.amazonaws.com/data/",
+				"access_key_id":     "AKIAEXAMPLE",
+				"secret_access_key": "secret",
+			},
+			want:    "CREATE NAMED COLLECTION `s3_data` AS `access_key_id` = 'AKIAEXAMPLE', `secret_access_key` = 'secret', `url` = 'https://bucket.s3.amazonaws.com/data/';",
+			wantErr: false,
+		},
+		{
+			name:         "Create named collection on cluster",
+			resourceName: "kafka",
+			values:       map[string]string{"kafka_topic_list": "events"},
+			clusterName:  "cluster1",
+			want:         "CREATE NAMED COLLECTION `kafka` ON CLUSTER 'cluster1' AS `kafka_topic_list` = 'events';",
+			wantErr:      false,
+		},
+		{
+			name:         "Create named collection with funky value",
+			resourceName: "funky",
+			values:       map[string]string{"password": `it's a\secret`},
+			want:         "CREATE NAMED COLLECTION `funky` AS `password` = 'it\\'s a\\\\secret';",
+			wantErr:      false,
+		},
+		{
+			name:         "Create named collection fails without values",
+			resourceName: "empty",
+			wantErr:      true,
+		},
+		{
+			name:    "Create named collection fails without name",
+			values:  map[string]string{"key": "value"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q CreateNamedCollectionQueryBuilder
+			q = &createNamedCollectionQueryBuilder{
+				resourceName: tt.resourceName,
+				values:       tt.values,
+			}
+
+			if tt.clusterName != "" {
+				q = q.WithCluster(&tt.clusterName)
+			}
+
+			got, err := q.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
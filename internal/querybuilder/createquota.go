@@ -0,0 +1,85 @@
+package querybuilder
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// CreateQuotaQueryBuilder is an interface to build CREATE QUOTA SQL queries (already interpolated).
+type CreateQuotaQueryBuilder interface {
+	QueryBuilder
+	WithCluster(clusterName *string) CreateQuotaQueryBuilder
+	KeyedBy(keys []string) CreateQuotaQueryBuilder
+	ForIntervals(intervals []QuotaInterval) CreateQuotaQueryBuilder
+	To(rolesOrUsers []string) CreateQuotaQueryBuilder
+}
+
+type createQuotaQueryBuilder struct {
+	resourceName string
+	clusterName  *string
+	keys         []string
+	intervals    []QuotaInterval
+	to           []string
+}
+
+func NewCreateQuota(resourceName string) CreateQuotaQueryBuilder {
+	return &createQuotaQueryBuilder{
+		resourceName: resourceName,
+	}
+}
+
+func (q *createQuotaQueryBuilder) WithCluster(clusterName *string) CreateQuotaQueryBuilder {
+	q.clusterName = clusterName
+	return q
+}
+
+// KeyedBy sets the keys the quota is tracked by, like user_name or client_key, user_name. No keys means NOT KEYED.
+func (q *createQuotaQueryBuilder) KeyedBy(keys []string) CreateQuotaQueryBuilder {
+	q.keys = keys
+	return q
+}
+
+func (q *createQuotaQueryBuilder) ForIntervals(intervals []QuotaInterval) CreateQuotaQueryBuilder {
+	q.intervals = intervals
+	return q
+}
+
+func (q *createQuotaQueryBuilder) To(rolesOrUsers []string) CreateQuotaQueryBuilder {
+	q.to = rolesOrUsers
+	return q
+}
+
+func (q *createQuotaQueryBuilder) Build() (string, error) {
+	if q.resourceName == "" {
+		return "", errors.New("resourceName cannot be empty for CREATE QUOTA queries")
+	}
+
+	tokens := []string{
+		"CREATE",
+		"QUOTA",
+		backtick(q.resourceName),
+	}
+	if q.clusterName != nil {
+		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
+	}
+	tokens = append(tokens, keyedBySQLDef(q.keys))
+
+	if len(q.intervals) > 0 {
+		intervals := make([]string, 0, len(q.intervals))
+		for _, interval := range q.intervals {
+			def, err := interval.SQLDef()
+			if err != nil {
+				return "", errors.WithMessage(err, "invalid quota interval")
+			}
+			intervals = append(intervals, def)
+		}
+		tokens = append(tokens, strings.Join(intervals, ", "))
+	}
+
+	if len(q.to) > 0 {
+		tokens = append(tokens, "TO", strings.Join(backtickAll(q.to), ", "))
+	}
+
+	return strings.Join(tokens, " ") + ";", nil
+}
@@ -0,0 +1,91 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_createquota(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceName string
+		clusterName  string
+		keys         []string
+		intervals    []QuotaInterval
+		to           []string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Create quota without intervals",
+			resourceName: "limited",
+			want:         "CREATE QUOTA `limited` NOT KEYED;",
+			wantErr:      false,
+		},
+		{
+			name:         "Create keyed quota with limits",
+			resourceName: "limited",
+			keys:         []string{"client_key", "user_name"},
+			intervals: []QuotaInterval{
+				{
+					DurationSeconds: 3600,
+					Limits: []QuotaLimit{
+						{Resource: "queries", Max: "100"},
+						{Resource: "execution_time", Max: "0.5"},
+					},
+				},
+				{
+					DurationSeconds: 86400,
+					Randomized:      true,
+				},
+			},
+			to:      []string{"reader", "wr`iter"},
+			want:    "CREATE QUOTA `limited` KEYED BY client_key, user_name FOR INTERVAL 3600 second MAX queries = 100, execution_time = 0.5, FOR RANDOMIZED INTERVAL 86400 second TRACKING ONLY TO `reader`, `wr\\`iter`;",
+			wantErr: false,
+		},
+		{
+			name:         "Create quota on cluster",
+			resourceName: "limited",
+			clusterName:  "cluster1",
+			keys:         []string{"user_name"},
+			want:         "CREATE QUOTA `limited` ON CLUSTER 'cluster1' KEYED BY user_name;",
+			wantErr:      false,
+		},
+		{
+			name:         "Create quota fails with an empty interval",
+			resourceName: "limited",
+			intervals:    []QuotaInterval{{}},
+			wantErr:      true,
+		},
+		{
+			name:         "Create quota fails with an invalid limit",
+			resourceName: "limited",
+			intervals:    []QuotaInterval{{DurationSeconds: 60, Limits: []QuotaLimit{{Resource: "queries"}}}},
+			wantErr:      true,
+		},
+		{
+			name:    "Create quota fails without a name",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q CreateQuotaQueryBuilder
+			q = &createQuotaQueryBuilder{
+				resourceName: tt.resourceName,
+			}
+
+			if tt.clusterName != "" {
+				q = q.WithCluster(&tt.clusterName)
+			}
+
+			got, err := q.KeyedBy(tt.keys).ForIntervals(tt.intervals).To(tt.to).Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
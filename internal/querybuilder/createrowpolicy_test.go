@@ -0,0 +1,94 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_createrowpolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceName string
+		databaseName string
+		tableName    string
+		clusterName  string
+		condition    string
+		restrictive  bool
+		to           []string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Create permissive row policy",
+			resourceName: "tenant",
+			databaseName: "db",
+			tableName:    "events",
+			condition:    "tenant_id = 1",
+			to:           []string{"reader"},
+			want:         "CREATE ROW POLICY `tenant` ON `db`.`events` FOR SELECT USING tenant_id = 1 AS PERMISSIVE TO `reader`;",
+			wantErr:      false,
+		},
+		{
+			name:         "Create restrictive row policy for many roles",
+			resourceName: "tenant",
+			databaseName: "db",
+			tableName:    "events",
+			condition:    "tenant_id = 1",
+			restrictive:  true,
+			to:           []string{"reader", "wr`iter"},
+			want:         "CREATE ROW POLICY `tenant` ON `db`.`events` FOR SELECT USING tenant_id = 1 AS RESTRICTIVE TO `reader`, `wr\\`iter`;",
+			wantErr:      false,
+		},
+		{
+			name:         "Create row policy on cluster",
+			resourceName: "tenant",
+			databaseName: "db",
+			tableName:    "events",
+			clusterName:  "cluster1",
+			condition:    "1",
+			want:         "CREATE ROW POLICY `tenant` ON CLUSTER 'cluster1' ON `db`.`events` FOR SELECT USING 1 AS PERMISSIVE;",
+			wantErr:      false,
+		},
+		{
+			name:         "Create row policy fails without a condition",
+			resourceName: "tenant",
+			databaseName: "db",
+			tableName:    "events",
+			wantErr:      true,
+		},
+		{
+			name:         "Create row policy fails without a table",
+			resourceName: "tenant",
+			databaseName: "db",
+			condition:    "1",
+			wantErr:      true,
+		},
+		{
+			name:      "Create row policy fails without a name",
+			condition: "1",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q CreateRowPolicyQueryBuilder
+			q = &createRowPolicyQueryBuilder{
+				resourceName: tt.resourceName,
+				databaseName: tt.databaseName,
+				tableName:    tt.tableName,
+			}
+
+			if tt.clusterName != "" {
+				q = q.WithCluster(&tt.clusterName)
+			}
+
+			got, err := q.Using(tt.condition).Restrictive(tt.restrictive).To(tt.to).Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package querybuilder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// CreateTableQueryBuilder is an interface to build CREATE TABLE SQL queries (already interpolated).
+// The engine and the expressions of the table are SQL added as is to the query.
+type CreateTableQueryBuilder interface {
+	QueryBuilder
+	WithCluster(clusterName *string) CreateTableQueryBuilder
+	PartitionBy(expression *string) CreateTableQueryBuilder
+	PrimaryKey(expression *string) CreateTableQueryBuilder
+	OrderBy(expression *string) CreateTableQueryBuilder
+	TTL(expression *string) CreateTableQueryBuilder
+	Settings(settings map[string]string) CreateTableQueryBuilder
+	WithComment(comment *string) CreateTableQueryBuilder
+}
+
+type createTableQueryBuilder struct {
+	databaseName string
+	tableName    string
+	columns      []TableColumn
+	engine       string
+	clusterName  *string
+	partitionBy  *string
+	primaryKey   *string
+	orderBy      *string
+	ttl          *string
+	settings     map[string]string
+	comment      *string
+}
+
+func NewCreateTable(databaseName string, tableName string, columns []TableColumn, engine string) CreateTableQueryBuilder {
+	return &createTableQueryBuilder{
+		databaseName: databaseName,
+		tableName:    tableName,
+		columns:      columns,
+		engine:       engine,
+	}
+}
+
+func (q *createTableQueryBuilder) WithCluster(clusterName *string) CreateTableQueryBuilder {
+	q.clusterName = clusterName
+	return q
+}
+
+func (q *createTableQueryBuilder) PartitionBy(expression *string) CreateTableQueryBuilder {
+	q.partitionBy = expression
+	return q
+}
+
+func (q *createTableQueryBuilder) PrimaryKey(expression *string) CreateTableQueryBuilder {
+	q.primaryKey = expression
+	return q
+}
+
+func (q *createTableQueryBuilder) OrderBy(expression *string) CreateTableQueryBuilder {
+	q.orderBy = expression
+	return q
+}
+
+func (q *createTableQueryBuilder) TTL(expression *string) CreateTableQueryBuilder {
+	q.ttl = expression
+	return q
+}
+
+func (q *createTableQueryBuilder) Settings(settings map[string]string) CreateTableQueryBuilder {
+	q.settings = settings
+	return q
+}
+
+func (q *createTableQueryBuilder) WithComment(comment *string) CreateTableQueryBuilder {
+	q.comment = comment
+	return q
+}
+
+func (q *createTableQueryBuilder) Build() (string, error) {
+	if q.databaseName == "" || q.tableName == "" {
+		return "", errors.New("databaseName and tableName cannot be empty for CREATE TABLE queries")
+	}
+	if len(q.columns) == 0 {
+		return "", errors.New("columns cannot be empty for CREATE TABLE queries")
+	}
+	if q.engine == "" {
+		return "", errors.New("engine cannot be empty for CREATE TABLE queries")
+	}
+
+	columns := make([]string, 0, len(q.columns))
+	for _, c := range q.columns {
+		def, err := c.SQLDef()
+		if err != nil {
+			return "", errors.WithMessage(err, "error building column definition")
+		}
+		columns = append(columns, def)
+	}
+
+	tokens := []string{
+		"CREATE",
+		"TABLE",
+		backtick(q.databaseName) + "." + backtick(q.tableName),
+	}
+	if q.clusterName != nil {
+		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
+	}
+	tokens = append(tokens, "("+strings.Join(columns, ", ")+")", "ENGINE", "=", q.engine)
+	if q.partitionBy != nil {
+		tokens = append(tokens, "PARTITION", "BY", *q.partitionBy)
+	}
+	if q.primaryKey != nil {
+		tokens = append(tokens, "PRIMARY", "KEY", *q.primaryKey)
+	}
+	if q.orderBy != nil {
+		tokens = append(tokens, "ORDER", "BY", *q.orderBy)
+	}
+	if q.ttl != nil {
+		tokens = append(tokens, "TTL", *q.ttl)
+	}
+	if len(q.settings) > 0 {
+		tokens = append(tokens, "SETTINGS", tableSettingsSQLDef(q.settings))
+	}
+	if q.comment != nil {
+		tokens = append(tokens, "COMMENT", quote(*q.comment))
+	}
+
+	return strings.Join(tokens, " ") + ";", nil
+}
+
+// tableSettingsSQLDef renders settings as a list of name = 'value' pairs, sorted by name.
+func tableSettingsSQLDef(settings map[string]string) string {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s = %s", backtick(name), quote(settings[name])))
+	}
+
+	return strings.Join(pairs, ", ")
+}
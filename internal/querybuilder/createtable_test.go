@@ -0,0 +1,71 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_createtable(t *testing.T) {
+	columns := []TableColumn{
+		{Name: "id", Type: "UInt64"},
+		{Name: "created_at", Type: "DateTime", Default: strptr("now()"), Codec: strptr("Delta, ZSTD")},
+	}
+
+	tests := []struct {
+		name    string
+		builder CreateTableQueryBuilder
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Simple table",
+			builder: NewCreateTable("db", "events", columns[:1], "Memory"),
+			want:    "CREATE TABLE `db`.`events` (`id` UInt64) ENGINE = Memory;",
+			wantErr: false,
+		},
+		{
+			name: "MergeTree table with all clauses on cluster",
+			builder: NewCreateTable("db", "events", columns, "ReplicatedMergeTree('/clickhouse/tables/{shard}/db/events', '{replica}')").
+				WithCluster(strptr("cluster1")).
+				PartitionBy(strptr("toYYYYMM(created_at)")).
+				PrimaryKey(strptr("id")).
+				OrderBy(strptr("(id, created_at)")).
+				TTL(strptr("created_at + INTERVAL 1 MONTH")).
+				Settings(map[string]string{"merge_with_ttl_timeout": "3600", "index_granularity": "8192"}).
+				WithComment(strptr("Events")),
+			want:    "CREATE TABLE `db`.`events` ON CLUSTER 'cluster1' (`id` UInt64, `created_at` DateTime DEFAULT now() CODEC(Delta, ZSTD)) ENGINE = ReplicatedMergeTree('/clickhouse/tables/{shard}/db/events', '{replica}') PARTITION BY toYYYYMM(created_at) PRIMARY KEY id ORDER BY (id, created_at) TTL created_at + INTERVAL 1 MONTH SETTINGS `index_granularity` = '8192', `merge_with_ttl_timeout` = '3600' COMMENT 'Events';",
+			wantErr: false,
+		},
+		{
+			name:    "Fails without columns",
+			builder: NewCreateTable("db", "events", nil, "Memory"),
+			wantErr: true,
+		},
+		{
+			name:    "Fails with invalid column",
+			builder: NewCreateTable("db", "events", []TableColumn{{Name: "id"}}, "Memory"),
+			wantErr: true,
+		},
+		{
+			name:    "Fails without engine",
+			builder: NewCreateTable("db", "events", columns, ""),
+			wantErr: true,
+		},
+		{
+			name:    "Fails without database",
+			builder: NewCreateTable("", "events", columns, "Memory"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 type CreateUserQueryBuilder interface {
 	QueryBuilder
 	Identified(with Identification, by string) CreateUserQueryBuilder
+	ValidUntil(validUntil *string) CreateUserQueryBuilder
 	WithSettingsProfile(profileName *string) CreateUserQueryBuilder
 	WithCluster(clusterName *string) CreateUserQueryBuilder
 }
@@ -24,6 +25,7 @@ const (
 type createUserQueryBuilder struct {
 	resourceName    string
 	identified      string
+	validUntil      *string
 	settingsProfile *string
 	clusterName     *string
 }
@@ -39,6 +41,11 @@ func (q *createUserQueryBuilder) Identified(with Identification, by string) Crea
 	return q
 }
 
+func (q *createUserQueryBuilder) ValidUntil(validUntil *string) CreateUserQueryBuilder {
+	q.validUntil = validUntil
+	return q
+}
+
 func (q *createUserQueryBuilder) WithSettingsProfile(profileName *string) CreateUserQueryBuilder {
 	q.settingsProfile = profileName
 	return q
@@ -65,6 +72,9 @@ func (q *createUserQueryBuilder) Build() (string, error) {
 	if q.identified != "" {
 		tokens = append(tokens, q.identified)
 	}
+	if q.validUntil != nil {
+		tokens = append(tokens, "VALID", "UNTIL", quote(*q.validUntil))
+	}
 	if q.settingsProfile != nil {
 		tokens = append(tokens, "SETTINGS", "PROFILE", quote(*q.settingsProfile))
 	}
@@ -11,6 +11,7 @@ func Test_createuser(t *testing.T) {
 		identifiedWith  Identification
 		identifiedBy    string
 		settingsProfile string
+		validUntil      string
 		want            string
 		wantErr         bool
 	}{
@@ -47,6 +48,15 @@ func Test_createuser(t *testing.T) {
 			want:            "CREATE USER `foo` SETTINGS PROFILE 'test';",
 			wantErr:         false,
 		},
+		{
+			name:           "Create user with password and expiration",
+			resourceName:   "john",
+			identifiedWith: IdentificationSHA256Hash,
+			identifiedBy:   "blah",
+			validUntil:     "2030-01-01 00:00:00",
+			want:           "CREATE USER `john` IDENTIFIED WITH sha256_hash BY 'blah' VALID UNTIL '2030-01-01 00:00:00';",
+			wantErr:        false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -59,6 +69,10 @@ func Test_createuser(t *testing.T) {
 				q = q.Identified(tt.identifiedWith, tt.identifiedBy)
 			}
 
+			if tt.validUntil != "" {
+				q = q.ValidUntil(&tt.validUntil)
+			}
+
 			if tt.settingsProfile != "" {
 				q = q.WithSettingsProfile(&tt.settingsProfile)
 			}
@@ -11,6 +11,9 @@ const (
 	resourceTypeRole            = "ROLE"
 	resourceTypeUser            = "USER"
 	resourceTypeSettingsProfile = "SETTINGS PROFILE"
+	resourceTypeQuota           = "QUOTA"
+	resourceTypeFunction        = "FUNCTION"
+	resourceTypeNamedCollection = "NAMED COLLECTION"
 )
 
 type DropQueryBuilder interface {
@@ -40,6 +43,18 @@ func NewDropSettingsProfile(resourceName string) DropQueryBuilder {
 	return newDrop(resourceTypeSettingsProfile, resourceName)
 }
 
+func NewDropQuota(resourceName string) DropQueryBuilder {
+	return newDrop(resourceTypeQuota, resourceName)
+}
+
+func NewDropFunction(resourceName string) DropQueryBuilder {
+	return newDrop(resourceTypeFunction, resourceName)
+}
+
+func NewDropNamedCollection(resourceName string) DropQueryBuilder {
+	return newDrop(resourceTypeNamedCollection, resourceName)
+}
+
 func (q *dropQueryBuilder) WithCluster(clusterName *string) DropQueryBuilder {
 	q.clusterName = clusterName
 	return q
@@ -82,6 +82,29 @@ func Test_drop(t *testing.T) {
 			want:         "DROP USER `jo\\`hn`;",
 			wantErr:      false,
 		},
+		{
+			name:         "Drop quota on cluster",
+			resourceType: resourceTypeQuota,
+			resourceName: "limited",
+			clusterName:  &cluster,
+			want:         "DROP QUOTA `limited` ON CLUSTER 'cluster1';",
+			wantErr:      false,
+		},
+		{
+			name:         "Drop function",
+			resourceType: resourceTypeFunction,
+			resourceName: "linear_equation",
+			want:         "DROP FUNCTION `linear_equation`;",
+			wantErr:      false,
+		},
+		{
+			name:         "Drop named collection on cluster",
+			resourceType: resourceTypeNamedCollection,
+			resourceName: "s3_data",
+			clusterName:  &cluster,
+			want:         "DROP NAMED COLLECTION `s3_data` ON CLUSTER 'cluster1';",
+			wantErr:      false,
+		},
 		{
 			name:         "Fail to drop user with empty name",
 			resourceType: resourceTypeUser,
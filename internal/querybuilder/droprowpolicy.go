@@ -0,0 +1,52 @@
+package querybuilder
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+type dropRowPolicyQueryBuilder struct {
+	resourceName string
+	databaseName string
+	tableName    string
+	clusterName  *string
+}
+
+// NewDropRowPolicy returns a builder for DROP ROW POLICY queries. Unlike other entities, row policies are identified
+// by their name and the table they apply to.
+func NewDropRowPolicy(resourceName string, databaseName string, tableName string) DropQueryBuilder {
+	return &dropRowPolicyQueryBuilder{
+		resourceName: resourceName,
+		databaseName: databaseName,
+		tableName:    tableName,
+	}
+}
+
+func (q *dropRowPolicyQueryBuilder) WithCluster(clusterName *string) DropQueryBuilder {
+	q.clusterName = clusterName
+	return q
+}
+
+func (q *dropRowPolicyQueryBuilder) Build() (string, error) {
+	if q.resourceName == "" {
+		return "", errors.New("resourceName cannot be empty for DROP ROW POLICY queries")
+	}
+	if q.databaseName == "" || q.tableName == "" {
+		return "", errors.New("databaseName and tableName cannot be empty for DROP ROW POLICY queries")
+	}
+
+	tokens := []string{
+		"DROP",
+		"ROW",
+		"POLICY",
+		backtick(q.resourceName),
+		"ON",
+		backtick(q.databaseName) + "." + backtick(q.tableName),
+	}
+	if q.clusterName != nil {
+		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
+	}
+
+	return strings.Join(tokens, " ") + ";", nil
+}
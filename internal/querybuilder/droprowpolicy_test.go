@@ -0,0 +1,70 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_droprowpolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceName string
+		databaseName string
+		tableName    string
+		clusterName  string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Drop row policy",
+			resourceName: "tenant",
+			databaseName: "db",
+			tableName:    "events",
+			want:         "DROP ROW POLICY `tenant` ON `db`.`events`;",
+			wantErr:      false,
+		},
+		{
+			name:         "Drop row policy on cluster",
+			resourceName: "tenant",
+			databaseName: "db",
+			tableName:    "events",
+			clusterName:  "cluster1",
+			want:         "DROP ROW POLICY `tenant` ON `db`.`events` ON CLUSTER 'cluster1';",
+			wantErr:      false,
+		},
+		{
+			name:         "Drop row policy fails without a table",
+			resourceName: "tenant",
+			databaseName: "db",
+			wantErr:      true,
+		},
+		{
+			name:         "Drop row policy fails without a name",
+			databaseName: "db",
+			tableName:    "events",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q DropQueryBuilder
+			q = &dropRowPolicyQueryBuilder{
+				resourceName: tt.resourceName,
+				databaseName: tt.databaseName,
+				tableName:    tt.tableName,
+			}
+
+			if tt.clusterName != "" {
+				q = q.WithCluster(&tt.clusterName)
+			}
+
+			got, err := q.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
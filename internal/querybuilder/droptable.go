@@ -0,0 +1,45 @@
+package querybuilder
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+type dropTableQueryBuilder struct {
+	databaseName string
+	tableName    string
+	clusterName  *string
+}
+
+// NewDropTable returns a builder for DROP TABLE queries. Tables are dropped synchronously, so that a table with the
+// same replication path can be created again right away.
+func NewDropTable(databaseName string, tableName string) DropQueryBuilder {
+	return &dropTableQueryBuilder{
+		databaseName: databaseName,
+		tableName:    tableName,
+	}
+}
+
+func (q *dropTableQueryBuilder) WithCluster(clusterName *string) DropQueryBuilder {
+	q.clusterName = clusterName
+	return q
+}
+
+func (q *dropTableQueryBuilder) Build() (string, error) {
+	if q.databaseName == "" || q.tableName == "" {
+		return "", errors.New("databaseName and tableName cannot be empty for DROP TABLE queries")
+	}
+
+	tokens := []string{
+		"DROP",
+		"TABLE",
+		backtick(q.databaseName) + "." + backtick(q.tableName),
+	}
+	if q.clusterName != nil {
+		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
+	}
+	tokens = append(tokens, "SYNC")
+
+	return strings.Join(tokens, " ") + ";", nil
+}
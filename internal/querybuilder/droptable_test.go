@@ -0,0 +1,59 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_droptable(t *testing.T) {
+	tests := []struct {
+		name         string
+		databaseName string
+		tableName    string
+		clusterName  string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Drop table",
+			databaseName: "db",
+			tableName:    "events",
+			want:         "DROP TABLE `db`.`events` SYNC;",
+			wantErr:      false,
+		},
+		{
+			name:         "Drop table on cluster",
+			databaseName: "db",
+			tableName:    "events",
+			clusterName:  "cluster1",
+			want:         "DROP TABLE `db`.`events` ON CLUSTER 'cluster1' SYNC;",
+			wantErr:      false,
+		},
+		{
+			name:      "Drop table fails without a database",
+			tableName: "events",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q DropQueryBuilder
+			q = &dropTableQueryBuilder{
+				databaseName: tt.databaseName,
+				tableName:    tt.tableName,
+			}
+
+			if tt.clusterName != "" {
+				q = q.WithCluster(&tt.clusterName)
+			}
+
+			got, err := q.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package querybuilder
 
 import (
 	"fmt"
+	"strings"
 )
 
 type Field interface {
@@ -31,3 +32,118 @@ func (f *field) SQLDef() string {
 	}
 	return backtick(f.name)
 }
+
+// HostNameColumn is the name of the column returned by NewHostNameField.
+const HostNameColumn = "host_name"
+
+type hostNameField struct{}
+
+// NewHostNameField returns a field with the name of the server each row comes from, to tell replicas apart when
+// selecting from a cluster.
+func NewHostNameField() Field {
+	return &hostNameField{}
+}
+
+func (f *hostNameField) ToString() Field {
+	// hostName() is already a String.
+	return f
+}
+
+func (f *hostNameField) SQLDef() string {
+	return fmt.Sprintf("hostName() AS %s", backtick(HostNameColumn))
+}
+
+// DefinitionColumn is the name of the column returned by NewDefinitionField.
+const DefinitionColumn = "definition"
+
+type definitionField struct {
+	names []string
+}
+
+// NewDefinitionField returns a field with the values of the columns called names joined in a single String, to compare
+// the definition of an entity across replicas.
+func NewDefinitionField(names []string) Field {
+	return &definitionField{
+		names: names,
+	}
+}
+
+func (f *definitionField) ToString() Field {
+	// The values are already joined in a String.
+	return f
+}
+
+func (f *definitionField) SQLDef() string {
+	columns := make([]string, 0, len(f.names))
+	for _, name := range f.names {
+		columns = append(columns, backtick(name))
+	}
+	return fmt.Sprintf("toString(tuple(%s)) AS %s", strings.Join(columns, ", "), backtick(DefinitionColumn))
+}
+
+type mapKeysField struct {
+	name string
+}
+
+// NewMapKeysField returns a field with the keys of the Map column called name, as an Array(String) column with the
+// same name.
+func NewMapKeysField(name string) Field {
+	return &mapKeysField{
+		name: name,
+	}
+}
+
+func (f *mapKeysField) ToString() Field {
+	// Keys are read as an array.
+	return f
+}
+
+func (f *mapKeysField) SQLDef() string {
+	return fmt.Sprintf("mapKeys(%s) AS %s", backtick(f.name), backtick(f.name))
+}
+
+type mapValuesField struct {
+	name  string
+	alias string
+}
+
+// NewMapValuesField returns a field with the values of the Map column called name, in the same order as the keys
+// returned by NewMapKeysField, as an Array(String) column called alias.
+func NewMapValuesField(name string, alias string) Field {
+	return &mapValuesField{
+		name:  name,
+		alias: alias,
+	}
+}
+
+func (f *mapValuesField) ToString() Field {
+	// Values are read as an array.
+	return f
+}
+
+func (f *mapValuesField) SQLDef() string {
+	return fmt.Sprintf("mapValues(%s) AS %s", backtick(f.name), backtick(f.alias))
+}
+
+type utcDateTimeField struct {
+	name  string
+	alias string
+}
+
+// NewUTCDateTimeField returns a field with the DateTime column called name formatted as 'YYYY-MM-DD hh:mm:ss' in UTC,
+// as a String column called alias.
+func NewUTCDateTimeField(name string, alias string) Field {
+	return &utcDateTimeField{
+		name:  name,
+		alias: alias,
+	}
+}
+
+func (f *utcDateTimeField) ToString() Field {
+	// The date is already formatted as a String.
+	return f
+}
+
+func (f *utcDateTimeField) SQLDef() string {
+	return fmt.Sprintf("formatDateTime(%s, '%%Y-%%m-%%d %%H:%%i:%%S', 'UTC') AS %s", backtick(f.name), backtick(f.alias))
+}
@@ -48,3 +48,53 @@ func Test_field_SQLDef(t *testing.T) {
 		})
 	}
 }
+
+func Test_hostNameField_SQLDef(t *testing.T) {
+	want := "hostName() AS `host_name`"
+	if got := NewHostNameField().SQLDef(); got != want {
+		t.Errorf("SQLDef() = %v, want %v", got, want)
+	}
+	if got := NewHostNameField().ToString().SQLDef(); got != want {
+		t.Errorf("ToString().SQLDef() = %v, want %v", got, want)
+	}
+}
+
+func Test_definitionField_SQLDef(t *testing.T) {
+	want := "toString(tuple(`engine`, `comment`)) AS `definition`"
+	if got := NewDefinitionField([]string{"engine", "comment"}).SQLDef(); got != want {
+		t.Errorf("SQLDef() = %v, want %v", got, want)
+	}
+	if got := NewDefinitionField([]string{"engine", "comment"}).ToString().SQLDef(); got != want {
+		t.Errorf("ToString().SQLDef() = %v, want %v", got, want)
+	}
+}
+
+func Test_mapKeysField_SQLDef(t *testing.T) {
+	want := "mapKeys(`collection`) AS `collection`"
+	if got := NewMapKeysField("collection").SQLDef(); got != want {
+		t.Errorf("SQLDef() = %v, want %v", got, want)
+	}
+	if got := NewMapKeysField("collection").ToString().SQLDef(); got != want {
+		t.Errorf("ToString().SQLDef() = %v, want %v", got, want)
+	}
+}
+
+func Test_mapValuesField_SQLDef(t *testing.T) {
+	want := "mapValues(`collection`) AS `collection_values`"
+	if got := NewMapValuesField("collection", "collection_values").SQLDef(); got != want {
+		t.Errorf("SQLDef() = %v, want %v", got, want)
+	}
+	if got := NewMapValuesField("collection", "collection_values").ToString().SQLDef(); got != want {
+		t.Errorf("ToString().SQLDef() = %v, want %v", got, want)
+	}
+}
+
+func Test_utcDateTimeField_SQLDef(t *testing.T) {
+	want := "formatDateTime(`valid_until`, '%Y-%m-%d %H:%i:%S', 'UTC') AS `valid_until_utc`"
+	if got := NewUTCDateTimeField("valid_until", "valid_until_utc").SQLDef(); got != want {
+		t.Errorf("SQLDef() = %v, want %v", got, want)
+	}
+	if got := NewUTCDateTimeField("valid_until", "valid_until_utc").ToString().SQLDef(); got != want {
+		t.Errorf("ToString().SQLDef() = %v, want %v", got, want)
+	}
+}
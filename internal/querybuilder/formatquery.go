@@ -0,0 +1,32 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// FormatQueryColumn is the name of the column returned by the queries built with NewFormatQuery.
+const FormatQueryColumn = "query"
+
+type formatQueryQueryBuilder struct {
+	sql string
+}
+
+// NewFormatQuery returns a builder for a SELECT query formatting sql on a single line, the way ClickHouse formats the
+// definitions it stores, like the create_query column of system.functions.
+func NewFormatQuery(sql string) QueryBuilder {
+	return &formatQueryQueryBuilder{
+		sql: sql,
+	}
+}
+
+func (q *formatQueryQueryBuilder) Build() (string, error) {
+	sql := strings.TrimSuffix(strings.TrimSpace(q.sql), ";")
+	if sql == "" {
+		return "", errors.New("sql cannot be empty for formatQuerySingleLine queries")
+	}
+
+	return fmt.Sprintf("SELECT formatQuerySingleLine(%s) AS %s;", quote(sql), backtick(FormatQueryColumn)), nil
+}
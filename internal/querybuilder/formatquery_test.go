@@ -0,0 +1,44 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_formatquery(t *testing.T) {
+	tests := []struct {
+		name    string
+		sql     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Format create function",
+			sql:     "CREATE FUNCTION `linear` AS (`x`, `k`, `b`) -> k*x + b;",
+			want:    "SELECT formatQuerySingleLine('CREATE FUNCTION `linear` AS (`x`, `k`, `b`) -> k*x + b') AS `query`;",
+			wantErr: false,
+		},
+		{
+			name:    "Quotes are escaped",
+			sql:     "CREATE FUNCTION `greet` AS (`x`) -> concat('hello ', x)",
+			want:    "SELECT formatQuerySingleLine('CREATE FUNCTION `greet` AS (`x`) -> concat(\\'hello \\', x)') AS `query`;",
+			wantErr: false,
+		},
+		{
+			name:    "Empty query",
+			sql:     " ;",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFormatQuery(tt.sql).Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ type GrantPrivilegeQueryBuilder interface {
 	WithDatabase(*string) GrantPrivilegeQueryBuilder
 	WithTable(*string) GrantPrivilegeQueryBuilder
 	WithColumn(*string) GrantPrivilegeQueryBuilder
+	OnNamedCollection(*string) GrantPrivilegeQueryBuilder
 	WithGrantOption(bool) GrantPrivilegeQueryBuilder
 	WithCluster(*string) GrantPrivilegeQueryBuilder
 }
@@ -25,6 +26,10 @@ type grantPrivilegeQueryBuilder struct {
 	column      *string
 	grantOption bool
 	clusterName *string
+
+	// namedCollectionScope is set when the privilege is on a named collection, rather than on a database or table.
+	namedCollectionScope bool
+	namedCollection      *string
 }
 
 func GrantPrivilege(accessType string, to string) GrantPrivilegeQueryBuilder {
@@ -49,6 +54,14 @@ func (q *grantPrivilegeQueryBuilder) WithColumn(column *string) GrantPrivilegeQu
 	return q
 }
 
+// OnNamedCollection targets the named collection called name, or all named collections when name is nil.
+// Database, table and column are ignored.
+func (q *grantPrivilegeQueryBuilder) OnNamedCollection(name *string) GrantPrivilegeQueryBuilder {
+	q.namedCollectionScope = true
+	q.namedCollection = name
+	return q
+}
+
 func (q *grantPrivilegeQueryBuilder) WithCluster(clusterName *string) GrantPrivilegeQueryBuilder {
 	q.clusterName = clusterName
 	return q
@@ -76,17 +89,23 @@ func (q *grantPrivilegeQueryBuilder) Build() (string, error) {
 	}
 
 	// Privilege
-	if q.column != nil && *q.column != "" {
+	if q.column != nil && *q.column != "" && !q.namedCollectionScope {
 		tokens = append(tokens, fmt.Sprintf("%s(%s)", q.accessType, backtick(*q.column)))
 	} else {
 		tokens = append(tokens, q.accessType)
 	}
 
-	// Target database/table
+	// Target database/table or named collection
 	{
 		tokens = append(tokens, "ON")
 
-		if q.database != nil {
+		if q.namedCollectionScope {
+			if q.namedCollection != nil {
+				tokens = append(tokens, backtick(*q.namedCollection))
+			} else {
+				tokens = append(tokens, "*")
+			}
+		} else if q.database != nil {
 			if q.table != nil {
 				tokens = append(tokens, fmt.Sprintf("%s.%s", backtick(*q.database), backtick(*q.table)))
 			} else {
@@ -29,6 +29,18 @@ func Test_grantPrivilegeQueryBuilder(t *testing.T) {
 			want:    "GRANT SELECT ON `db1`.`tbl1` TO `user1`;",
 			wantErr: false,
 		},
+		{
+			name:    "Named collection",
+			builder: GrantPrivilege("NAMED COLLECTION", "user1").OnNamedCollection(strptr("s3_data")),
+			want:    "GRANT NAMED COLLECTION ON `s3_data` TO `user1`;",
+			wantErr: false,
+		},
+		{
+			name:    "All named collections",
+			builder: GrantPrivilege("NAMED COLLECTION", "user1").OnNamedCollection(nil),
+			want:    "GRANT NAMED COLLECTION ON * TO `user1`;",
+			wantErr: false,
+		},
 		{
 			name:    "Select on single column",
 			builder: GrantPrivilege("SELECT", "user1").WithDatabase(strptr("db1")).WithTable(strptr("tbl1")).WithColumn(strptr("test")),
@@ -0,0 +1,72 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// QuotaLimit is the maximum value of a resource in a quota interval, like `queries = 100`.
+type QuotaLimit struct {
+	// Resource is the name of the limited resource, like queries, errors, result_rows, read_bytes or execution_time.
+	Resource string
+	// Max is the maximum value, as a number literal.
+	Max string
+}
+
+// QuotaInterval is a period of time and the limits applied to it in a quota.
+type QuotaInterval struct {
+	DurationSeconds uint64
+	Randomized      bool
+	// Limits is empty for intervals that only track resource consumption.
+	Limits []QuotaLimit
+}
+
+func (i QuotaInterval) SQLDef() (string, error) {
+	if i.DurationSeconds == 0 {
+		return "", errors.New("DurationSeconds cannot be 0 for quota intervals")
+	}
+
+	tokens := []string{"FOR"}
+	if i.Randomized {
+		tokens = append(tokens, "RANDOMIZED")
+	}
+	tokens = append(tokens, "INTERVAL", fmt.Sprintf("%d", i.DurationSeconds), "second")
+
+	if len(i.Limits) == 0 {
+		tokens = append(tokens, "TRACKING", "ONLY")
+		return strings.Join(tokens, " "), nil
+	}
+
+	limits := make([]string, 0, len(i.Limits))
+	for _, limit := range i.Limits {
+		if limit.Resource == "" || limit.Max == "" {
+			return "", errors.New("Resource and Max cannot be empty for quota limits")
+		}
+		limits = append(limits, fmt.Sprintf("%s = %s", limit.Resource, limit.Max))
+	}
+	tokens = append(tokens, "MAX", strings.Join(limits, ", "))
+
+	return strings.Join(tokens, " "), nil
+}
+
+// dropQuotaIntervalSQLDef returns the clause removing interval from a quota. ClickHouse tells intervals apart by their
+// duration and whether they are randomized, so both must match the interval being removed.
+func dropQuotaIntervalSQLDef(interval QuotaInterval) string {
+	tokens := []string{"FOR"}
+	if interval.Randomized {
+		tokens = append(tokens, "RANDOMIZED")
+	}
+	tokens = append(tokens, "INTERVAL", fmt.Sprintf("%d", interval.DurationSeconds), "second", "NO", "LIMITS")
+
+	return strings.Join(tokens, " ")
+}
+
+func keyedBySQLDef(keys []string) string {
+	if len(keys) == 0 {
+		return "NOT KEYED"
+	}
+
+	return "KEYED BY " + strings.Join(keys, ", ")
+}
@@ -0,0 +1,53 @@
+package querybuilder
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// RenameTableQueryBuilder is an interface to build RENAME TABLE SQL queries (already interpolated).
+type RenameTableQueryBuilder interface {
+	QueryBuilder
+	WithCluster(clusterName *string) RenameTableQueryBuilder
+}
+
+type renameTableQueryBuilder struct {
+	databaseName string
+	from         string
+	to           string
+	clusterName  *string
+}
+
+// NewRenameTable returns a builder for RENAME TABLE queries, renaming the table called from in databaseName to to.
+func NewRenameTable(databaseName string, from string, to string) RenameTableQueryBuilder {
+	return &renameTableQueryBuilder{
+		databaseName: databaseName,
+		from:         from,
+		to:           to,
+	}
+}
+
+func (q *renameTableQueryBuilder) WithCluster(clusterName *string) RenameTableQueryBuilder {
+	q.clusterName = clusterName
+	return q
+}
+
+func (q *renameTableQueryBuilder) Build() (string, error) {
+	if q.databaseName == "" || q.from == "" || q.to == "" {
+		return "", errors.New("databaseName, from and to cannot be empty for RENAME TABLE queries")
+	}
+
+	tokens := []string{
+		"RENAME",
+		"TABLE",
+		backtick(q.databaseName) + "." + backtick(q.from),
+		"TO",
+		backtick(q.databaseName) + "." + backtick(q.to),
+	}
+	if q.clusterName != nil {
+		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
+	}
+
+	return strings.Join(tokens, " ") + ";", nil
+}
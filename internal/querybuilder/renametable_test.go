@@ -0,0 +1,59 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func Test_renametable(t *testing.T) {
+	tests := []struct {
+		name         string
+		databaseName string
+		from         string
+		to           string
+		clusterName  string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Rename table",
+			databaseName: "db",
+			from:         "events",
+			to:           "events_v1",
+			want:         "RENAME TABLE `db`.`events` TO `db`.`events_v1`;",
+			wantErr:      false,
+		},
+		{
+			name:         "Rename table on cluster",
+			databaseName: "db",
+			from:         "events",
+			to:           "events_v1",
+			clusterName:  "cluster1",
+			want:         "RENAME TABLE `db`.`events` TO `db`.`events_v1` ON CLUSTER 'cluster1';",
+			wantErr:      false,
+		},
+		{
+			name:         "Rename table fails without a new name",
+			databaseName: "db",
+			from:         "events",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewRenameTable(tt.databaseName, tt.from, tt.to)
+
+			if tt.clusterName != "" {
+				q = q.WithCluster(&tt.clusterName)
+			}
+
+			got, err := q.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Build() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ type RevokePrivilegeQueryBuilder interface {
 	WithDatabase(*string) RevokePrivilegeQueryBuilder
 	WithTable(*string) RevokePrivilegeQueryBuilder
 	WithColumn(*string) RevokePrivilegeQueryBuilder
+	OnNamedCollection(*string) RevokePrivilegeQueryBuilder
+	GrantOptionOnly() RevokePrivilegeQueryBuilder
 	WithCluster(*string) RevokePrivilegeQueryBuilder
 }
 
@@ -23,6 +25,13 @@ type revokePrivilegeQueryBuilder struct {
 	table       *string
 	column      *string
 	clusterName *string
+
+	// namedCollectionScope is set when the privilege is on a named collection, rather than on a database or table.
+	namedCollectionScope bool
+	namedCollection      *string
+
+	// grantOptionOnly is set to revoke the grant option while leaving the privilege granted.
+	grantOptionOnly bool
 }
 
 func RevokePrivilege(accessType string, from string) RevokePrivilegeQueryBuilder {
@@ -47,6 +56,20 @@ func (q *revokePrivilegeQueryBuilder) WithColumn(column *string) RevokePrivilege
 	return q
 }
 
+// OnNamedCollection targets the named collection called name, or all named collections when name is nil.
+// Database, table and column are ignored.
+func (q *revokePrivilegeQueryBuilder) OnNamedCollection(name *string) RevokePrivilegeQueryBuilder {
+	q.namedCollectionScope = true
+	q.namedCollection = name
+	return q
+}
+
+// GrantOptionOnly makes the query revoke the grant option of the privilege, which stays granted.
+func (q *revokePrivilegeQueryBuilder) GrantOptionOnly() RevokePrivilegeQueryBuilder {
+	q.grantOptionOnly = true
+	return q
+}
+
 func (q *revokePrivilegeQueryBuilder) WithCluster(clusterName *string) RevokePrivilegeQueryBuilder {
 	q.clusterName = clusterName
 	return q
@@ -68,18 +91,28 @@ func (q *revokePrivilegeQueryBuilder) Build() (string, error) {
 		tokens = append(tokens, "ON", "CLUSTER", quote(*q.clusterName))
 	}
 
+	if q.grantOptionOnly {
+		tokens = append(tokens, "GRANT", "OPTION", "FOR")
+	}
+
 	// Privilege
-	if q.column != nil && *q.column != "" {
+	if q.column != nil && *q.column != "" && !q.namedCollectionScope {
 		tokens = append(tokens, fmt.Sprintf("%s(%s)", q.accessType, backtick(*q.column)))
 	} else {
 		tokens = append(tokens, q.accessType)
 	}
 
-	// Target database/table
+	// Target database/table or named collection
 	{
 		tokens = append(tokens, "ON")
 
-		if q.database != nil {
+		if q.namedCollectionScope {
+			if q.namedCollection != nil {
+				tokens = append(tokens, backtick(*q.namedCollection))
+			} else {
+				tokens = append(tokens, "*")
+			}
+		} else if q.database != nil {
 			if q.table != nil {
 				tokens = append(tokens, fmt.Sprintf("%s.%s", backtick(*q.database), backtick(*q.table)))
 			} else {
@@ -29,12 +29,36 @@ func Test_revokePrivilegeQueryBuilder(t *testing.T) {
 			want:    "REVOKE SELECT ON `db1`.`tbl1` FROM `user1`;",
 			wantErr: false,
 		},
+		{
+			name:    "Named collection",
+			builder: RevokePrivilege("NAMED COLLECTION", "user1").OnNamedCollection(strptr("s3_data")),
+			want:    "REVOKE NAMED COLLECTION ON `s3_data` FROM `user1`;",
+			wantErr: false,
+		},
+		{
+			name:    "All named collections",
+			builder: RevokePrivilege("NAMED COLLECTION", "user1").OnNamedCollection(nil),
+			want:    "REVOKE NAMED COLLECTION ON * FROM `user1`;",
+			wantErr: false,
+		},
 		{
 			name:    "Select on single column",
 			builder: RevokePrivilege("SELECT", "user1").WithDatabase(strptr("db1")).WithTable(strptr("tbl1")).WithColumn(strptr("test")),
 			want:    "REVOKE SELECT(`test`) ON `db1`.`tbl1` FROM `user1`;",
 			wantErr: false,
 		},
+		{
+			name:    "Grant option only",
+			builder: RevokePrivilege("SELECT", "user1").WithDatabase(strptr("db1")).GrantOptionOnly(),
+			want:    "REVOKE GRANT OPTION FOR SELECT ON `db1`.* FROM `user1`;",
+			wantErr: false,
+		},
+		{
+			name:    "Grant option only on cluster",
+			builder: RevokePrivilege("SELECT", "user1").WithCluster(strptr("cluster1")).GrantOptionOnly(),
+			want:    "REVOKE ON CLUSTER 'cluster1' GRANT OPTION FOR SELECT ON *.* FROM `user1`;",
+			wantErr: false,
+		},
 		{
 			name:    "Missing access type",
 			builder: RevokePrivilege("", "user1"),
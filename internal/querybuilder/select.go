@@ -19,6 +19,7 @@ type SelectQueryBuilder interface {
 	QueryBuilder
 	Where(...Where) SelectQueryBuilder
 	WithCluster(clusterName *string) SelectQueryBuilder
+	FromAllReplicas() SelectQueryBuilder
 	OrderBy(column Field, order OrderDirection) SelectQueryBuilder
 }
 
@@ -27,6 +28,7 @@ type selectQueryBuilder struct {
 	fields         []Field
 	where          Where
 	clusterName    *string
+	allReplicas    bool
 	orderBy        Field
 	orderDirection *OrderDirection
 }
@@ -48,6 +50,12 @@ func (q *selectQueryBuilder) WithCluster(clusterName *string) SelectQueryBuilder
 	return q
 }
 
+// FromAllReplicas makes queries run WithCluster read from every replica of the cluster, instead of one per shard.
+func (q *selectQueryBuilder) FromAllReplicas() SelectQueryBuilder {
+	q.allReplicas = true
+	return q
+}
+
 func (q *selectQueryBuilder) OrderBy(column Field, order OrderDirection) SelectQueryBuilder {
 	q.orderBy = column
 	q.orderDirection = &order
@@ -75,7 +83,9 @@ func (q *selectQueryBuilder) Build() (string, error) {
 		}
 		tableName := strings.Join(tokens, ".")
 
-		if q.clusterName != nil {
+		if q.clusterName != nil && q.allReplicas {
+			from = fmt.Sprintf("clusterAllReplicas(%s, %s)", quote(*q.clusterName), tableName)
+		} else if q.clusterName != nil {
 			from = fmt.Sprintf("cluster(%s, %s)", quote(*q.clusterName), tableName)
 		} else {
 			from = tableName
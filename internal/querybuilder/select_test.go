@@ -14,6 +14,7 @@ func Test_selectQueryBuilder_Build(t *testing.T) {
 		where    []Where
 		from     string
 		cluster  string
+		all      bool
 		orderCol *Field
 		orderDir *OrderDirection
 		want     string
@@ -34,6 +35,15 @@ func Test_selectQueryBuilder_Build(t *testing.T) {
 			want:    "SELECT `name` FROM cluster('cluster1', `users`);",
 			wantErr: false,
 		},
+		{
+			name:    "Select host name with cluster",
+			fields:  []Field{NewHostNameField(), NewField("name")},
+			from:    "system.users",
+			cluster: "cluster1",
+			all:     true,
+			want:    "SELECT hostName() AS `host_name`, `name` FROM clusterAllReplicas('cluster1', `system`.`users`);",
+			wantErr: false,
+		},
 		{
 			name:    "Select two fields",
 			fields:  []Field{NewField("name"), NewField("surname")},
@@ -84,6 +94,9 @@ func Test_selectQueryBuilder_Build(t *testing.T) {
 			if tt.cluster != "" {
 				q = q.WithCluster(&tt.cluster)
 			}
+			if tt.all {
+				q = q.FromAllReplicas()
+			}
 			if tt.orderCol != nil && tt.orderDir != nil {
 				q = q.OrderBy(*tt.orderCol, *tt.orderDir)
 			}
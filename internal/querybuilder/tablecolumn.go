@@ -0,0 +1,42 @@
+package querybuilder
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// TableColumn is the definition of a column in CREATE TABLE and ALTER TABLE queries.
+// Type, Default and Codec are SQL added as is to the query.
+type TableColumn struct {
+	Name    string
+	Type    string
+	Default *string
+	Codec   *string
+	Comment *string
+}
+
+func (c *TableColumn) SQLDef() (string, error) {
+	if c.Name == "" {
+		return "", errors.New("Name cannot be empty for table columns")
+	}
+	if c.Type == "" {
+		return "", errors.New("Type cannot be empty for table columns")
+	}
+
+	tokens := []string{
+		backtick(c.Name),
+		c.Type,
+	}
+	if c.Default != nil {
+		tokens = append(tokens, "DEFAULT", *c.Default)
+	}
+	if c.Codec != nil {
+		tokens = append(tokens, "CODEC("+*c.Codec+")")
+	}
+	if c.Comment != nil {
+		tokens = append(tokens, "COMMENT", quote(*c.Comment))
+	}
+
+	return strings.Join(tokens, " "), nil
+}
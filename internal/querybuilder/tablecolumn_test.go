@@ -0,0 +1,55 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func TestTableColumn_SQLDef(t *testing.T) {
+	tests := []struct {
+		name    string
+		column  TableColumn
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Name and type",
+			column:  TableColumn{Name: "id", Type: "UInt64"},
+			want:    "`id` UInt64",
+			wantErr: false,
+		},
+		{
+			name: "All properties",
+			column: TableColumn{
+				Name:    "created_at",
+				Type:    "DateTime64(3)",
+				Default: strptr("now64(3)"),
+				Codec:   strptr("Delta, ZSTD(3)"),
+				Comment: strptr("When it's created"),
+			},
+			want:    "`created_at` DateTime64(3) DEFAULT now64(3) CODEC(Delta, ZSTD(3)) COMMENT 'When it\\'s created'",
+			wantErr: false,
+		},
+		{
+			name:    "Fails without type",
+			column:  TableColumn{Name: "id"},
+			wantErr: true,
+		},
+		{
+			name:    "Fails without name",
+			column:  TableColumn{Type: "String"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.column.SQLDef()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SQLDef() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SQLDef() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package resourceutil
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// SetPlannedState stores plan as the new state, like after a dry run where nothing was created or updated and can
+// be read back. Values only known after the apply, like computed IDs, are stored as null.
+func SetPlannedState(plan tfsdk.Plan, state *tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	raw, err := tftypes.Transform(plan.Raw, func(_ *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if !value.IsKnown() {
+			return tftypes.NewValue(value.Type(), nil), nil
+		}
+
+		return value, nil
+	})
+	if err != nil {
+		diags.AddError("Error Storing Planned State", err.Error())
+		return diags
+	}
+
+	state.Raw = raw
+
+	return diags
+}
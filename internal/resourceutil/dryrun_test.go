@@ -0,0 +1,47 @@
+package resourceutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSetPlannedState(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":   schema.StringAttribute{Computed: true},
+			"name": schema.StringAttribute{Required: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String, "name": tftypes.String}}
+
+	plan := tfsdk.Plan{
+		Schema: s,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"name": tftypes.NewValue(tftypes.String, "admin"),
+		}),
+	}
+	state := tfsdk.State{Schema: s}
+
+	diags := SetPlannedState(plan, &state)
+	if diags.HasError() {
+		t.Fatalf("SetPlannedState() diags = %v", diags)
+	}
+
+	var id, name types.String
+	state.GetAttribute(ctx, path.Root("id"), &id)
+	state.GetAttribute(ctx, path.Root("name"), &name)
+	if !id.IsNull() {
+		t.Errorf("id = %v, want null", id)
+	}
+	if name.ValueString() != "admin" {
+		t.Errorf("name = %v, want admin", name)
+	}
+}
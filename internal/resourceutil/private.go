@@ -0,0 +1,45 @@
+package resourceutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// PrivateState is implemented by the private state of the framework requests and responses.
+type PrivateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// GetApplied returns the value stored under key by SetApplied after the last apply, like the definition of an entity
+// as formatted by ClickHouse, or nil when it is unknown, like after an import.
+func GetApplied[T any](ctx context.Context, private PrivateState, key string) (*T, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, key)
+	if diags.HasError() || raw == nil {
+		return nil, diags
+	}
+
+	var value T
+	err := json.Unmarshal(raw, &value)
+	if err != nil {
+		// Treat an unreadable value as unknown, the one read from ClickHouse will be used.
+		return nil, diags
+	}
+
+	return &value, diags
+}
+
+// SetApplied stores value under key, as JSON, to be read back with GetApplied.
+func SetApplied[T any](ctx context.Context, private PrivateState, key string, value T) diag.Diagnostics {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error Storing Private State", fmt.Sprintf("%+v\n", err))
+		return diags
+	}
+
+	return private.SetKey(ctx, key, raw)
+}
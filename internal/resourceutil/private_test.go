@@ -0,0 +1,59 @@
+package resourceutil
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+type fakePrivateState map[string][]byte
+
+func (f fakePrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return f[key], nil
+}
+
+func (f fakePrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	f[key] = value
+	return nil
+}
+
+func TestSetApplied_GetApplied(t *testing.T) {
+	type applied struct {
+		Name    string
+		Columns []string
+	}
+
+	ctx := context.Background()
+	private := fakePrivateState{}
+	want := applied{Name: "t", Columns: []string{"id", "ts"}}
+
+	diags := SetApplied(ctx, private, "applied", want)
+	if diags.HasError() {
+		t.Fatalf("SetApplied() diags = %v", diags)
+	}
+
+	got, diags := GetApplied[applied](ctx, private, "applied")
+	if diags.HasError() {
+		t.Fatalf("GetApplied() diags = %v", diags)
+	}
+	if got == nil || !reflect.DeepEqual(*got, want) {
+		t.Errorf("GetApplied() = %v, want %v", got, want)
+	}
+}
+
+func TestGetApplied_unknown(t *testing.T) {
+	ctx := context.Background()
+	private := fakePrivateState{"unreadable": []byte("{")}
+
+	for _, key := range []string{"missing", "unreadable"} {
+		got, diags := GetApplied[string](ctx, private, key)
+		if diags.HasError() {
+			t.Fatalf("GetApplied(%q) diags = %v", key, diags)
+		}
+		if got != nil {
+			t.Errorf("GetApplied(%q) = %v, want nil", key, *got)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package resourceutil
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/project"
+)
+
+// WithResourceQueryTags returns a context that makes the clients tag queries with the type name of r, as returned by
+// its Metadata method, and the Terraform operation being run.
+func WithResourceQueryTags(ctx context.Context, r resource.Resource, operation string) context.Context {
+	resp := &resource.MetadataResponse{}
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: project.TypeName()}, resp)
+
+	return clickhouseclient.WithQueryTags(ctx, resp.TypeName, operation)
+}
+
+// WithDataSourceQueryTags is the same as WithResourceQueryTags for the data source d.
+func WithDataSourceQueryTags(ctx context.Context, d datasource.DataSource, operation string) context.Context {
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: project.TypeName()}, resp)
+
+	return clickhouseclient.WithQueryTags(ctx, resp.TypeName, operation)
+}
@@ -0,0 +1,44 @@
+package resourceutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+)
+
+// ReportReplicaDrift handles the result of looking for the replicas of clusterName out of sync for an entity, described
+// like `User "john"`. When some are missing it, the resource is removed from the state, so that the next apply creates
+// it again on them. Replicas where it is defined differently are reported in a warning, as they can't be told apart
+// in the state. It returns true when Read must stop, because the resource was removed or err was reported.
+func ReportReplicaDrift(ctx context.Context, resp *resource.ReadResponse, errorSummary string, entity string, clusterName string, drift *dbops.ReplicaDrift, err error) bool {
+	if err != nil {
+		resp.Diagnostics.AddError(
+			errorSummary,
+			fmt.Sprintf("%+v\n", err),
+		)
+		return true
+	}
+
+	if len(drift.Different) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Replica drift detected",
+			fmt.Sprintf("%s is defined differently on replicas %s of cluster %q than on the other replicas, it must be fixed by hand on them.", entity, strings.Join(drift.Different, ", "), clusterName),
+		)
+	}
+
+	if len(drift.Missing) == 0 {
+		return false
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Replica drift detected",
+		fmt.Sprintf("%s is missing on replicas %s of cluster %q, it will be created again on them.", entity, strings.Join(drift.Missing, ", "), clusterName),
+	)
+	resp.State.RemoveResource(ctx)
+
+	return true
+}
@@ -0,0 +1,36 @@
+package resourceutil
+
+// ClosingQuote returns the index following the end of the literal quoted by s[start].
+func ClosingQuote(s string, start int) int {
+	quote := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+
+	return len(s)
+}
+
+// ClosingParenthesis returns the index of the parenthesis closing the one at the beginning of s, or -1.
+func ClosingParenthesis(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = ClosingQuote(s, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,87 @@
+package resourceutil
+
+import (
+	"testing"
+)
+
+func TestClosingParenthesis(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{
+			name: "Whole expression",
+			s:    "(a + 1)",
+			want: 6,
+		},
+		{
+			name: "Nested parentheses",
+			s:    "(f(a), g(b)) * 2",
+			want: 11,
+		},
+		{
+			name: "Parentheses in literals and quoted identifiers",
+			s:    "(')', `)`, \")\")",
+			want: 14,
+		},
+		{
+			name: "Escaped quote",
+			s:    "('\\')')",
+			want: 6,
+		},
+		{
+			name: "Unbalanced",
+			s:    "(a",
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClosingParenthesis(tt.s); got != tt.want {
+				t.Errorf("ClosingParenthesis() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosingQuote(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		start int
+		want  int
+	}{
+		{
+			name:  "Literal",
+			s:     "x = 'a' AND y",
+			start: 4,
+			want:  7,
+		},
+		{
+			name:  "Escaped quote",
+			s:     "'a\\'b'",
+			start: 0,
+			want:  6,
+		},
+		{
+			name:  "Other quotes inside",
+			s:     "`a'b\"c`",
+			start: 0,
+			want:  7,
+		},
+		{
+			name:  "Unterminated",
+			s:     "'abc",
+			start: 0,
+			want:  4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClosingQuote(tt.s, tt.start); got != tt.want {
+				t.Errorf("ClosingQuote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package dbopsclient
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
@@ -70,7 +71,7 @@ func NewDbopsClient(protocol string) (dbopsClient dbops.Client, connectionSettin
 		}
 	}
 
-	dbopsClient, err = dbops.NewClient(clickhouseClient)
+	dbopsClient, err = dbops.NewClient(context.Background(), clickhouseClient)
 	if err != nil {
 		return
 	}
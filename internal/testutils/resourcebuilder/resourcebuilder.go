@@ -20,21 +20,37 @@ import (
 // and return them as strings.
 // Used in acceptance tests to build test resource definitions.
 type ResourceBuilder struct {
+	blockType    string
 	resourceType string
 	resourceName string
 
 	dependencies []string
+	dependsOn    []hclwrite.Tokens
 
 	file *hclwrite.File
 }
 
 func New(resourceType string, resourceName string) *ResourceBuilder {
+	return newBlock("resource", resourceType, resourceName)
+}
+
+// NewDataSource returns a ResourceBuilder that builds a data source definition like:
+//
+//	data "data_source_type" "name" {
+//	  attribute = "value"
+//	}
+func NewDataSource(dataSourceType string, dataSourceName string) *ResourceBuilder {
+	return newBlock("data", dataSourceType, dataSourceName)
+}
+
+func newBlock(blockType string, resourceType string, resourceName string) *ResourceBuilder {
 	file := hclwrite.NewEmptyFile()
 
 	rootBody := file.Body()
-	rootBody.AppendNewBlock("resource", []string{resourceType, resourceName})
+	rootBody.AppendNewBlock(blockType, []string{resourceType, resourceName})
 
 	return &ResourceBuilder{
+		blockType:    blockType,
 		resourceType: resourceType,
 		resourceName: resourceName,
 
@@ -71,6 +87,17 @@ func (r *ResourceBuilder) WithResourceFieldReference(attrName string, resourceTy
 	return r
 }
 
+// WithDependsOn adds the given resource to the `depends_on` meta-argument.
+func (r *ResourceBuilder) WithDependsOn(resourceType string, resourceName string) *ResourceBuilder {
+	r.dependsOn = append(r.dependsOn, hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: resourceName},
+	}))
+	r.getRootResourceBody().SetAttributeRaw("depends_on", hclwrite.TokensForTuple(r.dependsOn))
+
+	return r
+}
+
 func (r *ResourceBuilder) WithFunction(attrName string, function string, arg string) *ResourceBuilder {
 	// function call
 	r.getRootResourceBody().SetAttributeRaw(attrName, hclwrite.Tokens{
@@ -89,6 +116,12 @@ func (r *ResourceBuilder) WithListAttribute(attrName string, data []cty.Value) *
 	return r
 }
 
+func (r *ResourceBuilder) WithMapAttribute(attrName string, data map[string]cty.Value) *ResourceBuilder {
+	r.getRootResourceBody().SetAttributeValue(attrName, cty.MapVal(data))
+
+	return r
+}
+
 func (r *ResourceBuilder) AddDependency(resource string) *ResourceBuilder {
 	r.dependencies = append(r.dependencies, resource)
 	return r
@@ -103,5 +136,5 @@ func (r *ResourceBuilder) Build() string {
 }
 
 func (r *ResourceBuilder) getRootResourceBody() *hclwrite.Body {
-	return r.file.Body().FirstMatchingBlock("resource", []string{r.resourceType, r.resourceName}).Body()
+	return r.file.Body().FirstMatchingBlock(r.blockType, []string{r.resourceType, r.resourceName}).Body()
 }
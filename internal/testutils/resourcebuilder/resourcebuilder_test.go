@@ -8,6 +8,7 @@ import (
 func TestResourcebuilder_Build(t *testing.T) {
 	tests := []struct {
 		name                   string
+		dataSource             bool
 		resourceType           string
 		resourceName           string
 		stringAttributes       map[string]string
@@ -20,6 +21,10 @@ func TestResourcebuilder_Build(t *testing.T) {
 			function string
 			arg      string
 		}
+		dependsOn []struct {
+			resourceType string
+			resourceName string
+		}
 		want string
 	}{
 		{
@@ -74,12 +79,48 @@ func TestResourcebuilder_Build(t *testing.T) {
 			},
 			want: `resource "test" "foo" {
   hash = sha256("test")
+}`,
+		},
+		{
+			name:         "Resource with depends_on",
+			resourceType: "test",
+			resourceName: "foo",
+			dependsOn: []struct {
+				resourceType string
+				resourceName string
+			}{
+				{
+					resourceType: "aws_vpc",
+					resourceName: "vpc1",
+				},
+				{
+					resourceType: "aws_subnet",
+					resourceName: "subnet1",
+				},
+			},
+			want: `resource "test" "foo" {
+  depends_on = [aws_vpc.vpc1, aws_subnet.subnet1]
+}`,
+		},
+		{
+			name:         "Data source with string attribute",
+			dataSource:   true,
+			resourceType: "test",
+			resourceName: "foo",
+			stringAttributes: map[string]string{
+				"name": "john",
+			},
+			want: `data "test" "foo" {
+  name = "john"
 }`,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := New(tt.resourceType, tt.resourceName)
+			if tt.dataSource {
+				r = NewDataSource(tt.resourceType, tt.resourceName)
+			}
 
 			for n, v := range tt.stringAttributes {
 				r.WithStringAttribute(n, v)
@@ -93,6 +134,10 @@ func TestResourcebuilder_Build(t *testing.T) {
 				r.WithFunction(n, v.function, v.arg)
 			}
 
+			for _, v := range tt.dependsOn {
+				r.WithDependsOn(v.resourceType, v.resourceName)
+			}
+
 			if got := strings.TrimRight(r.Build(), "\n"); got != tt.want {
 				t.Errorf("Build() = %q, want %q", got, tt.want)
 			}
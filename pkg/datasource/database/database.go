@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed database.md
+var databaseDataSourceDescription string
+
+var (
+	_ datasource.DataSource              = &DataSource{}
+	_ datasource.DataSourceWithConfigure = &DataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+type DataSource struct {
+	client dbops.Client
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_database"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to look up the database into. If omitted, the database will be looked up on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\n",
+			},
+			"uuid": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The system-assigned UUID of the database. Exactly one of `uuid` and `name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.Expressions{
+						path.MatchRoot("uuid"),
+						path.MatchRoot("name"),
+					}...),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the database. Exactly one of `uuid` and `name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Computed:    true,
+				Description: "Comment associated with the database",
+			},
+		},
+		MarkdownDescription: databaseDataSourceDescription,
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(dbops.Client)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = resourceutil.WithDataSourceQueryTags(ctx, d, clickhouseclient.OperationReadDataSource)
+
+	var config Database
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var db *dbops.Database
+	var err error
+	if !config.UUID.IsNull() {
+		db, err = d.client.GetDatabase(ctx, config.UUID.ValueString(), config.ClusterName.ValueStringPointer())
+	} else {
+		db, err = d.client.FindDatabaseByName(ctx, config.Name.ValueString(), config.ClusterName.ValueStringPointer())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse Database",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if db == nil {
+		resp.Diagnostics.AddError(
+			"ClickHouse Database not found",
+			"No database matching the given uuid or name was found",
+		)
+		return
+	}
+
+	state := Database{
+		ClusterName: config.ClusterName,
+		UUID:        types.StringValue(db.UUID),
+		Name:        types.StringValue(db.Name),
+		Comment:     types.StringValue(db.Comment),
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,133 @@
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	dataSourceType = "clickhousedbops_database"
+	dataSourceName = "foo"
+
+	databaseResourceName = "db1"
+)
+
+func TestDatabaseDataSource_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		uuid := attrs["uuid"]
+		if uuid == "" {
+			return false, fmt.Errorf("uuid attribute was not set")
+		}
+		database, err := dbopsClient.GetDatabase(ctx, uuid, clusterName)
+		return database != nil, err
+	}
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		uuid := attrs["uuid"]
+		if uuid == nil {
+			return fmt.Errorf("uuid was nil")
+		}
+
+		database, err := dbopsClient.GetDatabase(ctx, uuid.(string), clusterName)
+		if err != nil {
+			return err
+		}
+
+		if database == nil {
+			return fmt.Errorf("database with uuid %q was not found", uuid)
+		}
+
+		if attrs["name"].(string) != database.Name {
+			return fmt.Errorf("expected name to be %q, was %q", database.Name, attrs["name"].(string))
+		}
+
+		if attrs["comment"].(string) != database.Comment {
+			return fmt.Errorf("expected comment to be %q, was %q", database.Comment, attrs["comment"].(string))
+		}
+
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		return nil
+	}
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	databaseResource := resourcebuilder.New("clickhousedbops_database", databaseResourceName).
+		WithStringAttribute("name", name).
+		WithStringAttribute("comment", "test comment")
+	clusterDatabaseResource := resourcebuilder.New("clickhousedbops_database", databaseResourceName).
+		WithStringAttribute("name", name).
+		WithStringAttribute("cluster_name", clusterName)
+
+	tests := []runner.TestCase{
+		{
+			Name:     "Look up Database by name using Native protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "native",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("name", "clickhousedbops_database", databaseResourceName, "name").
+				AddDependency(databaseResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:     "Look up Database by uuid using HTTP protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "http",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("uuid", "clickhousedbops_database", databaseResourceName, "uuid").
+				AddDependency(databaseResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "Look up Database by name using Native protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "native",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("name", "clickhousedbops_database", databaseResourceName, "name").
+				WithStringAttribute("cluster_name", clusterName).
+				AddDependency(clusterDatabaseResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "Look up Database by uuid using HTTP protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "http",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("uuid", "clickhousedbops_database", databaseResourceName, "uuid").
+				WithStringAttribute("cluster_name", clusterName).
+				AddDependency(clusterDatabaseResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
@@ -0,0 +1,141 @@
+package databases
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed databases.md
+var databasesDataSourceDescription string
+
+var (
+	_ datasource.DataSource              = &DataSource{}
+	_ datasource.DataSourceWithConfigure = &DataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+type DataSource struct {
+	client dbops.Client
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databases"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to list databases from. If omitted, databases will be listed from the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\n",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "If set, only databases whose name matches this regular expression are returned. The regular expression syntax is the one accepted by Go's `regexp` package.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"databases": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of databases, sorted by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid": schema.StringAttribute{
+							Computed:    true,
+							Description: "The system-assigned UUID of the database",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the database",
+						},
+						"comment": schema.StringAttribute{
+							Computed:    true,
+							Description: "Comment associated with the database",
+						},
+					},
+				},
+			},
+		},
+		MarkdownDescription: databasesDataSourceDescription,
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(dbops.Client)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = resourceutil.WithDataSourceQueryTags(ctx, d, clickhouseclient.OperationReadDataSource)
+
+	var config Databases
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !config.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid regular expression",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+	}
+
+	databases, err := d.client.ListDatabases(ctx, config.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing ClickHouse Databases",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	state := Databases{
+		ClusterName: config.ClusterName,
+		NameRegex:   config.NameRegex,
+		Databases:   make([]Database, 0),
+	}
+
+	for _, db := range databases {
+		if nameRegex != nil && !nameRegex.MatchString(db.Name) {
+			continue
+		}
+
+		state.Databases = append(state.Databases, Database{
+			UUID:    types.StringValue(db.UUID),
+			Name:    types.StringValue(db.Name),
+			Comment: types.StringValue(db.Comment),
+		})
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,140 @@
+package databases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	dataSourceType = "clickhousedbops_databases"
+	dataSourceName = "foo"
+
+	databaseResourceName = "database1"
+)
+
+func TestDatabasesDataSource_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		uuid := attrs["databases.0.uuid"]
+		if uuid == "" {
+			return false, fmt.Errorf("databases.0.uuid attribute was not set")
+		}
+		database, err := dbopsClient.GetDatabase(ctx, uuid, clusterName)
+		return database != nil, err
+	}
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		databases, ok := attrs["databases"].([]interface{})
+		if !ok {
+			return fmt.Errorf("databases attribute was not a list")
+		}
+
+		if len(databases) != 1 {
+			return fmt.Errorf("expected exactly one database to match, got %d", len(databases))
+		}
+
+		db := databases[0].(map[string]interface{})
+
+		database, err := dbopsClient.GetDatabase(ctx, db["uuid"].(string), clusterName)
+		if err != nil {
+			return err
+		}
+
+		if database == nil {
+			return fmt.Errorf("database with uuid %q was not found", db["uuid"])
+		}
+
+		if db["name"].(string) != name {
+			return fmt.Errorf("expected name to be %q, was %q", name, db["name"].(string))
+		}
+
+		if db["comment"].(string) != database.Comment {
+			return fmt.Errorf("expected comment to be %q, was %q", database.Comment, db["comment"].(string))
+		}
+
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		return nil
+	}
+
+	databaseResource := resourcebuilder.New("clickhousedbops_database", databaseResourceName).
+		WithStringAttribute("name", name).
+		WithStringAttribute("comment", "test comment")
+	clusterDatabaseResource := resourcebuilder.New("clickhousedbops_database", databaseResourceName).
+		WithStringAttribute("name", name).
+		WithStringAttribute("cluster_name", clusterName)
+
+	tests := []runner.TestCase{
+		{
+			Name:     "List Databases using Native protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "native",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithStringAttribute("name_regex", fmt.Sprintf("^%s$", name)).
+				AddDependency(databaseResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:     "List Databases using HTTP protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "http",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithStringAttribute("name_regex", fmt.Sprintf("^%s$", name)).
+				AddDependency(databaseResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "List Databases using Native protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "native",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithStringAttribute("name_regex", fmt.Sprintf("^%s$", name)).
+				WithStringAttribute("cluster_name", clusterName).
+				AddDependency(clusterDatabaseResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "List Databases using HTTP protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "http",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithStringAttribute("name_regex", fmt.Sprintf("^%s$", name)).
+				WithStringAttribute("cluster_name", clusterName).
+				AddDependency(clusterDatabaseResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
@@ -0,0 +1,17 @@
+package databases
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Databases struct {
+	ClusterName types.String `tfsdk:"cluster_name"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+	Databases   []Database   `tfsdk:"databases"`
+}
+
+type Database struct {
+	UUID    types.String `tfsdk:"uuid"`
+	Name    types.String `tfsdk:"name"`
+	Comment types.String `tfsdk:"comment"`
+}
@@ -0,0 +1,295 @@
+package grants
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed grants.md
+var grantsDataSourceDescription string
+
+var (
+	_ datasource.DataSource              = &DataSource{}
+	_ datasource.DataSourceWithConfigure = &DataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+type DataSource struct {
+	client dbops.Client
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grants"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to read grants from. If omitted, grants will be read from the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\n",
+			},
+			"grantee_user_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the `user` to read grants of. Exactly one of `grantee_user_name` and `grantee_role_name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.Expressions{
+						path.MatchRoot("grantee_user_name"),
+						path.MatchRoot("grantee_role_name"),
+					}...),
+				},
+			},
+			"grantee_role_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the `role` to read grants of. Exactly one of `grantee_user_name` and `grantee_role_name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"include_inherited": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, also return the grants inherited through the roles granted to the grantee, recursively. Defaults to false.",
+			},
+			"privileges": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Privileges granted to the grantee. Parts of them can be revoked, see `partial_revokes`",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"privilege_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The granted privilege, such as `SELECT`",
+						},
+						"database_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The database the privilege is granted on. Null means all databases",
+						},
+						"table_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The table the privilege is granted on. Null means all tables",
+						},
+						"column_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The column the privilege is granted on. Null means all columns",
+						},
+						"grant_option": schema.BoolAttribute{
+							Computed:    true,
+							Description: "If true, the grantee is allowed to grant this privilege to other users",
+						},
+						"inherited_from": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the role the privilege was inherited from. Null for privileges granted directly to the grantee",
+						},
+					},
+				},
+			},
+			"partial_revokes": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Privileges revoked from the grantee within broader privileges listed in `privileges`, such as `SELECT` on the `secret` database revoked after `SELECT` was granted on all databases",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"privilege_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The revoked privilege, such as `SELECT`",
+						},
+						"database_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The database the privilege is revoked on. Null means all databases",
+						},
+						"table_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The table the privilege is revoked on. Null means all tables",
+						},
+						"column_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The column the privilege is revoked on. Null means all columns",
+						},
+						"grant_option": schema.BoolAttribute{
+							Computed:    true,
+							Description: "If true, only the permission to grant this privilege to other users was revoked",
+						},
+						"inherited_from": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the role the revoke was inherited from. Null for privileges revoked directly from the grantee",
+						},
+					},
+				},
+			},
+			"roles": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Roles granted to the grantee",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the granted role",
+						},
+						"admin_option": schema.BoolAttribute{
+							Computed:    true,
+							Description: "If true, the grantee is allowed to grant this role to other users",
+						},
+						"inherited_from": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the role this role was inherited from. Null for roles granted directly to the grantee",
+						},
+					},
+				},
+			},
+		},
+		MarkdownDescription: grantsDataSourceDescription,
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(dbops.Client)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = resourceutil.WithDataSourceQueryTags(ctx, d, clickhouseclient.OperationReadDataSource)
+
+	var config Grants
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := Grants{
+		ClusterName:      config.ClusterName,
+		GranteeUserName:  config.GranteeUserName,
+		GranteeRoleName:  config.GranteeRoleName,
+		IncludeInherited: config.IncludeInherited,
+		Privileges:       make([]Privilege, 0),
+		PartialRevokes:   make([]Privilege, 0),
+		Roles:            make([]Role, 0),
+	}
+
+	// When querying a cluster, the same grant is returned once per replica.
+	seenPrivileges := make(map[string]bool)
+	seenRoles := make(map[string]bool)
+
+	// Roles whose grants still need to be read. The grantee itself comes first, with a nil inheritedFrom.
+	type pending struct {
+		userName      *string
+		roleName      *string
+		inheritedFrom *string
+	}
+	queue := []pending{{
+		userName: config.GranteeUserName.ValueStringPointer(),
+		roleName: config.GranteeRoleName.ValueStringPointer(),
+	}}
+	visited := make(map[string]bool)
+	if config.GranteeRoleName.ValueStringPointer() != nil {
+		visited[config.GranteeRoleName.ValueString()] = true
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		privileges, err := d.client.GetAllGrantsForGrantee(ctx, current.userName, current.roleName, config.ClusterName.ValueStringPointer())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ClickHouse Grants",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+
+		for _, p := range privileges {
+			key := fmt.Sprintf("%s|%s|%s|%s|%t|%t|%s", p.AccessType, ptrString(p.DatabaseName), ptrString(p.TableName), ptrString(p.ColumnName), p.GrantOption, p.PartialRevoke, ptrString(current.inheritedFrom))
+			if seenPrivileges[key] {
+				continue
+			}
+			seenPrivileges[key] = true
+
+			// Partial revokes are listed apart, so that they are not mistaken for granted privileges.
+			list := &state.Privileges
+			if p.PartialRevoke {
+				list = &state.PartialRevokes
+			}
+
+			*list = append(*list, Privilege{
+				Privilege:     types.StringValue(p.AccessType),
+				Database:      types.StringPointerValue(p.DatabaseName),
+				Table:         types.StringPointerValue(p.TableName),
+				Column:        types.StringPointerValue(p.ColumnName),
+				GrantOption:   types.BoolValue(p.GrantOption),
+				InheritedFrom: types.StringPointerValue(current.inheritedFrom),
+			})
+		}
+
+		roles, err := d.client.GetAllGrantRolesForGrantee(ctx, current.userName, current.roleName, config.ClusterName.ValueStringPointer())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading ClickHouse Grants",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+
+		for _, r := range roles {
+			key := fmt.Sprintf("%s|%t|%s", r.RoleName, r.AdminOption, ptrString(current.inheritedFrom))
+			if !seenRoles[key] {
+				seenRoles[key] = true
+
+				state.Roles = append(state.Roles, Role{
+					RoleName:      types.StringValue(r.RoleName),
+					AdminOption:   types.BoolValue(r.AdminOption),
+					InheritedFrom: types.StringPointerValue(current.inheritedFrom),
+				})
+			}
+
+			if config.IncludeInherited.ValueBool() && !visited[r.RoleName] {
+				visited[r.RoleName] = true
+				queue = append(queue, pending{
+					roleName:      &r.RoleName,
+					inheritedFrom: &r.RoleName,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(state.Privileges, func(i, j int) bool {
+		return state.Privileges[i].Privilege.ValueString() < state.Privileges[j].Privilege.ValueString()
+	})
+
+	sort.SliceStable(state.PartialRevokes, func(i, j int) bool {
+		return state.PartialRevokes[i].Privilege.ValueString() < state.PartialRevokes[j].Privilege.ValueString()
+	})
+
+	sort.SliceStable(state.Roles, func(i, j int) bool {
+		return state.Roles[i].RoleName.ValueString() < state.Roles[j].RoleName.ValueString()
+	})
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func ptrString(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+
+	return *s
+}
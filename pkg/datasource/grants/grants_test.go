@@ -0,0 +1,171 @@
+package grants_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	dataSourceType = "clickhousedbops_grants"
+	dataSourceName = "foo"
+
+	roleResourceName           = "role1"
+	userResourceName           = "user1"
+	grantPrivilegeResourceName = "privilege1"
+	grantRoleResourceName      = "grant1"
+)
+
+func TestGrantsDataSource_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		userName := attrs["grantee_user_name"]
+		if userName == "" {
+			return false, fmt.Errorf("grantee_user_name attribute was not set")
+		}
+
+		grants, err := dbopsClient.GetAllGrantRolesForGrantee(ctx, &userName, nil, clusterName)
+		return len(grants) > 0, err
+	}
+
+	roleName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	userName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		roles, ok := attrs["roles"].([]interface{})
+		if !ok {
+			return fmt.Errorf("roles attribute was not a list")
+		}
+
+		if len(roles) != 1 {
+			return fmt.Errorf("expected exactly one granted role, got %d", len(roles))
+		}
+
+		role := roles[0].(map[string]interface{})
+		if role["role_name"].(string) != roleName {
+			return fmt.Errorf("expected role_name to be %q, was %q", roleName, role["role_name"])
+		}
+
+		if role["inherited_from"] != nil {
+			return fmt.Errorf("expected role to be granted directly, was inherited from %q", role["inherited_from"])
+		}
+
+		privileges, ok := attrs["privileges"].([]interface{})
+		if !ok {
+			return fmt.Errorf("privileges attribute was not a list")
+		}
+
+		if len(privileges) != 1 {
+			return fmt.Errorf("expected exactly one privilege, got %d", len(privileges))
+		}
+
+		privilege := privileges[0].(map[string]interface{})
+		if privilege["privilege_name"].(string) != "SELECT" {
+			return fmt.Errorf("expected privilege_name to be %q, was %q", "SELECT", privilege["privilege_name"])
+		}
+
+		if privilege["database_name"] != "system" {
+			return fmt.Errorf("expected database_name to be %q, was %q", "system", privilege["database_name"])
+		}
+
+		if privilege["inherited_from"] != roleName {
+			return fmt.Errorf("expected inherited_from to be %q, was %q", roleName, privilege["inherited_from"])
+		}
+
+		return nil
+	}
+
+	buildResources := func(clusterName *string) string {
+		role := resourcebuilder.New("clickhousedbops_role", roleResourceName).
+			WithStringAttribute("name", roleName)
+		user := resourcebuilder.New("clickhousedbops_user", userResourceName).
+			WithStringAttribute("name", userName).
+			WithFunction("password_sha256_hash_wo", "sha256", "test").
+			WithIntAttribute("password_sha256_hash_wo_version", 1)
+		grantPrivilege := resourcebuilder.New("clickhousedbops_grant_privilege", grantPrivilegeResourceName).
+			WithStringAttribute("privilege_name", "SELECT").
+			WithStringAttribute("database_name", "system").
+			WithResourceFieldReference("grantee_role_name", "clickhousedbops_role", roleResourceName, "name")
+		// Referencing the grant_privilege resource ensures the privilege exists before the data source is read.
+		grantRole := resourcebuilder.New("clickhousedbops_grant_role", grantRoleResourceName).
+			WithResourceFieldReference("role_name", "clickhousedbops_grant_privilege", grantPrivilegeResourceName, "grantee_role_name").
+			WithResourceFieldReference("grantee_user_name", "clickhousedbops_user", userResourceName, "name")
+		dataSource := resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+			WithResourceFieldReference("grantee_user_name", "clickhousedbops_grant_role", grantRoleResourceName, "grantee_user_name").
+			WithBoolAttribute("include_inherited", true)
+
+		if clusterName != nil {
+			role = role.WithStringAttribute("cluster_name", *clusterName)
+			user = user.WithStringAttribute("cluster_name", *clusterName)
+			grantPrivilege = grantPrivilege.WithStringAttribute("cluster_name", *clusterName)
+			grantRole = grantRole.WithStringAttribute("cluster_name", *clusterName)
+			dataSource = dataSource.WithStringAttribute("cluster_name", *clusterName)
+		}
+
+		return dataSource.
+			AddDependency(role.Build()).
+			AddDependency(user.Build()).
+			AddDependency(grantPrivilege.Build()).
+			AddDependency(grantRole.Build()).
+			Build()
+	}
+
+	tests := []runner.TestCase{
+		{
+			Name:                "Read inherited grants of a user using Native protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "native",
+			Resource:            buildResources(nil),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Read inherited grants of a user using HTTP protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "http",
+			Resource:            buildResources(nil),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Read inherited grants of a user using Native protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "native",
+			Resource:            buildResources(&clusterName),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Read inherited grants of a user using HTTP protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "http",
+			Resource:            buildResources(&clusterName),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
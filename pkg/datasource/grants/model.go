@@ -0,0 +1,30 @@
+package grants
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Grants struct {
+	ClusterName      types.String `tfsdk:"cluster_name"`
+	GranteeUserName  types.String `tfsdk:"grantee_user_name"`
+	GranteeRoleName  types.String `tfsdk:"grantee_role_name"`
+	IncludeInherited types.Bool   `tfsdk:"include_inherited"`
+	Privileges       []Privilege  `tfsdk:"privileges"`
+	PartialRevokes   []Privilege  `tfsdk:"partial_revokes"`
+	Roles            []Role       `tfsdk:"roles"`
+}
+
+type Privilege struct {
+	Privilege     types.String `tfsdk:"privilege_name"`
+	Database      types.String `tfsdk:"database_name"`
+	Table         types.String `tfsdk:"table_name"`
+	Column        types.String `tfsdk:"column_name"`
+	GrantOption   types.Bool   `tfsdk:"grant_option"`
+	InheritedFrom types.String `tfsdk:"inherited_from"`
+}
+
+type Role struct {
+	RoleName      types.String `tfsdk:"role_name"`
+	AdminOption   types.Bool   `tfsdk:"admin_option"`
+	InheritedFrom types.String `tfsdk:"inherited_from"`
+}
@@ -0,0 +1,12 @@
+package role
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Role struct {
+	ClusterName      types.String `tfsdk:"cluster_name"`
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	SettingsProfiles types.List   `tfsdk:"settings_profiles"`
+}
@@ -0,0 +1,132 @@
+package role
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed role.md
+var roleDataSourceDescription string
+
+var (
+	_ datasource.DataSource              = &DataSource{}
+	_ datasource.DataSourceWithConfigure = &DataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+type DataSource struct {
+	client dbops.Client
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to look up the role into. If omitted, the role will be looked up on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\n",
+			},
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The system-assigned ID of the role. Exactly one of `id` and `name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.Expressions{
+						path.MatchRoot("id"),
+						path.MatchRoot("name"),
+					}...),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the role. Exactly one of `id` and `name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"settings_profiles": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Names of the settings profiles associated with the role",
+			},
+		},
+		MarkdownDescription: roleDataSourceDescription,
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(dbops.Client)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = resourceutil.WithDataSourceQueryTags(ctx, d, clickhouseclient.OperationReadDataSource)
+
+	var config Role
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var role *dbops.Role
+	var err error
+	if !config.ID.IsNull() {
+		role, err = d.client.GetRole(ctx, config.ID.ValueString(), config.ClusterName.ValueStringPointer())
+	} else {
+		role, err = d.client.FindRoleByName(ctx, config.Name.ValueString(), config.ClusterName.ValueStringPointer())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse Role",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if role == nil {
+		resp.Diagnostics.AddError(
+			"ClickHouse Role not found",
+			"No role matching the given id or name was found",
+		)
+		return
+	}
+
+	profiles := make([]attr.Value, 0)
+	for _, p := range role.SettingsProfiles {
+		profiles = append(profiles, types.StringValue(p))
+	}
+
+	state := Role{
+		ClusterName:      config.ClusterName,
+		ID:               types.StringValue(role.ID),
+		Name:             types.StringValue(role.Name),
+		SettingsProfiles: types.ListValueMust(types.StringType, profiles),
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
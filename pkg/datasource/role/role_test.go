@@ -0,0 +1,128 @@
+package role_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	dataSourceType = "clickhousedbops_role"
+	dataSourceName = "foo"
+
+	roleResourceName = "role1"
+)
+
+func TestRoleDataSource_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		id := attrs["id"]
+		if id == "" {
+			return false, fmt.Errorf("id attribute was not set")
+		}
+		role, err := dbopsClient.GetRole(ctx, id, clusterName)
+		return role != nil, err
+	}
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		id := attrs["id"]
+		if id == nil {
+			return fmt.Errorf("id was nil")
+		}
+
+		role, err := dbopsClient.GetRole(ctx, id.(string), clusterName)
+		if err != nil {
+			return err
+		}
+
+		if role == nil {
+			return fmt.Errorf("role with id %q was not found", id)
+		}
+
+		if attrs["name"].(string) != role.Name {
+			return fmt.Errorf("expected name to be %q, was %q", role.Name, attrs["name"].(string))
+		}
+
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		return nil
+	}
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	roleResource := resourcebuilder.New("clickhousedbops_role", roleResourceName).
+		WithStringAttribute("name", name)
+	clusterRoleResource := resourcebuilder.New("clickhousedbops_role", roleResourceName).
+		WithStringAttribute("name", name).
+		WithStringAttribute("cluster_name", clusterName)
+
+	tests := []runner.TestCase{
+		{
+			Name:     "Look up Role by name using Native protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "native",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("name", "clickhousedbops_role", roleResourceName, "name").
+				AddDependency(roleResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:     "Look up Role by id using HTTP protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "http",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("id", "clickhousedbops_role", roleResourceName, "id").
+				AddDependency(roleResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "Look up Role by name using Native protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "native",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("name", "clickhousedbops_role", roleResourceName, "name").
+				WithStringAttribute("cluster_name", clusterName).
+				AddDependency(clusterRoleResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "Look up Role by id using HTTP protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "http",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("id", "clickhousedbops_role", roleResourceName, "id").
+				WithStringAttribute("cluster_name", clusterName).
+				AddDependency(clusterRoleResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
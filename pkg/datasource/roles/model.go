@@ -0,0 +1,16 @@
+package roles
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Roles struct {
+	ClusterName types.String `tfsdk:"cluster_name"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+	Roles       []Role       `tfsdk:"roles"`
+}
+
+type Role struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
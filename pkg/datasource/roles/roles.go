@@ -0,0 +1,136 @@
+package roles
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed roles.md
+var rolesDataSourceDescription string
+
+var (
+	_ datasource.DataSource              = &DataSource{}
+	_ datasource.DataSourceWithConfigure = &DataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+type DataSource struct {
+	client dbops.Client
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to list roles from. If omitted, roles will be listed from the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\n",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "If set, only roles whose name matches this regular expression are returned. The regular expression syntax is the one accepted by Go's `regexp` package.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"roles": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "List of roles, sorted by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The system-assigned ID for the role",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the role",
+						},
+					},
+				},
+			},
+		},
+		MarkdownDescription: rolesDataSourceDescription,
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(dbops.Client)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = resourceutil.WithDataSourceQueryTags(ctx, d, clickhouseclient.OperationReadDataSource)
+
+	var config Roles
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !config.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid regular expression",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+	}
+
+	roles, err := d.client.ListRoles(ctx, config.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing ClickHouse Roles",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	state := Roles{
+		ClusterName: config.ClusterName,
+		NameRegex:   config.NameRegex,
+		Roles:       make([]Role, 0),
+	}
+
+	for _, r := range roles {
+		if nameRegex != nil && !nameRegex.MatchString(r.Name) {
+			continue
+		}
+
+		state.Roles = append(state.Roles, Role{
+			ID:   types.StringValue(r.ID),
+			Name: types.StringValue(r.Name),
+		})
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
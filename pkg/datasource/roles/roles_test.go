@@ -0,0 +1,135 @@
+package roles_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	dataSourceType = "clickhousedbops_roles"
+	dataSourceName = "foo"
+
+	roleResourceName = "role1"
+)
+
+func TestRolesDataSource_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		id := attrs["roles.0.id"]
+		if id == "" {
+			return false, fmt.Errorf("roles.0.id attribute was not set")
+		}
+		role, err := dbopsClient.GetRole(ctx, id, clusterName)
+		return role != nil, err
+	}
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		roles, ok := attrs["roles"].([]interface{})
+		if !ok {
+			return fmt.Errorf("roles attribute was not a list")
+		}
+
+		if len(roles) != 1 {
+			return fmt.Errorf("expected exactly one role to match, got %d", len(roles))
+		}
+
+		r := roles[0].(map[string]interface{})
+
+		role, err := dbopsClient.GetRole(ctx, r["id"].(string), clusterName)
+		if err != nil {
+			return err
+		}
+
+		if role == nil {
+			return fmt.Errorf("role with id %q was not found", r["id"])
+		}
+
+		if r["name"].(string) != name {
+			return fmt.Errorf("expected name to be %q, was %q", name, r["name"].(string))
+		}
+
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		return nil
+	}
+
+	roleResource := resourcebuilder.New("clickhousedbops_role", roleResourceName).
+		WithStringAttribute("name", name)
+	clusterRoleResource := resourcebuilder.New("clickhousedbops_role", roleResourceName).
+		WithStringAttribute("name", name).
+		WithStringAttribute("cluster_name", clusterName)
+
+	tests := []runner.TestCase{
+		{
+			Name:     "List Roles using Native protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "native",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithStringAttribute("name_regex", fmt.Sprintf("^%s$", name)).
+				AddDependency(roleResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:     "List Roles using HTTP protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "http",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithStringAttribute("name_regex", fmt.Sprintf("^%s$", name)).
+				AddDependency(roleResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "List Roles using Native protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "native",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithStringAttribute("name_regex", fmt.Sprintf("^%s$", name)).
+				WithStringAttribute("cluster_name", clusterName).
+				AddDependency(clusterRoleResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "List Roles using HTTP protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "http",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithStringAttribute("name_regex", fmt.Sprintf("^%s$", name)).
+				WithStringAttribute("cluster_name", clusterName).
+				AddDependency(clusterRoleResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
@@ -0,0 +1,132 @@
+package settingsprofile
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed settingsprofile.md
+var settingsProfileDataSourceDescription string
+
+var (
+	_ datasource.DataSource              = &DataSource{}
+	_ datasource.DataSourceWithConfigure = &DataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+type DataSource struct {
+	client dbops.Client
+}
+
+func (d *DataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_settings_profile"
+}
+
+func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to look up the settings profile into. If omitted, the settings profile will be looked up on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\n",
+			},
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the settings profile. Exactly one of `id` and `name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.Expressions{
+						path.MatchRoot("id"),
+						path.MatchRoot("name"),
+					}...),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the settings profile. Exactly one of `id` and `name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"inherit_from": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "List of setting profile names this profile inherits from",
+			},
+		},
+		MarkdownDescription: settingsProfileDataSourceDescription,
+	}
+}
+
+func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(dbops.Client)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = resourceutil.WithDataSourceQueryTags(ctx, d, clickhouseclient.OperationReadDataSource)
+
+	var config SettingsProfile
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var profile *dbops.SettingsProfile
+	var err error
+	if !config.ID.IsNull() {
+		profile, err = d.client.GetSettingsProfile(ctx, config.ID.ValueString(), config.ClusterName.ValueStringPointer())
+	} else {
+		profile, err = d.client.FindSettingsProfileByName(ctx, config.Name.ValueString(), config.ClusterName.ValueStringPointer())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse SettingsProfile",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if profile == nil {
+		resp.Diagnostics.AddError(
+			"ClickHouse SettingsProfile not found",
+			"No settings profile matching the given id or name was found",
+		)
+		return
+	}
+
+	inheritFrom := make([]attr.Value, 0)
+	for _, i := range profile.InheritFrom {
+		inheritFrom = append(inheritFrom, types.StringValue(i))
+	}
+
+	state := SettingsProfile{
+		ClusterName: config.ClusterName,
+		ID:          types.StringValue(profile.ID),
+		Name:        types.StringValue(profile.Name),
+		InheritFrom: types.ListValueMust(types.StringType, inheritFrom),
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,128 @@
+package settingsprofile_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	dataSourceType = "clickhousedbops_settings_profile"
+	dataSourceName = "foo"
+
+	profileResourceName = "profile1"
+)
+
+func TestSettingsProfileDataSource_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		id := attrs["id"]
+		if id == "" {
+			return false, fmt.Errorf("id attribute was not set")
+		}
+		profile, err := dbopsClient.GetSettingsProfile(ctx, id, clusterName)
+		return profile != nil, err
+	}
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		id := attrs["id"]
+		if id == nil {
+			return fmt.Errorf("id was nil")
+		}
+
+		profile, err := dbopsClient.GetSettingsProfile(ctx, id.(string), clusterName)
+		if err != nil {
+			return err
+		}
+
+		if profile == nil {
+			return fmt.Errorf("settings profile with id %q was not found", id)
+		}
+
+		if attrs["name"].(string) != profile.Name {
+			return fmt.Errorf("expected name to be %q, was %q", profile.Name, attrs["name"].(string))
+		}
+
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		return nil
+	}
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	profileResource := resourcebuilder.New("clickhousedbops_settings_profile", profileResourceName).
+		WithStringAttribute("name", name)
+	clusterProfileResource := resourcebuilder.New("clickhousedbops_settings_profile", profileResourceName).
+		WithStringAttribute("name", name).
+		WithStringAttribute("cluster_name", clusterName)
+
+	tests := []runner.TestCase{
+		{
+			Name:     "Look up SettingsProfile by name using Native protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "native",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("name", "clickhousedbops_settings_profile", profileResourceName, "name").
+				AddDependency(profileResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:     "Look up SettingsProfile by id using HTTP protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "http",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("id", "clickhousedbops_settings_profile", profileResourceName, "id").
+				AddDependency(profileResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "Look up SettingsProfile by name using Native protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "native",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("name", "clickhousedbops_settings_profile", profileResourceName, "name").
+				WithStringAttribute("cluster_name", clusterName).
+				AddDependency(clusterProfileResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "Look up SettingsProfile by id using HTTP protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "http",
+			Resource: resourcebuilder.NewDataSource(dataSourceType, dataSourceName).
+				WithResourceFieldReference("id", "clickhousedbops_settings_profile", profileResourceName, "id").
+				WithStringAttribute("cluster_name", clusterName).
+				AddDependency(clusterProfileResource.Build()).
+				Build(),
+			ResourceName:        dataSourceName,
+			ResourceAddress:     fmt.Sprintf("data.%s.%s", dataSourceType, dataSourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
@@ -0,0 +1,16 @@
+package users
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Users struct {
+	ClusterName types.String `tfsdk:"cluster_name"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+	Users       []User       `tfsdk:"users"`
+}
+
+type User struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
@@ -16,3 +16,8 @@ func Commit() string {
 func FullName() string {
 	return "terraform-provider-clickhousedbops"
 }
+
+// TypeName is the name of the provider, prefixing the type name of its resources and data sources.
+func TypeName() string {
+	return "clickhousedbops"
+}
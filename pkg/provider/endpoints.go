@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pingcap/errors"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+)
+
+func availableHostSelectionStrategies() []string {
+	ret := make([]string, 0, len(clickhouseclient.AvailableHostSelectionStrategies))
+	for _, s := range clickhouseclient.AvailableHostSelectionStrategies {
+		ret = append(ret, string(s))
+	}
+
+	return ret
+}
+
+// parseEndpoints parses a list of endpoints in `host` or `host:port` format, using defaultPort for the ones without a port.
+func parseEndpoints(values []string, defaultPort types.Int32) ([]clickhouseclient.Endpoint, error) {
+	ret := make([]clickhouseclient.Endpoint, 0, len(values))
+	for _, value := range values {
+		host, portStr, err := net.SplitHostPort(value)
+		if err != nil {
+			// No port specified, the whole value is the host (IPv6 addresses might be wrapped in brackets).
+			host = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+			if defaultPort.IsNull() {
+				return nil, errors.New(fmt.Sprintf("endpoint %q has no port and the port attribute is not set", value))
+			}
+			portStr = strconv.Itoa(int(defaultPort.ValueInt32()))
+		}
+
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil || port == 0 {
+			return nil, errors.New(fmt.Sprintf("invalid port in endpoint %q", value))
+		}
+
+		if host == "" {
+			return nil, errors.New(fmt.Sprintf("invalid endpoint %q, host is empty", value))
+		}
+
+		ret = append(ret, clickhouseclient.Endpoint{Host: host, Port: uint16(port)})
+	}
+
+	return ret, nil
+}
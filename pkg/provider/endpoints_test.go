@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+)
+
+func TestParseEndpoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []string
+		defaultPort types.Int32
+		want        []clickhouseclient.Endpoint
+		wantErr     bool
+	}{
+		{
+			name:        "Hosts with and without port",
+			values:      []string{"ch-0.example.com", "ch-1.example.com:9440"},
+			defaultPort: types.Int32Value(9000),
+			want: []clickhouseclient.Endpoint{
+				{Host: "ch-0.example.com", Port: 9000},
+				{Host: "ch-1.example.com", Port: 9440},
+			},
+		},
+		{
+			name:        "IPv6 addresses",
+			values:      []string{"[::1]:9440", "[::2]", "::3"},
+			defaultPort: types.Int32Value(9000),
+			want: []clickhouseclient.Endpoint{
+				{Host: "::1", Port: 9440},
+				{Host: "::2", Port: 9000},
+				{Host: "::3", Port: 9000},
+			},
+		},
+		{
+			name:        "Missing port without default",
+			values:      []string{"ch-0.example.com"},
+			defaultPort: types.Int32Null(),
+			wantErr:     true,
+		},
+		{
+			name:        "Invalid port",
+			values:      []string{"ch-0.example.com:99999"},
+			defaultPort: types.Int32Null(),
+			wantErr:     true,
+		},
+		{
+			name:        "Empty host",
+			values:      []string{":9000"},
+			defaultPort: types.Int32Null(),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEndpoints(tt.values, tt.defaultPort)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseEndpoints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseEndpoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
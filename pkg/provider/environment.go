@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	envProtocol     = "CLICKHOUSE_PROTOCOL"
+	envHost         = "CLICKHOUSE_HOST"
+	envPort         = "CLICKHOUSE_PORT"
+	envAuthStrategy = "CLICKHOUSE_AUTH_STRATEGY"
+	envUser         = "CLICKHOUSE_USER"
+	envPassword     = "CLICKHOUSE_PASSWORD"
+	envToken        = "CLICKHOUSE_TOKEN"
+)
+
+// resolveEnvironment fills the attributes that are not set in the provider configuration using environment variables
+// and validates that all the required ones are set after that.
+// Values explicitly set in the configuration always take precedence over the environment.
+func resolveEnvironment(data *Model, getenv func(string) string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.Protocol.IsNull() {
+		if value := getenv(envProtocol); value != "" {
+			if !slices.Contains(availableProtocols, value) {
+				diags.AddAttributeError(path.Root("protocol"), "invalid configuration", fmt.Sprintf("invalid protocol %q in the %s environment variable. Valid options are: %s", value, envProtocol, strings.Join(availableProtocols, ", ")))
+			}
+			data.Protocol = types.StringValue(value)
+		} else {
+			diags.AddAttributeError(path.Root("protocol"), "missing configuration", fmt.Sprintf("protocol must be set in the provider configuration or using the %s environment variable", envProtocol))
+		}
+	}
+
+	if data.Host.IsNull() && data.Endpoints.IsNull() {
+		if value := getenv(envHost); value != "" {
+			data.Host = types.StringValue(value)
+		} else {
+			diags.AddAttributeError(path.Root("host"), "missing configuration", fmt.Sprintf("host must be set in the provider configuration or using the %s environment variable", envHost))
+		}
+	}
+
+	if data.Port.IsNull() {
+		if value := getenv(envPort); value != "" {
+			port, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				diags.AddAttributeError(path.Root("port"), "invalid configuration", fmt.Sprintf("invalid port %q in the %s environment variable", value, envPort))
+			} else {
+				data.Port = types.Int32Value(int32(port)) //nolint:gosec
+			}
+		} else if data.Endpoints.IsNull() {
+			// When using endpoints, port is only required for the ones that don't specify it.
+			diags.AddAttributeError(path.Root("port"), "missing configuration", fmt.Sprintf("port must be set in the provider configuration or using the %s environment variable", envPort))
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	if data.AuthConfig == nil {
+		data.AuthConfig = &AuthConfig{
+			Strategy:  types.StringNull(),
+			Username:  types.StringNull(),
+			Password:  types.StringNull(),
+			Token:     types.StringNull(),
+			TokenFile: types.StringNull(),
+		}
+	}
+
+	if data.AuthConfig.Strategy.IsNull() {
+		if value := getenv(envAuthStrategy); value != "" {
+			if !slices.Contains(availableAuthStrategies, value) {
+				diags.AddAttributeError(path.Root("auth_config").AtName("strategy"), "invalid configuration", fmt.Sprintf("invalid authentication strategy %q in the %s environment variable. Valid options are: %s", value, envAuthStrategy, strings.Join(availableAuthStrategies, ", ")))
+				return diags
+			}
+			data.AuthConfig.Strategy = types.StringValue(value)
+		} else {
+			data.AuthConfig.Strategy = types.StringValue(defaultAuthStrategy(data.Protocol.ValueString()))
+		}
+	}
+
+	// Only read the environment variables that make sense for the chosen strategy, so that leftover
+	// variables in the environment don't make an otherwise valid configuration fail.
+	switch data.AuthConfig.Strategy.ValueString() {
+	case authStrategyToken:
+		if data.AuthConfig.Token.IsNull() && data.AuthConfig.TokenFile.IsNull() {
+			if value := getenv(envToken); value != "" {
+				data.AuthConfig.Token = types.StringValue(value)
+			}
+		}
+	case authStrategyCertificate:
+		if data.AuthConfig.Username.IsNull() {
+			if value := getenv(envUser); value != "" {
+				data.AuthConfig.Username = types.StringValue(value)
+			}
+		}
+	default:
+		if data.AuthConfig.Username.IsNull() {
+			if value := getenv(envUser); value != "" {
+				data.AuthConfig.Username = types.StringValue(value)
+			}
+		}
+		if data.AuthConfig.Password.IsNull() {
+			if value := getenv(envPassword); value != "" {
+				data.AuthConfig.Password = types.StringValue(value)
+			}
+		}
+	}
+
+	return diags
+}
+
+// defaultAuthStrategy returns the authentication strategy to use for the given protocol when none is configured.
+func defaultAuthStrategy(protocol string) string {
+	if protocol == protocolHTTP || protocol == protocolHTTPS {
+		return authStrategyBasicAuth
+	}
+
+	return authStrategyPassword
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveEnvironment(t *testing.T) {
+	emptyModel := func() Model {
+		return Model{
+			Protocol: types.StringNull(),
+			Host:     types.StringNull(),
+			Port:     types.Int32Null(),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    Model
+		env     map[string]string
+		wantErr bool
+		want    Model
+	}{
+		{
+			name: "All values from environment",
+			data: emptyModel(),
+			env: map[string]string{
+				envProtocol: protocolHTTPS,
+				envHost:     "clickhouse.example.com",
+				envPort:     "8443",
+				envUser:     "automation",
+				envPassword: "secret",
+			},
+			want: Model{
+				Protocol: types.StringValue(protocolHTTPS),
+				Host:     types.StringValue("clickhouse.example.com"),
+				Port:     types.Int32Value(8443),
+				AuthConfig: &AuthConfig{
+					Strategy:  types.StringValue(authStrategyBasicAuth),
+					Username:  types.StringValue("automation"),
+					Password:  types.StringValue("secret"),
+					Token:     types.StringNull(),
+					TokenFile: types.StringNull(),
+				},
+			},
+		},
+		{
+			name: "Configuration takes precedence over environment",
+			data: Model{
+				Protocol: types.StringValue(protocolNative),
+				Host:     types.StringValue("localhost"),
+				Port:     types.Int32Value(9000),
+				AuthConfig: &AuthConfig{
+					Strategy:  types.StringValue(authStrategyPassword),
+					Username:  types.StringValue("default"),
+					Password:  types.StringNull(),
+					Token:     types.StringNull(),
+					TokenFile: types.StringNull(),
+				},
+			},
+			env: map[string]string{
+				envProtocol: protocolHTTPS,
+				envHost:     "clickhouse.example.com",
+				envPort:     "8443",
+				envUser:     "automation",
+				envPassword: "secret",
+			},
+			want: Model{
+				Protocol: types.StringValue(protocolNative),
+				Host:     types.StringValue("localhost"),
+				Port:     types.Int32Value(9000),
+				AuthConfig: &AuthConfig{
+					Strategy:  types.StringValue(authStrategyPassword),
+					Username:  types.StringValue("default"),
+					Password:  types.StringValue("secret"),
+					Token:     types.StringNull(),
+					TokenFile: types.StringNull(),
+				},
+			},
+		},
+		{
+			name: "Token strategy ignores user and password variables",
+			data: emptyModel(),
+			env: map[string]string{
+				envProtocol:     protocolHTTPS,
+				envHost:         "clickhouse.example.com",
+				envPort:         "8443",
+				envAuthStrategy: authStrategyToken,
+				envUser:         "automation",
+				envPassword:     "secret",
+				envToken:        "abc",
+			},
+			want: Model{
+				Protocol: types.StringValue(protocolHTTPS),
+				Host:     types.StringValue("clickhouse.example.com"),
+				Port:     types.Int32Value(8443),
+				AuthConfig: &AuthConfig{
+					Strategy:  types.StringValue(authStrategyToken),
+					Username:  types.StringNull(),
+					Password:  types.StringNull(),
+					Token:     types.StringValue("abc"),
+					TokenFile: types.StringNull(),
+				},
+			},
+		},
+		{
+			name:    "Missing host",
+			data:    emptyModel(),
+			env:     map[string]string{envProtocol: protocolNative, envPort: "9000"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid port",
+			data:    emptyModel(),
+			env:     map[string]string{envProtocol: protocolNative, envHost: "localhost", envPort: "nine"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid protocol",
+			data:    emptyModel(),
+			env:     map[string]string{envProtocol: "grpc", envHost: "localhost", envPort: "9000"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid strategy",
+			data:    emptyModel(),
+			env:     map[string]string{envProtocol: protocolNative, envHost: "localhost", envPort: "9000", envAuthStrategy: "kerberos"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.data
+			diags := resolveEnvironment(&data, func(key string) string {
+				return tt.env[key]
+			})
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("resolveEnvironment() diags = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !data.Protocol.Equal(tt.want.Protocol) || !data.Host.Equal(tt.want.Host) || !data.Port.Equal(tt.want.Port) {
+				t.Errorf("resolveEnvironment() connection = %s %s %s, want %s %s %s", data.Protocol, data.Host, data.Port, tt.want.Protocol, tt.want.Host, tt.want.Port)
+			}
+			if *data.AuthConfig != *tt.want.AuthConfig {
+				t.Errorf("resolveEnvironment() auth_config = %+v, want %+v", *data.AuthConfig, *tt.want.AuthConfig)
+			}
+		})
+	}
+}
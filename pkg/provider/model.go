@@ -6,19 +6,40 @@ import (
 
 // Model describes the provider data model.
 type Model struct {
-	Protocol   types.String `tfsdk:"protocol"`
-	Host       types.String `tfsdk:"host"`
-	Port       types.Int32  `tfsdk:"port"`
-	AuthConfig AuthConfig   `tfsdk:"auth_config"`
-	TLSConfig  *TLSConfig   `tfsdk:"tls_config"`
+	Protocol              types.String `tfsdk:"protocol"`
+	Host                  types.String `tfsdk:"host"`
+	Port                  types.Int32  `tfsdk:"port"`
+	Endpoints             types.List   `tfsdk:"endpoints"`
+	HostSelectionStrategy types.String `tfsdk:"host_selection_strategy"`
+	AuthConfig            *AuthConfig  `tfsdk:"auth_config"`
+	TLSConfig             *TLSConfig   `tfsdk:"tls_config"`
+	Retry                 *Retry       `tfsdk:"retry"`
+	QueryTimeout          types.String `tfsdk:"query_timeout"`
+	ConnectTimeout        types.String `tfsdk:"connect_timeout"`
+	QuerySettings         types.Map    `tfsdk:"query_settings"`
+	DryRun                types.Bool   `tfsdk:"dry_run"`
+	SQLLogFile            types.String `tfsdk:"sql_log_file"`
+	LogQueryResults       types.Bool   `tfsdk:"log_query_results"`
 }
 
 type AuthConfig struct {
-	Strategy types.String `tfsdk:"strategy"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Strategy  types.String `tfsdk:"strategy"`
+	Username  types.String `tfsdk:"username"`
+	Password  types.String `tfsdk:"password"`
+	Token     types.String `tfsdk:"token"`
+	TokenFile types.String `tfsdk:"token_file"`
 }
 
 type TLSConfig struct {
-	InsecureSkipVerify types.Bool `tfsdk:"insecure_skip_verify"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	CACert             types.String `tfsdk:"ca_cert"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	ServerName         types.String `tfsdk:"server_name"`
+}
+
+type Retry struct {
+	MaxAttempts    types.Int32  `tfsdk:"max_attempts"`
+	InitialBackoff types.String `tfsdk:"initial_backoff"`
+	MaxBackoff     types.String `tfsdk:"max_backoff"`
 }
@@ -4,25 +4,47 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	databasedatasource "github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/datasource/database"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/datasource/databases"
+	grantsdatasource "github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/datasource/grants"
+	roledatasource "github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/datasource/role"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/datasource/roles"
+	settingsprofiledatasource "github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/datasource/settingsprofile"
+	userdatasource "github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/datasource/user"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/datasource/users"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/project"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/database"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/function"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/grantprivilege"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/grantrole"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/grants"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/namedcollection"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/quota"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/role"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/rolemembers"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/rowpolicy"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/setting"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/settingsprofile"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/settingsprofileassociation"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/table"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/pkg/resource/user"
 )
 
@@ -32,13 +54,19 @@ const (
 	protocolHTTP         = "http"
 	protocolHTTPS        = "https"
 
-	authStrategyPassword  = "password"
-	authStrategyBasicAuth = "basicauth"
+	authStrategyPassword    = "password"
+	authStrategyBasicAuth   = "basicauth"
+	authStrategyCertificate = "certificate"
+	authStrategyToken       = "token"
+
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff     = 10 * time.Second
 )
 
 var (
 	availableProtocols      = []string{protocolNative, protocolNativeSecure, protocolHTTP, protocolHTTPS}
-	availableAuthStrategies = []string{authStrategyPassword, authStrategyBasicAuth}
+	availableAuthStrategies = []string{authStrategyPassword, authStrategyBasicAuth, authStrategyCertificate, authStrategyToken}
 )
 
 // Ensure Provider satisfies various provider interfaces.
@@ -48,7 +76,7 @@ var _ provider.Provider = &Provider{}
 type Provider struct{}
 
 func (p *Provider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
-	resp.TypeName = "clickhousedbops"
+	resp.TypeName = project.TypeName()
 	resp.Version = project.Version()
 }
 
@@ -56,57 +84,173 @@ func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"protocol": schema.StringAttribute{
-				Required:    true,
-				Description: fmt.Sprintf("The protocol to use to connect to clickhouse instance. Valid options are: %s", strings.Join(availableProtocols, ", ")),
+				Optional:    true,
+				Description: fmt.Sprintf("The protocol to use to connect to clickhouse instance. Valid options are: %s. Can also be set using the `%s` environment variable", strings.Join(availableProtocols, ", "), envProtocol),
 				Validators: []validator.String{
 					stringvalidator.OneOf(availableProtocols...),
 				},
 			},
 			"host": schema.StringAttribute{
-				Required:    true,
-				Description: "The hostname to use to connect to the clickhouse instance",
+				Optional:    true,
+				Description: fmt.Sprintf("The hostname to use to connect to the clickhouse instance. Can also be set using the `%s` environment variable", envHost),
 			},
 			"port": schema.Int32Attribute{
-				Required:    true,
-				Description: "The port to use to connect to the clickhouse instance",
+				Optional:    true,
+				Description: fmt.Sprintf("The port to use to connect to the clickhouse instance. Can also be set using the `%s` environment variable", envPort),
+			},
+			"endpoints": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "List of ClickHouse servers to connect to, in `host` or `host:port` format, as an alternative to `host`. Entries without a port use `port`. When a server can't be reached, the next one is tried according to `host_selection_strategy`",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					listvalidator.ConflictsWith(path.MatchRoot("host")),
+				},
+			},
+			"host_selection_strategy": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The order in which `endpoints` are tried. Valid options are: %s. Defaults to %q", strings.Join(availableHostSelectionStrategies(), ", "), clickhouseclient.HostSelectionInOrder),
+				Validators: []validator.String{
+					stringvalidator.OneOf(availableHostSelectionStrategies()...),
+				},
 			},
 			"auth_config": schema.SingleNestedAttribute{
 				Attributes: map[string]schema.Attribute{
 					"strategy": schema.StringAttribute{
-						Required:    true,
-						Description: "The authentication method to use",
+						Optional:    true,
+						Description: fmt.Sprintf("The authentication method to use. Valid options are: %s. The %q strategy authenticates using the TLS client certificate set in `tls_config` and requires one of the %s or %s protocols. The %q strategy sends a bearer token and requires one of the %s or %s protocols. Can also be set using the `%s` environment variable. Defaults to %q for the native protocols and %q for the http protocols", strings.Join(availableAuthStrategies, ", "), authStrategyCertificate, protocolNativeSecure, protocolHTTPS, authStrategyToken, protocolHTTP, protocolHTTPS, envAuthStrategy, authStrategyPassword, authStrategyBasicAuth),
 						Validators: []validator.String{
 							stringvalidator.OneOf(availableAuthStrategies...),
 						},
 					},
 					"username": schema.StringAttribute{
-						Required:    true,
-						Description: "The username to use to authenticate to ClickHouse",
+						Optional:    true,
+						Description: fmt.Sprintf("The username to use to authenticate to ClickHouse. Required unless using the %q strategy. Can also be set using the `%s` environment variable", authStrategyToken, envUser),
 						Validators: []validator.String{
 							stringvalidator.LengthAtLeast(1),
 						},
 					},
 					"password": schema.StringAttribute{
 						Optional:    true,
-						Description: "The password to use to authenticate to ClickHouse",
+						Description: fmt.Sprintf("The password to use to authenticate to ClickHouse. Must be null when using the %q or %q strategies. Can also be set using the `%s` environment variable", authStrategyCertificate, authStrategyToken, envPassword),
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"token": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: fmt.Sprintf("The bearer token to use to authenticate to ClickHouse with the %q strategy. If neither `token` nor `token_file` are set, the token is read from the `%s` environment variable", authStrategyToken, envToken),
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("token_file")),
+						},
+					},
+					"token_file": schema.StringAttribute{
+						Optional:    true,
+						Description: fmt.Sprintf("Path to a file containing the bearer token to use with the %q strategy. The file is read again before each query, so it can be used with short-lived tokens rotated on disk", authStrategyToken),
 						Validators: []validator.String{
 							stringvalidator.LengthAtLeast(1),
 						},
 					},
 				},
-				Required:    true,
-				Description: "Authentication configuration",
+				Optional:    true,
+				Description: "Authentication configuration. Can be omitted when all of its attributes are set using environment variables",
 			},
 			"tls_config": schema.SingleNestedAttribute{
 				Attributes: map[string]schema.Attribute{
 					"insecure_skip_verify": schema.BoolAttribute{
 						Optional:    true,
-						Description: "Skip TLS cert verification when using the https protocol. This is insecure!",
+						Description: "Skip TLS cert verification when using the https or nativesecure protocols. This is insecure!",
+					},
+					"ca_cert": schema.StringAttribute{
+						Optional:    true,
+						Description: "PEM encoded CA certificate bundle used to verify the server certificate. Defaults to the system's CA bundle",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"client_cert": schema.StringAttribute{
+						Optional:    true,
+						Description: "PEM encoded client certificate to present to the server for mutual TLS authentication. Requires `client_key`",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("client_key")),
+						},
+					},
+					"client_key": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "PEM encoded private key of `client_cert`",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("client_cert")),
+						},
+					},
+					"server_name": schema.StringAttribute{
+						Optional:    true,
+						Description: "Server name used to verify the server certificate and sent in the TLS SNI extension. Defaults to `host`",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
 					},
 				},
 				Optional:    true,
 				Description: "TLS configuration options",
 			},
+			"query_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum time a single query can take, as a duration string like `30s` or `5m`. Queries are cancelled client-side when the timeout is reached, the server might keep running them unless `max_execution_time` is also set in `query_settings`. Defaults to no timeout",
+			},
+			"connect_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum time to establish a connection to a ClickHouse server, as a duration string. Defaults to `30s`",
+			},
+			"query_settings": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "ClickHouse settings to apply to every query run by the provider, for example `distributed_ddl_task_timeout` or `max_execution_time`. Queries run `ON CLUSTER` always use `distributed_ddl_output_mode = never_throw`, so that the outcome on every host is checked and any host that failed or timed out is reported as an error.",
+			},
+			"sql_log_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a file where every statement changing ClickHouse, like CREATE, ALTER, GRANT, REVOKE and DROP, is written in the order it is run. The file is emptied every time the provider is configured, so that it only holds the statements of the current run. Read queries are not recorded. The file contains password hashes, so it should be protected like the Terraform state. Values of named collections are redacted, as they can hold plain secrets",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"log_query_results": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, the results of queries are written to the debug logs. Only supported with the http and https protocols. Results are not logged by default, as they can be large",
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, statements changing ClickHouse are written to `sql_log_file` instead of being run, while read queries still run on the server. Requires `sql_log_file`. Created and updated resources are stored with their planned values instead of being read back, computed values like `id` are left empty. Deletions, like any statement not run while creating or updating a resource, always fail so that resources are kept in the state",
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("sql_log_file")),
+				},
+			},
+			"retry": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int32Attribute{
+						Optional:    true,
+						Description: fmt.Sprintf("Maximum number of times a query is run, including the first attempt. Set to 1 to disable retries. Defaults to %d", defaultRetryMaxAttempts),
+						Validators: []validator.Int32{
+							int32validator.AtLeast(1),
+						},
+					},
+					"initial_backoff": schema.StringAttribute{
+						Optional:    true,
+						Description: fmt.Sprintf("Time to wait before the first retry, as a duration string like `500ms` or `2s`. It doubles after every retry. Defaults to `%s`", defaultRetryInitialBackoff),
+					},
+					"max_backoff": schema.StringAttribute{
+						Optional:    true,
+						Description: fmt.Sprintf("Maximum time to wait between two attempts, as a duration string. Defaults to `%s`", defaultRetryMaxBackoff),
+					},
+				},
+				Optional:    true,
+				Description: "Retry configuration for queries failing with transient errors, such as network failures, `TOO_MANY_SIMULTANEOUS_QUERIES` or Keeper session expiry. Errors like syntax errors or missing privileges are never retried. Statements changing entities, like `CREATE` or `GRANT`, are only retried when they never reached the server, for example when the connection was refused",
+			},
 		},
 	}
 }
@@ -121,11 +265,62 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
-	if data.Host.IsUnknown() || data.Protocol.IsUnknown() || data.Port.IsUnknown() || data.AuthConfig.Strategy.IsUnknown() || data.AuthConfig.Username.IsUnknown() {
+	if data.Host.IsUnknown() || data.Protocol.IsUnknown() || data.Port.IsUnknown() || data.Endpoints.IsUnknown() || (data.AuthConfig != nil && (data.AuthConfig.Strategy.IsUnknown() || data.AuthConfig.Username.IsUnknown())) {
 		// We don't know the service data yet.
 		return
 	}
 
+	resp.Diagnostics.Append(resolveEnvironment(&data, os.Getenv)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var endpoints []clickhouseclient.Endpoint
+	if !data.Endpoints.IsNull() {
+		var values []string
+		resp.Diagnostics.Append(data.Endpoints.ElementsAs(ctx, &values, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		endpoints, err = parseEndpoints(values, data.Port)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoints"), "invalid configuration", err.Error())
+			return
+		}
+	}
+	hostSelectionStrategy := clickhouseclient.HostSelectionStrategy(data.HostSelectionStrategy.ValueString())
+
+	queryTimeout := parseDuration(data.QueryTimeout, path.Root("query_timeout"), resp)
+	connectTimeout := parseDuration(data.ConnectTimeout, path.Root("connect_timeout"), resp)
+	var querySettings map[string]string
+	if !data.QuerySettings.IsNull() {
+		resp.Diagnostics.Append(data.QuerySettings.ElementsAs(ctx, &querySettings, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tlsConfig *tls.Config
+	if data.Protocol.ValueString() == protocolNativeSecure || data.Protocol.ValueString() == protocolHTTPS {
+		tlsOptions := clickhouseclient.TLSOptions{}
+		if data.TLSConfig != nil {
+			tlsOptions.InsecureSkipVerify = data.TLSConfig.InsecureSkipVerify.ValueBool()
+			tlsOptions.CACert = data.TLSConfig.CACert.ValueString()
+			tlsOptions.ClientCert = data.TLSConfig.ClientCert.ValueString()
+			tlsOptions.ClientKey = data.TLSConfig.ClientKey.ValueString()
+			tlsOptions.ServerName = data.TLSConfig.ServerName.ValueString()
+		}
+
+		tlsConfig, err = clickhouseclient.NewTLSConfig(tlsOptions)
+		if err != nil {
+			resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid TLS configuration. %s", err))
+			return
+		}
+	} else if data.TLSConfig != nil {
+		resp.Diagnostics.AddWarning("tls_config is ignored", fmt.Sprintf("tls_config is only used with the %s and %s protocols", protocolNativeSecure, protocolHTTPS))
+	}
+
 	var clickhouseClient clickhouseclient.ClickhouseClient
 	{
 		switch data.Protocol.ValueString() {
@@ -133,6 +328,7 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 			fallthrough
 		case protocolNativeSecure:
 			var auth *clickhouseclient.UserPasswordAuth
+			var certificateAuth *clickhouseclient.CertificateAuth
 			switch data.AuthConfig.Strategy.ValueString() {
 			case authStrategyPassword:
 				auth = &clickhouseclient.UserPasswordAuth{
@@ -147,14 +343,19 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 				if !valid {
 					resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid authentication strategy configuration. %s", strings.Join(errorStrings, ", ")))
 				}
+			case authStrategyCertificate:
+				certificateAuth = p.certificateAuth(data, resp)
+				if resp.Diagnostics.HasError() {
+					return
+				}
 			default:
-				resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid authentication strategy %q. %s protocol only supports %q", data.AuthConfig.Strategy, protocolNative, authStrategyPassword))
+				resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid authentication strategy %q. %s protocol only supports %q and %q", data.AuthConfig.Strategy, protocolNative, authStrategyPassword, authStrategyCertificate))
 				return
 			}
 
 			var port uint16
 			{
-				if !data.Port.IsUnknown() {
+				if !data.Port.IsNull() {
 					portVal := data.Port.ValueInt32()
 					if portVal <= 0 || portVal > 65535 {
 						resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid port %s.", data.Port.String()))
@@ -166,15 +367,23 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 			}
 
 			clickhouseClient, err = clickhouseclient.NewNativeClient(clickhouseclient.NativeClientConfig{
-				Host:             data.Host.ValueString(),
-				Port:             port,
-				UserPasswordAuth: auth,
-				EnableTLS:        data.Protocol.ValueString() == protocolNativeSecure,
+				Host:                  data.Host.ValueString(),
+				Port:                  port,
+				Endpoints:             endpoints,
+				HostSelectionStrategy: hostSelectionStrategy,
+				UserPasswordAuth:      auth,
+				CertificateAuth:       certificateAuth,
+				TLSConfig:             tlsConfig,
+				QueryTimeout:          queryTimeout,
+				ConnectTimeout:        connectTimeout,
+				Settings:              querySettings,
 			})
 		case protocolHTTP:
 			fallthrough
 		case protocolHTTPS:
 			var auth *clickhouseclient.BasicAuth
+			var certificateAuth *clickhouseclient.CertificateAuth
+			var tokenAuth *clickhouseclient.TokenAuth
 			switch data.AuthConfig.Strategy.ValueString() {
 			case authStrategyBasicAuth:
 				auth = &clickhouseclient.BasicAuth{
@@ -189,14 +398,24 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 				if !valid {
 					resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid authentication strategy configuration. %s", strings.Join(errorStrings, ", ")))
 				}
+			case authStrategyCertificate:
+				certificateAuth = p.certificateAuth(data, resp)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+			case authStrategyToken:
+				tokenAuth = p.tokenAuth(data, resp)
+				if resp.Diagnostics.HasError() {
+					return
+				}
 			default:
-				resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid authentication strategy %q. %s protocol only supports %q", data.AuthConfig.Strategy, protocolHTTP, authStrategyBasicAuth))
+				resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid authentication strategy %q. %s protocol only supports %q, %q and %q", data.AuthConfig.Strategy, protocolHTTP, authStrategyBasicAuth, authStrategyCertificate, authStrategyToken))
 				return
 			}
 
 			var port uint16
 			{
-				if !data.Port.IsUnknown() {
+				if !data.Port.IsNull() {
 					portVal := data.Port.ValueInt32()
 					if portVal <= 0 || portVal > 65535 {
 						resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid port %s.", data.Port.String()))
@@ -207,22 +426,25 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 				}
 			}
 
-			var tlsConfig *tls.Config
 			protocol := "http"
 			if data.Protocol.ValueString() == protocolHTTPS {
 				protocol = "https"
-				tlsConfig = &tls.Config{} //nolint:gosec
-				if data.TLSConfig != nil && !data.TLSConfig.InsecureSkipVerify.IsNull() {
-					tlsConfig.InsecureSkipVerify = data.TLSConfig.InsecureSkipVerify.ValueBool()
-				}
 			}
 
 			config := clickhouseclient.HTTPClientConfig{
-				Protocol:  protocol,
-				Host:      data.Host.ValueString(),
-				Port:      port,
-				BasicAuth: auth,
-				TLSConfig: tlsConfig,
+				Protocol:              protocol,
+				Host:                  data.Host.ValueString(),
+				Port:                  port,
+				Endpoints:             endpoints,
+				HostSelectionStrategy: hostSelectionStrategy,
+				BasicAuth:             auth,
+				CertificateAuth:       certificateAuth,
+				TokenAuth:             tokenAuth,
+				TLSConfig:             tlsConfig,
+				QueryTimeout:          queryTimeout,
+				ConnectTimeout:        connectTimeout,
+				Settings:              querySettings,
+				LogResults:            data.LogQueryResults.ValueBool(),
 			}
 
 			clickhouseClient, err = clickhouseclient.NewHTTPClient(config)
@@ -234,7 +456,30 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
-	dbopsClient, err := dbops.NewClient(clickhouseClient)
+	retryConfig := p.retryConfig(data, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if retryConfig.MaxAttempts > 1 {
+		clickhouseClient, err = clickhouseclient.NewRetryClient(clickhouseClient, retryConfig)
+		if err != nil {
+			resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid retry configuration. %s", err))
+			return
+		}
+	}
+
+	if !data.SQLLogFile.IsNull() {
+		sqlLogFile, err := clickhouseclient.NewSQLLogFile(data.SQLLogFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("sql_log_file"), "error opening sql_log_file", fmt.Sprintf("%+v\n", err))
+			return
+		}
+
+		clickhouseClient = clickhouseclient.NewRecordingClient(clickhouseClient, sqlLogFile, data.DryRun.ValueBool())
+	}
+
+	dbopsClient, err := dbops.NewClient(clickhouseclient.WithQueryTags(ctx, "provider", clickhouseclient.OperationConfigure), clickhouseClient)
 	if err != nil {
 		resp.Diagnostics.AddError("error initializing dbops client", fmt.Sprintf("%+v\n", err))
 		return
@@ -244,21 +489,130 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 	resp.DataSourceData = dbopsClient
 }
 
+// certificateAuth validates the configuration of the certificate authentication strategy and builds the CertificateAuth for it.
+func (p *Provider) certificateAuth(data Model, resp *provider.ConfigureResponse) *clickhouseclient.CertificateAuth {
+	if data.Protocol.ValueString() != protocolNativeSecure && data.Protocol.ValueString() != protocolHTTPS {
+		resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("authentication strategy %q requires one of the %s or %s protocols", authStrategyCertificate, protocolNativeSecure, protocolHTTPS))
+		return nil
+	}
+
+	if !data.AuthConfig.Password.IsNull() {
+		resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("password must not be set when using the %q authentication strategy", authStrategyCertificate))
+		return nil
+	}
+
+	if data.TLSConfig == nil || data.TLSConfig.ClientCert.IsNull() {
+		resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("tls_config.client_cert and tls_config.client_key must be set when using the %q authentication strategy", authStrategyCertificate))
+		return nil
+	}
+
+	auth := &clickhouseclient.CertificateAuth{
+		Username: data.AuthConfig.Username.ValueString(),
+	}
+
+	valid, errorStrings := auth.ValidateConfig()
+	if !valid {
+		resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid authentication strategy configuration. %s", strings.Join(errorStrings, ", ")))
+		return nil
+	}
+
+	return auth
+}
+
+// tokenAuth validates the configuration of the token authentication strategy and builds the TokenAuth for it.
+func (p *Provider) tokenAuth(data Model, resp *provider.ConfigureResponse) *clickhouseclient.TokenAuth {
+	if !data.AuthConfig.Username.IsNull() || !data.AuthConfig.Password.IsNull() {
+		resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("username and password must not be set when using the %q authentication strategy", authStrategyToken))
+		return nil
+	}
+
+	auth := &clickhouseclient.TokenAuth{
+		Token:     data.AuthConfig.Token.ValueString(),
+		TokenFile: data.AuthConfig.TokenFile.ValueString(),
+	}
+
+	valid, errorStrings := auth.ValidateConfig()
+	if !valid {
+		resp.Diagnostics.AddError("invalid configuration", fmt.Sprintf("invalid authentication strategy configuration. %s. The token can also be set using the %s environment variable", strings.Join(errorStrings, ", "), envToken))
+		return nil
+	}
+
+	return auth
+}
+
+// retryConfig builds the RetryConfig from the retry attribute, using defaults for the unset values.
+func (p *Provider) retryConfig(data Model, resp *provider.ConfigureResponse) clickhouseclient.RetryConfig {
+	config := clickhouseclient.RetryConfig{
+		MaxAttempts:    defaultRetryMaxAttempts,
+		InitialBackoff: defaultRetryInitialBackoff,
+		MaxBackoff:     defaultRetryMaxBackoff,
+	}
+
+	if data.Retry == nil {
+		return config
+	}
+
+	if !data.Retry.MaxAttempts.IsNull() {
+		config.MaxAttempts = int(data.Retry.MaxAttempts.ValueInt32())
+	}
+
+	if !data.Retry.InitialBackoff.IsNull() {
+		config.InitialBackoff = parseDuration(data.Retry.InitialBackoff, path.Root("retry").AtName("initial_backoff"), resp)
+	}
+
+	if !data.Retry.MaxBackoff.IsNull() {
+		config.MaxBackoff = parseDuration(data.Retry.MaxBackoff, path.Root("retry").AtName("max_backoff"), resp)
+	}
+
+	return config
+}
+
+// parseDuration parses a duration string attribute, returning 0 when it's not set.
+func parseDuration(value types.String, attributePath path.Path, resp *provider.ConfigureResponse) time.Duration {
+	if value.IsNull() {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration < 0 {
+		resp.Diagnostics.AddAttributeError(attributePath, "invalid configuration", fmt.Sprintf("invalid duration %q, expected a positive duration like \"30s\" or \"5m\"", value.ValueString()))
+		return 0
+	}
+
+	return duration
+}
+
 func (p *Provider) Resources(ctx context.Context) []func() tfresource.Resource {
 	return []func() tfresource.Resource{
 		database.NewResource,
 		role.NewResource,
 		user.NewResource,
 		grantrole.NewResource,
+		rolemembers.NewResource,
 		grantprivilege.NewResource,
+		grants.NewResource,
 		settingsprofile.NewResource,
 		setting.NewResource,
 		settingsprofileassociation.NewResource,
+		rowpolicy.NewResource,
+		quota.NewResource,
+		function.NewResource,
+		namedcollection.NewResource,
+		table.NewResource,
 	}
 }
 
 func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		databasedatasource.NewDataSource,
+		roledatasource.NewDataSource,
+		userdatasource.NewDataSource,
+		settingsprofiledatasource.NewDataSource,
+		databases.NewDataSource,
+		roles.NewDataSource,
+		users.NewDataSource,
+		grantsdatasource.NewDataSource,
+	}
 }
 
 func New() func() provider.Provider {
@@ -17,7 +17,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/pingcap/errors"
 
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
 )
 
 //go:embed database.md
@@ -51,7 +53,7 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 		Attributes: map[string]schema.Attribute{
 			"cluster_name": schema.StringAttribute{
 				Optional:    true,
-				Description: "Name of the cluster to create the database into. If omitted, the database will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\nShould be set when hitting a cluster with more than one replica.",
+				Description: "Name of the cluster to create the database into. If omitted, the database will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\nShould be set when hitting a cluster with more than one replica.\nWhen set, every replica is checked on refresh and the database is planned for creation again if it is missing on any of them. Replicas where it is defined differently than on the others are reported in a warning.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -95,6 +97,8 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
 	var plan Database
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -111,6 +115,11 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	state, err := r.syncDatabaseState(ctx, db.UUID, plan.ClusterName.ValueStringPointer())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -136,6 +145,8 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 }
 
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
 	var plan Database
 	diags := req.State.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -152,6 +163,13 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	if state != nil && !plan.ClusterName.IsNull() {
+		drift, err := r.client.FindReplicaDrift(ctx, dbops.DatabasesTable, state.Name.ValueString(), plan.ClusterName.ValueString())
+		if resourceutil.ReportReplicaDrift(ctx, resp, "Error syncing database", fmt.Sprintf("Database %q", state.Name.ValueString()), plan.ClusterName.ValueString(), drift, err) {
+			return
+		}
+	}
+
 	if state == nil {
 		resp.State.RemoveResource(ctx)
 	} else {
@@ -168,6 +186,8 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 }
 
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
 	var plan Database
 	diags := req.State.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -186,6 +206,8 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 }
 
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationImport)
+
 	// req.ID can either be in the form <cluster name>:<database ref> or just <database ref>
 	// database ref can either be the name or the UUID of the database.
 
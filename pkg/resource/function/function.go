@@ -0,0 +1,327 @@
+package function
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed function.md
+var functionResourceDescription string
+
+var (
+	_ resource.Resource                = &Resource{}
+	_ resource.ResourceWithConfigure   = &Resource{}
+	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.ResourceWithModifyPlan  = &Resource{}
+)
+
+// bodyKey is the private state key holding the body as formatted by ClickHouse after the function was created.
+const bodyKey = "body"
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client dbops.Client
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_function"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to create the resource into. If omitted, resource will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster or when user defined functions are stored in ZooKeeper.\nWhen set, every replica is checked on refresh and the resource is planned for creation again if it is missing on any of them. Replicas where it is defined differently than on the others are reported in a warning.\n",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the function",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"parameters": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Names of the parameters of the function, in order. Can be empty.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"body": schema.StringAttribute{
+				Required:    true,
+				Description: "SQL expression computing the result of the function from its parameters, for example `k * x + b`. Changes in formatting are ignored when ClickHouse formats both expressions the same way.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+		MarkdownDescription: functionResourceDescription,
+	}
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationPlan)
+
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.client == nil {
+		// Nothing to compare when creating or destroying the function.
+		return
+	}
+
+	var plan, state Function
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Body.IsUnknown() || plan.Parameters.IsUnknown() || plan.Body.Equal(state.Body) {
+		return
+	}
+
+	// The state holds either the configured body or, after an import, the body formatted by ClickHouse. Formatting both
+	// the same way tells whether they are the same expression, so that the function is not recreated.
+	planBody, diags := r.formatBody(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	stateBody, diags := r.formatBody(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || planBody == nil || stateBody == nil {
+		return
+	}
+
+	if *planBody == *stateBody {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("body"), state.Body)...)
+	}
+}
+
+// formatBody returns the body of function formatted by ClickHouse, or nil if the server can't format it.
+func (r *Resource) formatBody(ctx context.Context, function Function) (*string, diag.Diagnostics) {
+	parameters := make([]string, 0)
+	diags := function.Parameters.ElementsAs(ctx, &parameters, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	formatted, err := r.client.FormatFunctionBody(ctx, parameters, function.Body.ValueString())
+	if err != nil {
+		// Older servers can't format queries, and an invalid body is reported when creating the function. The bodies
+		// are then compared as they are.
+		tflog.Debug(ctx, "Unable to format function body", map[string]any{"error": err.Error()})
+		return nil, diags
+	}
+
+	return &formatted, diags
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(dbops.Client)
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
+	var plan Function
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	parameters := make([]string, 0)
+	resp.Diagnostics.Append(plan.Parameters.ElementsAs(ctx, &parameters, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	function, err := r.client.CreateFunction(ctx, dbops.Function{
+		Name:       plan.Name.ValueString(),
+		Parameters: parameters,
+		Body:       plan.Body.ValueString(),
+	}, plan.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Function",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	if function == nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Function",
+			"The function was not found after being created",
+		)
+		return
+	}
+
+	// The body is kept as configured, ClickHouse stores it reformatted.
+	resp.Diagnostics.Append(resourceutil.SetApplied(ctx, resp.Private, bodyKey, function.Body)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
+	var state Function
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	function, err := r.client.GetFunction(ctx, state.Name.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse Function",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if function != nil && !state.ClusterName.IsNull() {
+		drift, err := r.client.FindReplicaDrift(ctx, dbops.FunctionsTable, function.Name, state.ClusterName.ValueString())
+		if resourceutil.ReportReplicaDrift(ctx, resp, "Error Reading ClickHouse Function", fmt.Sprintf("Function %q", function.Name), state.ClusterName.ValueString(), drift, err) {
+			return
+		}
+	}
+
+	if function == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Only report the body read from ClickHouse if the function was replaced since it was created, as the configured
+	// one is formatted differently.
+	applied, diags := resourceutil.GetApplied[string](ctx, req.Private, bodyKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if applied == nil || *applied != function.Body {
+		state.Body = types.StringValue(function.Body)
+	}
+
+	parameters, diags := types.ListValueFrom(ctx, types.StringType, function.Parameters)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Parameters = parameters
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	panic("Update of function resource is not supported")
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
+	var state Function
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteFunction(ctx, state.Name.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting ClickHouse Function",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationImport)
+
+	// req.ID can either be in the form <cluster name>:<function name> or just <function name>
+
+	// Check if cluster name is specified
+	name := req.ID
+	var clusterName *string
+	if strings.Contains(req.ID, ":") {
+		clusterName = &strings.Split(req.ID, ":")[0]
+		name = strings.Split(req.ID, ":")[1]
+	}
+
+	function, err := r.client.GetFunction(ctx, name, clusterName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cannot find function",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if function == nil {
+		resp.Diagnostics.AddError(
+			"Cannot find function",
+			fmt.Sprintf("No SQL user defined function named %q was found", name),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), function.Name)...)
+
+	if clusterName != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), clusterName)...)
+	}
+}
@@ -0,0 +1,131 @@
+package function_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	resourceType = "clickhousedbops_function"
+	resourceName = "foo"
+)
+
+func TestFunction_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		name := attrs["name"]
+		if name == "" {
+			return false, fmt.Errorf("name attribute was not set")
+		}
+		function, err := dbopsClient.GetFunction(ctx, name, clusterName)
+		return function != nil, err
+	}
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		name := attrs["name"]
+		if name == nil {
+			return fmt.Errorf("name was nil")
+		}
+
+		function, err := dbopsClient.GetFunction(ctx, name.(string), clusterName)
+		if err != nil {
+			return err
+		}
+
+		if function == nil {
+			return fmt.Errorf("function %q was not found", name)
+		}
+
+		// Check state fields are aligned with the function we retrieved from CH.
+		parameters := attrs["parameters"].([]interface{})
+		if len(parameters) != len(function.Parameters) {
+			return fmt.Errorf("expected %d parameters, got %d", len(function.Parameters), len(parameters))
+		}
+		for i, p := range parameters {
+			if p.(string) != function.Parameters[i] {
+				return fmt.Errorf("expected parameter %d to be %q, was %q", i, function.Parameters[i], p.(string))
+			}
+		}
+
+		// The body is kept as configured.
+		if attrs["body"].(string) != "k*x + b" {
+			return fmt.Errorf("expected body to be kept as configured, was %q", attrs["body"].(string))
+		}
+
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		return nil
+	}
+
+	buildResource := func(clusterName *string) string {
+		function := resourcebuilder.New(resourceType, resourceName).
+			WithStringAttribute("name", "f"+acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)).
+			WithListAttribute("parameters", []cty.Value{cty.StringVal("x"), cty.StringVal("k"), cty.StringVal("b")}).
+			WithStringAttribute("body", "k*x + b")
+
+		if clusterName != nil {
+			function = function.WithStringAttribute("cluster_name", *clusterName)
+		}
+
+		return function.Build()
+	}
+
+	tests := []runner.TestCase{
+		{
+			Name:                "Create Function using Native protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "native",
+			Resource:            buildResource(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Function using HTTP protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "http",
+			Resource:            buildResource(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Function using Native protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "native",
+			Resource:            buildResource(&clusterName),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Function using HTTP protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "http",
+			Resource:            buildResource(&clusterName),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
@@ -0,0 +1,12 @@
+package function
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Function struct {
+	ClusterName types.String `tfsdk:"cluster_name"`
+	Name        types.String `tfsdk:"name"`
+	Parameters  types.List   `tfsdk:"parameters"`
+	Body        types.String `tfsdk:"body"`
+}
@@ -16,18 +16,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/privileges"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
 )
 
 //go:embed grantprivilege.md
 var grantPrivilegeDescription string
 
-type availableGrants struct {
-	Aliases map[string]string   `json:"aliases"`
-	Groups  map[string][]string `json:"groups"`
-	Scopes  map[string]string   `json:"scopes"`
-}
-
 var (
 	_ resource.Resource              = &Resource{}
 	_ resource.ResourceWithConfigure = &Resource{}
@@ -48,7 +45,7 @@ func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, res
 func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	validPrivileges := make([]string, 0)
 
-	upstrGrts := parseGrants()
+	upstrGrts := privileges.ParseGrants()
 
 	for privilege := range upstrGrts.Scopes {
 		validPrivileges = append(validPrivileges, privilege)
@@ -114,6 +111,22 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 					stringvalidator.AlsoRequires(path.Expressions{path.MatchRoot("table_name")}...),
 				},
 			},
+			"named_collection_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the named collection to grant privilege on, for privileges such as `NAMED COLLECTION`. Defaults to all named collections if left null",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.NoneOf("*"),
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot("database_name"),
+						path.MatchRoot("table_name"),
+						path.MatchRoot("column_name"),
+					}...),
+				},
+			},
 			"grantee_user_name": schema.StringAttribute{
 				Optional:    true,
 				Description: "Name of the `user` to grant privileges to.",
@@ -164,12 +177,14 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _
 }
 
 func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationPlan)
+
 	if req.Plan.Raw.IsNull() {
 		// If the entire plan is null, the resource is planned for destruction.
 		return
 	}
 
-	upstrGrts := parseGrants()
+	upstrGrts := privileges.ParseGrants()
 
 	var plan, state, config GrantPrivilege
 	diags := req.Plan.Get(ctx, &plan)
@@ -225,6 +240,15 @@ func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReques
 	// Check required fields which depend on the grant's scope.
 	{
 		scope := upstrGrts.Scopes[plan.Privilege.ValueString()]
+		if scope != "NAMED_COLLECTION" && !plan.NamedCollection.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("named_collection_name"),
+				"Invalid Grant Privilege",
+				fmt.Sprintf("'named_collection_name' must be null when 'privilege_name' is %q", plan.Privilege.ValueString()),
+			)
+			return
+		}
+
 		switch scope {
 		case "GLOBAL":
 			if !plan.Database.IsNull() {
@@ -249,7 +273,14 @@ func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReques
 				return
 			}
 		case "NAMED_COLLECTION":
-			fallthrough
+			if !plan.Database.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("database_name"),
+					"Invalid Grant Privilege",
+					fmt.Sprintf("'database_name' must be null when 'privilege_name' is %q, use 'named_collection_name' instead", plan.Privilege.ValueString()),
+				)
+				return
+			}
 		case "USER_NAME":
 			fallthrough
 		case "TABLE ENGINE":
@@ -264,6 +295,8 @@ func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReques
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
 	var plan GrantPrivilege
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -273,7 +306,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	grant := dbops.GrantPrivilege{
 		AccessType:      plan.Privilege.ValueString(),
-		DatabaseName:    plan.Database.ValueStringPointer(),
+		DatabaseName:    plan.databaseColumn().ValueStringPointer(),
 		TableName:       plan.Table.ValueStringPointer(),
 		ColumnName:      plan.Column.ValueStringPointer(),
 		GranteeUserName: plan.GranteeUserName.ValueStringPointer(),
@@ -290,6 +323,11 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	if createdGrant == nil {
 		existing, err := r.client.GetAllGrantsForGrantee(ctx, grant.GranteeUserName, grant.GranteeRoleName, plan.ClusterName.ValueStringPointer())
 		if err != nil {
@@ -302,7 +340,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 		overlappingExplanations := make([]string, 0)
 		for _, e := range existing {
-			if overlaps(plan, e) {
+			if !e.PartialRevoke && overlaps(plan, e) {
 				// Prepare human-readable explanation of the overlap.
 				overlappingExplanations = append(overlappingExplanations, explainOverlap(plan, e))
 			}
@@ -331,13 +369,13 @@ This is a configuration error that prevents further actions. Please note that th
 	state := GrantPrivilege{
 		ClusterName:     plan.ClusterName,
 		Privilege:       types.StringValue(createdGrant.AccessType),
-		Database:        types.StringPointerValue(createdGrant.DatabaseName),
 		Table:           types.StringPointerValue(createdGrant.TableName),
 		Column:          types.StringPointerValue(createdGrant.ColumnName),
 		GranteeUserName: types.StringPointerValue(createdGrant.GranteeUserName),
 		GranteeRoleName: types.StringPointerValue(createdGrant.GranteeRoleName),
 		GrantOption:     types.BoolValue(createdGrant.GrantOption),
 	}
+	state.setDatabaseColumn(createdGrant.DatabaseName)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -347,6 +385,8 @@ This is a configuration error that prevents further actions. Please note that th
 }
 
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
 	var state GrantPrivilege
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -354,7 +394,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
-	grant, err := r.client.GetGrantPrivilege(ctx, state.Privilege.ValueString(), state.Database.ValueStringPointer(), state.Table.ValueStringPointer(), state.Column.ValueStringPointer(), state.GranteeUserName.ValueStringPointer(), state.GranteeRoleName.ValueStringPointer(), state.ClusterName.ValueStringPointer())
+	grant, err := r.client.GetGrantPrivilege(ctx, state.Privilege.ValueString(), state.databaseColumn().ValueStringPointer(), state.Table.ValueStringPointer(), state.Column.ValueStringPointer(), state.GranteeUserName.ValueStringPointer(), state.GranteeRoleName.ValueStringPointer(), state.ClusterName.ValueStringPointer())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading ClickHouse Privilege Grant",
@@ -365,7 +405,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	if grant != nil {
 		state.Privilege = types.StringValue(grant.AccessType)
-		state.Database = types.StringPointerValue(grant.DatabaseName)
+		state.setDatabaseColumn(grant.DatabaseName)
 		state.Table = types.StringPointerValue(grant.TableName)
 		state.Column = types.StringPointerValue(grant.ColumnName)
 		state.GranteeUserName = types.StringPointerValue(grant.GranteeUserName)
@@ -384,6 +424,8 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 }
 
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
 	var state GrantPrivilege
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -391,7 +433,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
-	err := r.client.RevokeGrantPrivilege(ctx, state.Privilege.ValueString(), state.Database.ValueStringPointer(), state.Table.ValueStringPointer(), state.Column.ValueStringPointer(), state.GranteeUserName.ValueStringPointer(), state.GranteeRoleName.ValueStringPointer(), state.ClusterName.ValueStringPointer())
+	err := r.client.RevokeGrantPrivilege(ctx, state.Privilege.ValueString(), state.databaseColumn().ValueStringPointer(), state.Table.ValueStringPointer(), state.Column.ValueStringPointer(), state.GranteeUserName.ValueStringPointer(), state.GranteeRoleName.ValueStringPointer(), state.ClusterName.ValueStringPointer())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting ClickHouse Privilege Grant",
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
@@ -17,6 +19,8 @@ const (
 
 	granteeRoleName = "grantee"
 	granteeUserName = "user1"
+
+	namedCollectionName = "collection1"
 )
 
 func TestGrantprivilege_acceptance(t *testing.T) {
@@ -30,6 +34,10 @@ func TestGrantprivilege_acceptance(t *testing.T) {
 		WithStringAttribute("name", granteeUserName).
 		WithFunction("password_sha256_hash_wo", "sha256", "test").
 		WithIntAttribute("password_sha256_hash_wo_version", 1)
+	namedCollectionResource := resourcebuilder.
+		New("clickhousedbops_named_collection", namedCollectionName).
+		WithStringAttribute("name", namedCollectionName).
+		WithMapAttribute("sensitive_values", map[string]cty.Value{"password": cty.StringVal("secret")})
 
 	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
 		accessType := attrs["privilege_name"]
@@ -44,10 +52,14 @@ func TestGrantprivilege_acceptance(t *testing.T) {
 			return false, fmt.Errorf("both grantee_user_name and grantee_role_name attribute were not set")
 		}
 
+		// Privileges on named collections have the name of the collection in the database column.
 		var database *string
 		if attrs["database_name"] != "" {
 			s := attrs["database_name"]
 			database = &s
+		} else if attrs["named_collection_name"] != "" {
+			s := attrs["named_collection_name"]
+			database = &s
 		}
 
 		var table *string
@@ -81,8 +93,12 @@ func TestGrantprivilege_acceptance(t *testing.T) {
 		}
 
 		var database *string
-		if attrs["database_name"] != nil {
-			s := attrs["database_name"].(string)
+		databaseAttr := attrs["database_name"]
+		if attrs["named_collection_name"] != nil {
+			databaseAttr = attrs["named_collection_name"]
+		}
+		if databaseAttr != nil {
+			s := databaseAttr.(string)
 			database = &s
 		}
 
@@ -126,7 +142,7 @@ func TestGrantprivilege_acceptance(t *testing.T) {
 			return fmt.Errorf("expected privilege_name to be %q, was %q", grantprivilege.AccessType, attrs["privilege_name"].(string))
 		}
 
-		if !nilcompare.NilCompare(grantprivilege.DatabaseName, attrs["database_name"]) {
+		if !nilcompare.NilCompare(grantprivilege.DatabaseName, databaseAttr) {
 			return fmt.Errorf("wrong value for database attribute")
 		}
 
@@ -205,6 +221,22 @@ func TestGrantprivilege_acceptance(t *testing.T) {
 			CheckNotExistsFunc:  checkNotExistsFunc,
 			CheckAttributesFunc: checkAttributesFunc,
 		},
+		{
+			Name:     "Grant privilege on named collection to user using Native protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "native",
+			Resource: resourcebuilder.New(resourceType, resourceName).
+				WithStringAttribute("privilege_name", "NAMED COLLECTION").
+				WithResourceFieldReference("named_collection_name", "clickhousedbops_named_collection", namedCollectionName, "name").
+				WithResourceFieldReference("grantee_user_name", "clickhousedbops_user", granteeUserName, "name").
+				AddDependency(granteeUserResource.Build()).
+				AddDependency(namedCollectionResource.Build()).
+				Build(),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
 		// Single replica, HTTP
 		{
 			Name:     "Grant privilege on single column to role using HTTP protocol on a single replica",
@@ -253,6 +285,20 @@ func TestGrantprivilege_acceptance(t *testing.T) {
 			CheckNotExistsFunc:  checkNotExistsFunc,
 			CheckAttributesFunc: checkAttributesFunc,
 		},
+		{
+			Name:     "Grant privilege on all named collections to role using HTTP protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "http",
+			Resource: resourcebuilder.New(resourceType, resourceName).
+				WithStringAttribute("privilege_name", "NAMED COLLECTION").
+				WithResourceFieldReference("grantee_role_name", "clickhousedbops_role", granteeRoleName, "name").
+				AddDependency(granteeRoleResource.Build()).
+				Build(),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
 		// Replicated storage, native
 		{
 			Name:     "Grant privilege on table to role using Native protocol on a cluster using replicated storage",
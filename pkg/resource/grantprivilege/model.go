@@ -2,6 +2,8 @@ package grantprivilege
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/privileges"
 )
 
 type GrantPrivilege struct {
@@ -10,7 +12,33 @@ type GrantPrivilege struct {
 	Database        types.String `tfsdk:"database_name"`
 	Table           types.String `tfsdk:"table_name"`
 	Column          types.String `tfsdk:"column_name"`
+	NamedCollection types.String `tfsdk:"named_collection_name"`
 	GranteeUserName types.String `tfsdk:"grantee_user_name"`
 	GranteeRoleName types.String `tfsdk:"grantee_role_name"`
 	GrantOption     types.Bool   `tfsdk:"grant_option"`
 }
+
+// onNamedCollection tells if the privilege is granted on named collections rather than on databases and tables.
+func (g *GrantPrivilege) onNamedCollection() bool {
+	return privileges.ParseGrants().Scopes[g.Privilege.ValueString()] == "NAMED_COLLECTION"
+}
+
+// databaseColumn returns the value of the 'database' column of system.grants for this privilege, that is the name of
+// the named collection for privileges granted on named collections.
+func (g *GrantPrivilege) databaseColumn() types.String {
+	if g.onNamedCollection() {
+		return g.NamedCollection
+	}
+	return g.Database
+}
+
+// setDatabaseColumn sets either Database or NamedCollection from the 'database' column of system.grants.
+func (g *GrantPrivilege) setDatabaseColumn(database *string) {
+	if g.onNamedCollection() {
+		g.Database = types.StringNull()
+		g.NamedCollection = types.StringPointerValue(database)
+	} else {
+		g.Database = types.StringPointerValue(database)
+		g.NamedCollection = types.StringNull()
+	}
+}
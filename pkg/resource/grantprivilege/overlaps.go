@@ -5,44 +5,27 @@ import (
 	"strings"
 
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/privileges"
 )
 
 func overlaps(current GrantPrivilege, existing dbops.GrantPrivilege) bool {
 	// AccessType
 	{
-		if current.Privilege.ValueString() != existing.AccessType {
-			// Check if existing privilege is a group containing current one.
-
-			groups := parseGrants().Groups
-
-			if members := groups[existing.AccessType]; members != nil {
-				found := false
-				for _, m := range members {
-					if m == current.Privilege.ValueString() {
-						found = true
-						break
-					}
-				}
-
-				if !found {
-					// Existing is a group, but current is not a member of the group
-					return false
-				}
-			} else {
-				// existing is not a group
-				return false
-			}
+		// Existing privilege must either be the same as current one or a group containing it.
+		if !privileges.ParseGrants().Includes(existing.AccessType, current.Privilege.ValueString()) {
+			return false
 		}
 	}
 
 	// DatabaseName
 	{
-		if !current.Database.IsNull() && existing.DatabaseName != nil && current.Database.ValueString() != *existing.DatabaseName {
+		database := current.databaseColumn()
+		if !database.IsNull() && existing.DatabaseName != nil && database.ValueString() != *existing.DatabaseName {
 			// DatabaseName is different, but it can still be overlapping if using wildcards.
-			if strings.HasSuffix(current.Database.ValueString(), "*") {
+			if strings.HasSuffix(database.ValueString(), "*") {
 				if strings.HasSuffix(*existing.DatabaseName, "*") {
 					// Both DatabaseNames end with a wildcard.
-					if !strings.HasPrefix(current.Database.ValueString(), strings.TrimSuffix(*existing.DatabaseName, "*")) {
+					if !strings.HasPrefix(database.ValueString(), strings.TrimSuffix(*existing.DatabaseName, "*")) {
 						return false
 					}
 				} else {
@@ -56,7 +39,7 @@ func overlaps(current GrantPrivilege, existing dbops.GrantPrivilege) bool {
 					return false
 				}
 			}
-		} else if current.Database.IsNull() && existing.DatabaseName != nil {
+		} else if database.IsNull() && existing.DatabaseName != nil {
 			return false
 		}
 	}
@@ -132,14 +115,22 @@ func explainOverlap(current GrantPrivilege, existing dbops.GrantPrivilege) strin
 		row = fmt.Sprintf("- Privilege %q is already granted", existing.AccessType)
 	}
 
-	if existing.TableName != nil {
-		row = fmt.Sprintf("%s on table %q", row, *existing.TableName)
+	if current.onNamedCollection() {
+		if existing.DatabaseName != nil {
+			row = fmt.Sprintf("%s on named collection %q", row, *existing.DatabaseName)
+		} else {
+			row = fmt.Sprintf("%s on all named collections", row)
+		}
 	} else {
-		row = fmt.Sprintf("%s on all tables", row)
-	}
+		if existing.TableName != nil {
+			row = fmt.Sprintf("%s on table %q", row, *existing.TableName)
+		} else {
+			row = fmt.Sprintf("%s on all tables", row)
+		}
 
-	if existing.DatabaseName != nil {
-		row = fmt.Sprintf("%s in the %q database", row, *existing.DatabaseName)
+		if existing.DatabaseName != nil {
+			row = fmt.Sprintf("%s in the %q database", row, *existing.DatabaseName)
+		}
 	}
 
 	if existing.GranteeUserName != nil {
@@ -76,6 +76,57 @@ func Test_overlaps(t *testing.T) {
 			},
 			want: false,
 		},
+
+		// NamedCollection
+		{
+			name: "NamedCollection: Same collection",
+			current: GrantPrivilege{
+				Privilege:       types.StringValue("NAMED COLLECTION"),
+				NamedCollection: types.StringValue("s3_data"),
+			},
+			existing: dbops.GrantPrivilege{
+				AccessType:   "NAMED COLLECTION",
+				DatabaseName: toStrPtr("s3_data"),
+			},
+			want: true,
+		},
+		{
+			name: "NamedCollection: Different collection",
+			current: GrantPrivilege{
+				Privilege:       types.StringValue("NAMED COLLECTION"),
+				NamedCollection: types.StringValue("s3_data"),
+			},
+			existing: dbops.GrantPrivilege{
+				AccessType:   "NAMED COLLECTION",
+				DatabaseName: toStrPtr("kafka"),
+			},
+			want: false,
+		},
+		{
+			name: "NamedCollection: existing is on all collections, current is set",
+			current: GrantPrivilege{
+				Privilege:       types.StringValue("NAMED COLLECTION"),
+				NamedCollection: types.StringValue("s3_data"),
+			},
+			existing: dbops.GrantPrivilege{
+				AccessType:   "NAMED COLLECTION",
+				DatabaseName: nil,
+			},
+			want: true,
+		},
+		{
+			name: "NamedCollection: existing is set, current is on all collections",
+			current: GrantPrivilege{
+				Privilege:       types.StringValue("NAMED COLLECTION"),
+				NamedCollection: types.StringNull(),
+			},
+			existing: dbops.GrantPrivilege{
+				AccessType:   "NAMED COLLECTION",
+				DatabaseName: toStrPtr("s3_data"),
+			},
+			want: false,
+		},
+
 		// TableName
 		{
 			name: "Table: Same value no wildcards",
@@ -15,7 +15,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
 )
 
 //go:embed grantrole.md
@@ -98,6 +100,8 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 }
 
 func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationPlan)
+
 	if req.Plan.Raw.IsNull() {
 		// If the entire plan is null, the resource is planned for destruction.
 		return
@@ -141,6 +145,8 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
 	var plan GrantRole
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -164,6 +170,11 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	state := GrantRole{
 		ClusterName:     plan.ClusterName,
 		RoleName:        types.StringValue(createdGrant.RoleName),
@@ -180,6 +191,8 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 }
 
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
 	var state GrantRole
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -214,6 +227,8 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 }
 
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
 	var state GrantRole
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
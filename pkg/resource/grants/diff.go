@@ -0,0 +1,154 @@
+package grants
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/privileges"
+)
+
+// covers returns true if `broader` being granted implies `narrower` is granted as well.
+func covers(broader dbops.GrantPrivilege, narrower dbops.GrantPrivilege) bool {
+	if !privileges.ParseGrants().Includes(broader.AccessType, narrower.AccessType) {
+		return false
+	}
+
+	if !coversName(broader.DatabaseName, narrower.DatabaseName) {
+		return false
+	}
+
+	if !coversName(broader.TableName, narrower.TableName) {
+		return false
+	}
+
+	// Columns do not support wildcards.
+	if broader.ColumnName != nil && (narrower.ColumnName == nil || *broader.ColumnName != *narrower.ColumnName) {
+		return false
+	}
+
+	// A privilege granted with grant option covers the same privilege granted without it, but not the other way around.
+	if narrower.GrantOption && !broader.GrantOption {
+		return false
+	}
+
+	return true
+}
+
+// coversName returns true if the database or table name `broader` includes `narrower`.
+// Nil means all names, while a trailing '*' is a prefix wildcard.
+func coversName(broader *string, narrower *string) bool {
+	if broader == nil {
+		return true
+	}
+
+	if narrower == nil {
+		return false
+	}
+
+	if *broader == *narrower {
+		return true
+	}
+
+	if strings.HasSuffix(*broader, "*") {
+		return strings.HasPrefix(*narrower, strings.TrimSuffix(*broader, "*"))
+	}
+
+	return false
+}
+
+// restricts returns true if the partial revoke `revoke` takes away part of the single privilege `grant`.
+func restricts(revoke dbops.GrantPrivilege, grant dbops.GrantPrivilege) bool {
+	// When only the grant option was revoked, the privilege itself is still granted.
+	if revoke.GrantOption && !grant.GrantOption {
+		return false
+	}
+
+	if !privileges.ParseGrants().Includes(revoke.AccessType, grant.AccessType) {
+		return false
+	}
+
+	if !overlapsName(revoke.DatabaseName, grant.DatabaseName) || !overlapsName(revoke.TableName, grant.TableName) {
+		return false
+	}
+
+	return revoke.ColumnName == nil || grant.ColumnName == nil || *revoke.ColumnName == *grant.ColumnName
+}
+
+// overlapsName returns true if some database or table is included in both `a` and `b`.
+func overlapsName(a *string, b *string) bool {
+	return coversName(a, b) || coversName(b, a)
+}
+
+// coveredBy returns true if every privilege included in `grant` is covered by at least one item in `set`, and no
+// partial revoke in `set` takes part of it away.
+// Groups are expanded, so a group granted as a whole is covered by a set listing all its members one by one.
+func coveredBy(grant dbops.GrantPrivilege, set []dbops.GrantPrivilege) bool {
+	for _, leaf := range privileges.ParseGrants().Leaves(grant.AccessType) {
+		g := grant
+		g.AccessType = leaf
+
+		found := false
+		for _, s := range set {
+			if s.PartialRevoke {
+				if restricts(s, g) {
+					return false
+				}
+				continue
+			}
+
+			if covers(s, g) {
+				found = true
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equivalent returns true if the two sets of privileges grant exactly the same permissions, even if expressed differently.
+func equivalent(a []dbops.GrantPrivilege, b []dbops.GrantPrivilege) bool {
+	for _, g := range a {
+		if !coveredBy(g, b) {
+			return false
+		}
+	}
+
+	for _, g := range b {
+		if !g.PartialRevoke && !coveredBy(g, a) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dedupe removes duplicated privileges, as returned when querying system.grants on a cluster.
+func dedupe(grants []dbops.GrantPrivilege) []dbops.GrantPrivilege {
+	ret := make([]dbops.GrantPrivilege, 0)
+	seen := make(map[string]bool)
+
+	for _, g := range grants {
+		key := fmt.Sprintf("%s|%s|%s|%s|%t|%t", g.AccessType, ptrString(g.DatabaseName), ptrString(g.TableName), ptrString(g.ColumnName), g.GrantOption, g.PartialRevoke)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		ret = append(ret, g)
+	}
+
+	return ret
+}
+
+func ptrString(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+
+	return *s
+}
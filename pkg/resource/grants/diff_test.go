@@ -0,0 +1,278 @@
+package grants
+
+import (
+	"testing"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+)
+
+func Test_covers(t *testing.T) {
+	tests := []struct {
+		name     string
+		broader  dbops.GrantPrivilege
+		narrower dbops.GrantPrivilege
+		want     bool
+	}{
+		{
+			name:     "Same privilege",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+			want:     true,
+		},
+		{
+			name:     "Group covers member",
+			broader:  dbops.GrantPrivilege{AccessType: "ALL", DatabaseName: toStrPtr("db")},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+			want:     true,
+		},
+		{
+			name:     "Group covers alias of nested member",
+			broader:  dbops.GrantPrivilege{AccessType: "ALTER TABLE", DatabaseName: toStrPtr("db")},
+			narrower: dbops.GrantPrivilege{AccessType: "UPDATE", DatabaseName: toStrPtr("db")},
+			want:     true,
+		},
+		{
+			name:     "Member does not cover group",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+			narrower: dbops.GrantPrivilege{AccessType: "ALL", DatabaseName: toStrPtr("db")},
+			want:     false,
+		},
+		{
+			name:     "All databases covers single database",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT"},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+			want:     true,
+		},
+		{
+			name:     "Single database does not cover all databases",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT"},
+			want:     false,
+		},
+		{
+			name:     "Different databases",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db1")},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db2")},
+			want:     false,
+		},
+		{
+			name:     "Database wildcard covers matching database",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db*")},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db1")},
+			want:     true,
+		},
+		{
+			name:     "Database covers its tables",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db"), TableName: toStrPtr("t")},
+			want:     true,
+		},
+		{
+			name:     "Table covers its columns",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db"), TableName: toStrPtr("t")},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db"), TableName: toStrPtr("t"), ColumnName: toStrPtr("c")},
+			want:     true,
+		},
+		{
+			name:     "Column does not cover whole table",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db"), TableName: toStrPtr("t"), ColumnName: toStrPtr("c")},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db"), TableName: toStrPtr("t")},
+			want:     false,
+		},
+		{
+			name:     "Grant option covers no grant option",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT", GrantOption: true},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT"},
+			want:     true,
+		},
+		{
+			name:     "No grant option does not cover grant option",
+			broader:  dbops.GrantPrivilege{AccessType: "SELECT"},
+			narrower: dbops.GrantPrivilege{AccessType: "SELECT", GrantOption: true},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := covers(tt.broader, tt.narrower); got != tt.want {
+				t.Errorf("covers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_equivalent(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []dbops.GrantPrivilege
+		b    []dbops.GrantPrivilege
+		want bool
+	}{
+		{
+			name: "Both empty",
+			a:    []dbops.GrantPrivilege{},
+			b:    []dbops.GrantPrivilege{},
+			want: true,
+		},
+		{
+			name: "Member absorbed by group",
+			a: []dbops.GrantPrivilege{
+				{AccessType: "ALL", DatabaseName: toStrPtr("db")},
+				{AccessType: "SELECT", DatabaseName: toStrPtr("db"), TableName: toStrPtr("t")},
+			},
+			b: []dbops.GrantPrivilege{
+				{AccessType: "ALL", DatabaseName: toStrPtr("db")},
+			},
+			want: true,
+		},
+		{
+			name: "All members collapsed into group",
+			a: []dbops.GrantPrivilege{
+				{AccessType: "SHOW DATABASES"},
+				{AccessType: "SHOW TABLES"},
+				{AccessType: "SHOW COLUMNS"},
+				{AccessType: "SHOW DICTIONARIES"},
+			},
+			b: []dbops.GrantPrivilege{
+				{AccessType: "SHOW"},
+			},
+			want: true,
+		},
+		{
+			name: "Alias",
+			a: []dbops.GrantPrivilege{
+				{AccessType: "UPDATE", DatabaseName: toStrPtr("db")},
+			},
+			b: []dbops.GrantPrivilege{
+				{AccessType: "ALTER UPDATE", DatabaseName: toStrPtr("db")},
+			},
+			want: true,
+		},
+		{
+			name: "Extra privilege",
+			a: []dbops.GrantPrivilege{
+				{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+			},
+			b: []dbops.GrantPrivilege{
+				{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+				{AccessType: "INSERT", DatabaseName: toStrPtr("db")},
+			},
+			want: false,
+		},
+		{
+			name: "Missing group member",
+			a: []dbops.GrantPrivilege{
+				{AccessType: "SHOW DATABASES"},
+				{AccessType: "SHOW TABLES"},
+			},
+			b: []dbops.GrantPrivilege{
+				{AccessType: "SHOW"},
+			},
+			want: false,
+		},
+		{
+			name: "Different grant option",
+			a: []dbops.GrantPrivilege{
+				{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+			},
+			b: []dbops.GrantPrivilege{
+				{AccessType: "SELECT", DatabaseName: toStrPtr("db"), GrantOption: true},
+			},
+			want: false,
+		},
+		{
+			name: "Partial revoke",
+			a: []dbops.GrantPrivilege{
+				{AccessType: "SELECT"},
+			},
+			b: []dbops.GrantPrivilege{
+				{AccessType: "SELECT"},
+				{AccessType: "SELECT", DatabaseName: toStrPtr("secret"), PartialRevoke: true},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := equivalent(tt.a, tt.b); got != tt.want {
+				t.Errorf("equivalent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_coveredBy(t *testing.T) {
+	tests := []struct {
+		name  string
+		grant dbops.GrantPrivilege
+		set   []dbops.GrantPrivilege
+		want  bool
+	}{
+		{
+			name:  "Partial revoke within the privilege",
+			grant: dbops.GrantPrivilege{AccessType: "SELECT"},
+			set: []dbops.GrantPrivilege{
+				{AccessType: "SELECT"},
+				{AccessType: "SELECT", DatabaseName: toStrPtr("secret"), PartialRevoke: true},
+			},
+			want: false,
+		},
+		{
+			name:  "Partial revoke of a group member",
+			grant: dbops.GrantPrivilege{AccessType: "ALL", DatabaseName: toStrPtr("db")},
+			set: []dbops.GrantPrivilege{
+				{AccessType: "ALL"},
+				{AccessType: "INSERT", DatabaseName: toStrPtr("db"), TableName: toStrPtr("t"), PartialRevoke: true},
+			},
+			want: false,
+		},
+		{
+			name:  "Partial revoke on another database",
+			grant: dbops.GrantPrivilege{AccessType: "SELECT", DatabaseName: toStrPtr("db")},
+			set: []dbops.GrantPrivilege{
+				{AccessType: "SELECT"},
+				{AccessType: "SELECT", DatabaseName: toStrPtr("secret"), PartialRevoke: true},
+			},
+			want: true,
+		},
+		{
+			name:  "Partial revoke of another privilege",
+			grant: dbops.GrantPrivilege{AccessType: "SELECT"},
+			set: []dbops.GrantPrivilege{
+				{AccessType: "SELECT"},
+				{AccessType: "INSERT"},
+				{AccessType: "INSERT", DatabaseName: toStrPtr("secret"), PartialRevoke: true},
+			},
+			want: true,
+		},
+		{
+			name:  "Only grant option revoked",
+			grant: dbops.GrantPrivilege{AccessType: "SELECT"},
+			set: []dbops.GrantPrivilege{
+				{AccessType: "SELECT", GrantOption: true},
+				{AccessType: "SELECT", DatabaseName: toStrPtr("secret"), GrantOption: true, PartialRevoke: true},
+			},
+			want: true,
+		},
+		{
+			name:  "Grant option revoked from privilege granted with grant option",
+			grant: dbops.GrantPrivilege{AccessType: "SELECT", GrantOption: true},
+			set: []dbops.GrantPrivilege{
+				{AccessType: "SELECT", GrantOption: true},
+				{AccessType: "SELECT", DatabaseName: toStrPtr("secret"), GrantOption: true, PartialRevoke: true},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := coveredBy(tt.grant, tt.set); got != tt.want {
+				t.Errorf("coveredBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func toStrPtr(s string) *string {
+	return &s
+}
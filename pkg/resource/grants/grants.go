@@ -0,0 +1,457 @@
+package grants
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/privileges"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed grants.md
+var grantsDescription string
+
+var (
+	_ resource.Resource                   = &Resource{}
+	_ resource.ResourceWithConfigure      = &Resource{}
+	_ resource.ResourceWithModifyPlan     = &Resource{}
+	_ resource.ResourceWithValidateConfig = &Resource{}
+)
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client dbops.Client
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grants"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	validPrivileges := make([]string, 0)
+
+	upstrGrts := privileges.ParseGrants()
+
+	for privilege := range upstrGrts.Scopes {
+		validPrivileges = append(validPrivileges, privilege)
+	}
+
+	for alias := range upstrGrts.Aliases {
+		validPrivileges = append(validPrivileges, alias)
+	}
+
+	for groupName := range upstrGrts.Groups {
+		validPrivileges = append(validPrivileges, groupName)
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to create the resource into. If omitted, resource will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\nWhen using a self hosted ClickHouse instance, this field should only be set when there is more than one replica and you are not using 'replicated' storage for user_directory.\n",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"grantee_user_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the `user` whose privileges are managed by this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.Expressions{
+						path.MatchRoot("grantee_user_name"),
+						path.MatchRoot("grantee_role_name"),
+					}...),
+				},
+			},
+			"grantee_role_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the `role` whose privileges are managed by this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"privileges": schema.SetNestedAttribute{
+				Required:    true,
+				Description: "The complete set of privileges granted to the grantee. Any other privilege granted to the grantee will be revoked.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"privilege_name": schema.StringAttribute{
+							Required:    true,
+							Description: "The privilege to grant, such as `CREATE DATABASE`, `SELECT`, etc. See https://clickhouse.com/docs/en/sql-reference/statements/grant#privileges.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(validPrivileges...),
+							},
+						},
+						"database_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "The name of the database to grant privilege on. Defaults to all databases if left null",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+								stringvalidator.NoneOf("*"),
+							},
+						},
+						"table_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "The name of the table to grant privilege on.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+								stringvalidator.NoneOf("*"),
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("database_name")),
+							},
+						},
+						"column_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "The name of the column in `table_name` to grant privilege on.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("table_name")),
+							},
+						},
+						"named_collection_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "The name of the named collection to grant privilege on, for privileges such as `NAMED COLLECTION`. Defaults to all named collections if left null",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+								stringvalidator.NoneOf("*"),
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("database_name")),
+							},
+						},
+						"grant_option": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "If true, the grantee will be able to grant the same privilege to others.",
+						},
+					},
+				},
+			},
+		},
+		MarkdownDescription: grantsDescription,
+	}
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(dbops.Client)
+}
+
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var privilegesSet types.Set
+	diags := req.Config.GetAttribute(ctx, path.Root("privileges"), &privilegesSet)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || privilegesSet.IsNull() || privilegesSet.IsUnknown() {
+		return
+	}
+
+	for _, e := range privilegesSet.Elements() {
+		if e.IsUnknown() {
+			return
+		}
+	}
+
+	var configured []Privilege
+	diags = privilegesSet.ElementsAs(ctx, &configured, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upstrGrts := privileges.ParseGrants()
+
+	// Check required fields which depend on the grant's scope.
+	for _, p := range configured {
+		if p.Privilege.IsUnknown() || p.Database.IsUnknown() {
+			continue
+		}
+
+		privilegeName := upstrGrts.Canonical(p.Privilege.ValueString())
+		scope := upstrGrts.Scopes[privilegeName]
+
+		if scope != "NAMED_COLLECTION" && !p.NamedCollection.IsNull() && !p.NamedCollection.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("privileges"),
+				"Invalid Grant Privilege",
+				fmt.Sprintf("'named_collection_name' must be null when 'privilege_name' is %q", p.Privilege.ValueString()),
+			)
+		}
+
+		switch scope {
+		case "GLOBAL":
+			if !p.Database.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("privileges"),
+					"Invalid Grant Privilege",
+					fmt.Sprintf("'database_name' must be null when 'privilege_name' is %q", p.Privilege.ValueString()),
+				)
+			}
+		case "COLUMN", "DICTIONARY", "VIEW":
+			if p.Database.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("privileges"),
+					"Invalid Grant Privilege",
+					fmt.Sprintf("'database_name' must be set when 'privilege_name' is %q", p.Privilege.ValueString()),
+				)
+			}
+		case "NAMED_COLLECTION":
+			if !p.Database.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("privileges"),
+					"Invalid Grant Privilege",
+					fmt.Sprintf("'database_name' must be null when 'privilege_name' is %q, use 'named_collection_name' instead", p.Privilege.ValueString()),
+				)
+			}
+		case "USER_NAME", "TABLE ENGINE":
+			resp.Diagnostics.AddAttributeError(
+				path.Root("privileges"),
+				"Unsupported Privilege",
+				fmt.Sprintf("%q privilege_name is currently unsupported", p.Privilege.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationPlan)
+
+	if req.Plan.Raw.IsNull() {
+		// If the entire plan is null, the resource is planned for destruction.
+		return
+	}
+
+	var clusterName types.String
+	diags := req.Config.GetAttribute(ctx, path.Root("cluster_name"), &clusterName)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client != nil {
+		isReplicatedStorage, err := r.client.IsReplicatedStorage(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Checking if service is using replicated storage",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+
+		if isReplicatedStorage {
+			// Grants cannot specify 'cluster_name' or apply will fail.
+			if !clusterName.IsNull() {
+				resp.Diagnostics.AddWarning(
+					"Invalid configuration",
+					"Your ClickHouse cluster is using Replicated storage for grants, please remove the 'cluster_name' attribute from your Grants resource definition if you encounter any errors.",
+				)
+			}
+		}
+	}
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
+	var plan Grants
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.sync(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Grants",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
+	var state Grants
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := r.client.GetAllGrantsForGrantee(ctx, state.GranteeUserName.ValueStringPointer(), state.GranteeRoleName.ValueStringPointer(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse Grants",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	// Privileges in the state are kept as they are unless they differ from the actual ones,
+	// so that groups, aliases and overlapping privileges are not reported as drift.
+	if !equivalent(toGrantPrivileges(state), existing) {
+		state.Privileges = make([]Privilege, 0)
+		for _, e := range dedupe(existing) {
+			// Privileges partially revoked are left out, so that the next apply grants them again as a whole, which
+			// removes the partial revokes.
+			if e.PartialRevoke || !coveredBy(e, existing) {
+				continue
+			}
+
+			p := Privilege{
+				Privilege:   types.StringValue(e.AccessType),
+				Table:       types.StringPointerValue(e.TableName),
+				Column:      types.StringPointerValue(e.ColumnName),
+				GrantOption: types.BoolValue(e.GrantOption),
+			}
+			p.setDatabaseColumn(e.DatabaseName)
+			state.Privileges = append(state.Privileges, p)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationUpdate)
+
+	var plan Grants
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.sync(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating ClickHouse Grants",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
+	var state Grants
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, g := range toGrantPrivileges(state) {
+		err := r.client.RevokeGrantPrivilege(ctx, g.AccessType, g.DatabaseName, g.TableName, g.ColumnName, g.GranteeUserName, g.GranteeRoleName, state.ClusterName.ValueStringPointer())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting ClickHouse Grants",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+	}
+}
+
+// sync issues the GRANT and REVOKE statements needed to make the grantee's privileges match the given ones.
+func (r *Resource) sync(ctx context.Context, desired Grants) error {
+	clusterName := desired.ClusterName.ValueStringPointer()
+	wanted := toGrantPrivileges(desired)
+
+	existing, err := r.client.GetAllGrantsForGrantee(ctx, desired.GranteeUserName.ValueStringPointer(), desired.GranteeRoleName.ValueStringPointer(), clusterName)
+	if err != nil {
+		return err
+	}
+
+	// Revoke first, as revoking a group also revokes all of its members.
+	revoked := false
+	for _, e := range dedupe(existing) {
+		if e.PartialRevoke || coveredBy(e, wanted) {
+			continue
+		}
+
+		// When only the grant option is not wanted anymore, it is revoked alone so that the privilege is never lost.
+		withoutOption := e
+		withoutOption.GrantOption = false
+		if e.GrantOption && coveredBy(withoutOption, wanted) {
+			err = r.client.RevokeGrantOption(ctx, e.AccessType, e.DatabaseName, e.TableName, e.ColumnName, e.GranteeUserName, e.GranteeRoleName, clusterName)
+		} else {
+			err = r.client.RevokeGrantPrivilege(ctx, e.AccessType, e.DatabaseName, e.TableName, e.ColumnName, e.GranteeUserName, e.GranteeRoleName, clusterName)
+		}
+		if err != nil {
+			return err
+		}
+		revoked = true
+	}
+
+	if revoked {
+		existing, err = r.client.GetAllGrantsForGrantee(ctx, desired.GranteeUserName.ValueStringPointer(), desired.GranteeRoleName.ValueStringPointer(), clusterName)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Granting a privilege also removes the partial revokes within it. A privilege only missing the grant option is
+	// granted again WITH GRANT OPTION, which adds the option to it.
+	for _, w := range wanted {
+		if !coveredBy(w, existing) {
+			_, err = r.client.GrantPrivilege(ctx, w, clusterName)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func toGrantPrivileges(grants Grants) []dbops.GrantPrivilege {
+	ret := make([]dbops.GrantPrivilege, 0)
+
+	for _, p := range grants.Privileges {
+		ret = append(ret, dbops.GrantPrivilege{
+			AccessType:      p.Privilege.ValueString(),
+			DatabaseName:    p.databaseColumn().ValueStringPointer(),
+			TableName:       p.Table.ValueStringPointer(),
+			ColumnName:      p.Column.ValueStringPointer(),
+			GranteeUserName: grants.GranteeUserName.ValueStringPointer(),
+			GranteeRoleName: grants.GranteeRoleName.ValueStringPointer(),
+			GrantOption:     p.GrantOption.ValueBool(),
+		})
+	}
+
+	return ret
+}
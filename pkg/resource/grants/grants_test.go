@@ -0,0 +1,160 @@
+package grants_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	resourceType = "clickhousedbops_grants"
+	resourceName = "foo"
+
+	granteeRoleName = "grantee"
+)
+
+func TestGrants_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		granteeRole := attrs["grantee_role_name"]
+		if granteeRole == "" {
+			return false, fmt.Errorf("grantee_role_name attribute was not set")
+		}
+
+		grants, err := dbopsClient.GetAllGrantsForGrantee(ctx, nil, &granteeRole, clusterName)
+		return len(grants) > 0, err
+	}
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		granteeRole, ok := attrs["grantee_role_name"].(string)
+		if !ok {
+			return fmt.Errorf("grantee_role_name attribute was not set")
+		}
+
+		grants, err := dbopsClient.GetAllGrantsForGrantee(ctx, nil, &granteeRole, clusterName)
+		if err != nil {
+			return err
+		}
+
+		// INSERT on the default database is included in ALL and is not expected to be returned.
+		expected := map[string]string{
+			"SELECT": "system",
+			"ALL":    "default",
+		}
+
+		for _, g := range grants {
+			database, ok := expected[g.AccessType]
+			if !ok {
+				return fmt.Errorf("unexpected privilege %q granted", g.AccessType)
+			}
+
+			if g.DatabaseName == nil || *g.DatabaseName != database {
+				return fmt.Errorf("expected privilege %q to be granted on database %q", g.AccessType, database)
+			}
+		}
+
+		if len(grants) == 0 {
+			return fmt.Errorf("no privileges were granted")
+		}
+
+		return nil
+	}
+
+	privileges := []cty.Value{
+		cty.ObjectVal(map[string]cty.Value{
+			"privilege_name": cty.StringVal("SELECT"),
+			"database_name":  cty.StringVal("system"),
+		}),
+		cty.ObjectVal(map[string]cty.Value{
+			"privilege_name": cty.StringVal("ALL"),
+			"database_name":  cty.StringVal("default"),
+		}),
+		cty.ObjectVal(map[string]cty.Value{
+			"privilege_name": cty.StringVal("INSERT"),
+			"database_name":  cty.StringVal("default"),
+		}),
+	}
+
+	granteeRoleResource := resourcebuilder.
+		New("clickhousedbops_role", granteeRoleName).
+		WithStringAttribute("name", granteeRoleName)
+	clusterGranteeRoleResource := resourcebuilder.
+		New("clickhousedbops_role", granteeRoleName).
+		WithStringAttribute("name", granteeRoleName).
+		WithStringAttribute("cluster_name", clusterName)
+
+	tests := []runner.TestCase{
+		{
+			Name:     "Grant privileges to role using Native protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "native",
+			Resource: resourcebuilder.New(resourceType, resourceName).
+				WithResourceFieldReference("grantee_role_name", "clickhousedbops_role", granteeRoleName, "name").
+				WithListAttribute("privileges", privileges).
+				AddDependency(granteeRoleResource.Build()).
+				Build(),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:     "Grant privileges to role using HTTP protocol on a single replica",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol: "http",
+			Resource: resourcebuilder.New(resourceType, resourceName).
+				WithResourceFieldReference("grantee_role_name", "clickhousedbops_role", granteeRoleName, "name").
+				WithListAttribute("privileges", privileges).
+				AddDependency(granteeRoleResource.Build()).
+				Build(),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:     "Grant privileges to role using Native protocol on a cluster using replicated storage",
+			ChEnv:    map[string]string{"CONFIGFILE": "config-replicated.xml"},
+			Protocol: "native",
+			Resource: resourcebuilder.New(resourceType, resourceName).
+				WithResourceFieldReference("grantee_role_name", "clickhousedbops_role", granteeRoleName, "name").
+				WithListAttribute("privileges", privileges).
+				AddDependency(granteeRoleResource.Build()).
+				Build(),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:        "Grant privileges to role using HTTP protocol on a cluster using localfile storage",
+			ChEnv:       map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName: &clusterName,
+			Protocol:    "http",
+			Resource: resourcebuilder.New(resourceType, resourceName).
+				WithStringAttribute("cluster_name", clusterName).
+				WithResourceFieldReference("grantee_role_name", "clickhousedbops_role", granteeRoleName, "name").
+				WithListAttribute("privileges", privileges).
+				AddDependency(clusterGranteeRoleResource.Build()).
+				Build(),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
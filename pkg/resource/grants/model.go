@@ -0,0 +1,49 @@
+package grants
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/privileges"
+)
+
+type Grants struct {
+	ClusterName     types.String `tfsdk:"cluster_name"`
+	GranteeUserName types.String `tfsdk:"grantee_user_name"`
+	GranteeRoleName types.String `tfsdk:"grantee_role_name"`
+	Privileges      []Privilege  `tfsdk:"privileges"`
+}
+
+type Privilege struct {
+	Privilege       types.String `tfsdk:"privilege_name"`
+	Database        types.String `tfsdk:"database_name"`
+	Table           types.String `tfsdk:"table_name"`
+	Column          types.String `tfsdk:"column_name"`
+	NamedCollection types.String `tfsdk:"named_collection_name"`
+	GrantOption     types.Bool   `tfsdk:"grant_option"`
+}
+
+// onNamedCollection tells if the privilege is granted on named collections rather than on databases and tables.
+func (p *Privilege) onNamedCollection() bool {
+	upstrGrts := privileges.ParseGrants()
+	return upstrGrts.Scopes[upstrGrts.Canonical(p.Privilege.ValueString())] == "NAMED_COLLECTION"
+}
+
+// databaseColumn returns the value of the 'database' column of system.grants for this privilege, that is the name of
+// the named collection for privileges granted on named collections.
+func (p *Privilege) databaseColumn() types.String {
+	if p.onNamedCollection() {
+		return p.NamedCollection
+	}
+	return p.Database
+}
+
+// setDatabaseColumn sets either Database or NamedCollection from the 'database' column of system.grants.
+func (p *Privilege) setDatabaseColumn(database *string) {
+	if p.onNamedCollection() {
+		p.Database = types.StringNull()
+		p.NamedCollection = types.StringPointerValue(database)
+	} else {
+		p.Database = types.StringPointerValue(database)
+		p.NamedCollection = types.StringNull()
+	}
+}
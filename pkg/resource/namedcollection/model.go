@@ -0,0 +1,107 @@
+package namedcollection
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type NamedCollection struct {
+	ClusterName     types.String `tfsdk:"cluster_name"`
+	Name            types.String `tfsdk:"name"`
+	Values          types.Map    `tfsdk:"values"`
+	SensitiveValues types.Map    `tfsdk:"sensitive_values"`
+}
+
+// allValues returns the values and the sensitive values of the named collection merged together.
+func (m *NamedCollection) allValues(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make(map[string]string)
+	for _, attr := range []types.Map{m.Values, m.SensitiveValues} {
+		if attr.IsNull() {
+			continue
+		}
+
+		elements := make(map[string]string)
+		diags.Append(attr.ElementsAs(ctx, &elements, false)...)
+		for key, value := range elements {
+			values[key] = value
+		}
+	}
+
+	return values, diags
+}
+
+// syncValues aligns the state with the keys and values found in ClickHouse. Missing keys are removed, so they are set
+// again on the next apply, and the values ClickHouse shows are updated, so that changes made outside of Terraform are
+// reverted on the next apply. Unknown keys are added to Values, so they are removed on the next apply, with an empty
+// value when ClickHouse hides it.
+// When importing, there are no values to compare with: unknown keys whose value is hidden are left out, so that the
+// next apply sets them to the configured values.
+func (m *NamedCollection) syncValues(ctx context.Context, keys []string, values map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	importing := m.Values.IsNull() && m.SensitiveValues.IsNull()
+
+	found := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		found[key] = true
+	}
+
+	known := make(map[string]bool)
+	sync := func(attr types.Map) types.Map {
+		if attr.IsNull() {
+			return attr
+		}
+
+		elements := make(map[string]string)
+		diags.Append(attr.ElementsAs(ctx, &elements, false)...)
+		for key := range elements {
+			known[key] = true
+			if !found[key] {
+				delete(elements, key)
+			} else if value, ok := values[key]; ok {
+				elements[key] = value
+			}
+		}
+
+		synced, d := types.MapValueFrom(ctx, types.StringType, elements)
+		diags.Append(d...)
+		return synced
+	}
+
+	m.Values = sync(m.Values)
+	m.SensitiveValues = sync(m.SensitiveValues)
+
+	unknown := make(map[string]string)
+	for _, key := range keys {
+		if known[key] {
+			continue
+		}
+		if value, ok := values[key]; ok {
+			unknown[key] = value
+		} else if !importing {
+			unknown[key] = ""
+		}
+	}
+
+	if len(unknown) == 0 {
+		return diags
+	}
+
+	elements := make(map[string]string)
+	if !m.Values.IsNull() {
+		diags.Append(m.Values.ElementsAs(ctx, &elements, false)...)
+	}
+	for key, value := range unknown {
+		elements[key] = value
+	}
+
+	synced, d := types.MapValueFrom(ctx, types.StringType, elements)
+	diags.Append(d...)
+	m.Values = synced
+
+	return diags
+}
@@ -0,0 +1,84 @@
+package namedcollection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_syncValues(t *testing.T) {
+	mapValue := func(elements map[string]string) types.Map {
+		if elements == nil {
+			return types.MapNull(types.StringType)
+		}
+		value, _ := types.MapValueFrom(context.Background(), types.StringType, elements)
+		return value
+	}
+
+	tests := []struct {
+		name            string
+		values          map[string]string
+		sensitiveValues map[string]string
+		keys            []string
+		found           map[string]string
+		wantValues      map[string]string
+		wantSensitive   map[string]string
+	}{
+		{
+			name:            "Hidden values are kept",
+			values:          map[string]string{"url": "https://example.com"},
+			sensitiveValues: map[string]string{"password": "secret"},
+			keys:            []string{"password", "url"},
+			found:           map[string]string{},
+			wantValues:      map[string]string{"url": "https://example.com"},
+			wantSensitive:   map[string]string{"password": "secret"},
+		},
+		{
+			name:            "Shown values are updated",
+			values:          map[string]string{"url": "https://example.com"},
+			sensitiveValues: map[string]string{"password": "secret"},
+			keys:            []string{"password", "url"},
+			found:           map[string]string{"password": "changed", "url": "https://example.org"},
+			wantValues:      map[string]string{"url": "https://example.org"},
+			wantSensitive:   map[string]string{"password": "changed"},
+		},
+		{
+			name:       "Missing keys are removed and unknown keys are added",
+			values:     map[string]string{"url": "https://example.com"},
+			keys:       []string{"format", "user"},
+			found:      map[string]string{"format": "CSV"},
+			wantValues: map[string]string{"format": "CSV", "user": ""},
+		},
+		{
+			name:       "Import leaves hidden values out",
+			keys:       []string{"format", "password"},
+			found:      map[string]string{"format": "CSV"},
+			wantValues: map[string]string{"format": "CSV"},
+		},
+		{
+			name:  "Import with all values hidden",
+			keys:  []string{"password"},
+			found: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &NamedCollection{
+				Values:          mapValue(tt.values),
+				SensitiveValues: mapValue(tt.sensitiveValues),
+			}
+
+			if diags := m.syncValues(context.Background(), tt.keys, tt.found); diags.HasError() {
+				t.Fatalf("syncValues() diags = %v", diags)
+			}
+
+			if want := mapValue(tt.wantValues); !m.Values.Equal(want) {
+				t.Errorf("syncValues() Values = %v, want %v", m.Values, want)
+			}
+			if want := mapValue(tt.wantSensitive); !m.SensitiveValues.Equal(want) {
+				t.Errorf("syncValues() SensitiveValues = %v, want %v", m.SensitiveValues, want)
+			}
+		})
+	}
+}
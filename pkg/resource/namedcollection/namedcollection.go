@@ -0,0 +1,326 @@
+package namedcollection
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed namedcollection.md
+var namedCollectionResourceDescription string
+
+var (
+	_ resource.Resource                   = &Resource{}
+	_ resource.ResourceWithConfigure      = &Resource{}
+	_ resource.ResourceWithImportState    = &Resource{}
+	_ resource.ResourceWithValidateConfig = &Resource{}
+)
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client dbops.Client
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_named_collection"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	valuesValidators := func(other string) []validator.Map {
+		return []validator.Map{
+			mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+			mapvalidator.AtLeastOneOf(path.MatchRoot(other)),
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to create the resource into. If omitted, resource will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster or when named collections are stored in ZooKeeper.\nWhen set, every replica is checked on refresh and the resource is planned for creation again if it is missing on any of them. Replicas where it is defined differently than on the others are reported in a warning.\n",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the named collection",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"values": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Values of the named collection, by key, which are shown in the plan.",
+				Validators:  valuesValidators("sensitive_values"),
+			},
+			"sensitive_values": schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "Values of the named collection, by key, which are hidden in the plan, such as passwords and access keys. A key cannot be in both `values` and `sensitive_values`.",
+				Validators:  valuesValidators("values"),
+			},
+		},
+		MarkdownDescription: namedCollectionResourceDescription,
+	}
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(dbops.Client)
+}
+
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config NamedCollection
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Values.IsUnknown() || config.SensitiveValues.IsUnknown() {
+		return
+	}
+
+	for key := range config.SensitiveValues.Elements() {
+		if _, ok := config.Values.Elements()[key]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sensitive_values").AtMapKey(key),
+				"Duplicate Key",
+				fmt.Sprintf("Key %q is set in both 'values' and 'sensitive_values', please keep it in only one of them.", key),
+			)
+		}
+	}
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
+	var plan NamedCollection
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	values, diags := plan.allValues(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namedCollection, err := r.client.CreateNamedCollection(ctx, dbops.NamedCollection{
+		Name:   plan.Name.ValueString(),
+		Values: values,
+	}, plan.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Named Collection",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	if namedCollection == nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Named Collection",
+			"The named collection was not found after being created",
+		)
+		return
+	}
+
+	// Values are kept as configured, ClickHouse may hide them.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
+	var state NamedCollection
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namedCollection, err := r.client.GetNamedCollection(ctx, state.Name.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse Named Collection",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if namedCollection != nil && !state.ClusterName.IsNull() {
+		drift, err := r.client.FindReplicaDrift(ctx, dbops.NamedCollectionsTable, namedCollection.Name, state.ClusterName.ValueString())
+		if resourceutil.ReportReplicaDrift(ctx, resp, "Error Reading ClickHouse Named Collection", fmt.Sprintf("Named collection %q", namedCollection.Name), state.ClusterName.ValueString(), drift, err) {
+			return
+		}
+	}
+
+	if namedCollection == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(state.syncValues(ctx, namedCollection.Keys, namedCollection.Values)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationUpdate)
+
+	var plan, state NamedCollection
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := plan.allValues(ctx)
+	resp.Diagnostics.Append(diags...)
+	current, diags := state.allValues(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := make(map[string]string)
+	for key, value := range planned {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			set[key] = value
+		}
+	}
+
+	remove := make([]string, 0)
+	for key := range current {
+		if _, ok := planned[key]; !ok {
+			remove = append(remove, key)
+		}
+	}
+
+	namedCollection, err := r.client.UpdateNamedCollection(ctx, plan.Name.ValueString(), set, remove, plan.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating ClickHouse Named Collection",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	if namedCollection == nil {
+		resp.Diagnostics.AddError(
+			"Error Updating ClickHouse Named Collection",
+			"The named collection was not found after being updated",
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
+	var state NamedCollection
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteNamedCollection(ctx, state.Name.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting ClickHouse Named Collection",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationImport)
+
+	// req.ID can either be in the form <cluster name>:<named collection name> or just <named collection name>
+
+	// Check if cluster name is specified
+	name := req.ID
+	var clusterName *string
+	if strings.Contains(req.ID, ":") {
+		clusterName = &strings.Split(req.ID, ":")[0]
+		name = strings.Split(req.ID, ":")[1]
+	}
+
+	namedCollection, err := r.client.GetNamedCollection(ctx, name, clusterName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cannot find named collection",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if namedCollection == nil {
+		resp.Diagnostics.AddError(
+			"Cannot find named collection",
+			fmt.Sprintf("No named collection called %q was found", name),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), namedCollection.Name)...)
+
+	if clusterName != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), clusterName)...)
+	}
+}
@@ -0,0 +1,138 @@
+package namedcollection_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	resourceType = "clickhousedbops_named_collection"
+	resourceName = "foo"
+)
+
+func TestNamedCollection_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		name := attrs["name"]
+		if name == "" {
+			return false, fmt.Errorf("name attribute was not set")
+		}
+		namedCollection, err := dbopsClient.GetNamedCollection(ctx, name, clusterName)
+		return namedCollection != nil, err
+	}
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		name := attrs["name"]
+		if name == nil {
+			return fmt.Errorf("name was nil")
+		}
+
+		namedCollection, err := dbopsClient.GetNamedCollection(ctx, name.(string), clusterName)
+		if err != nil {
+			return err
+		}
+
+		if namedCollection == nil {
+			return fmt.Errorf("named collection %q was not found", name)
+		}
+
+		// Check the keys in state are the ones found in ClickHouse, values are not shown.
+		keys := make([]string, 0)
+		for _, attr := range []string{"values", "sensitive_values"} {
+			if attrs[attr] == nil {
+				continue
+			}
+			for key := range attrs[attr].(map[string]interface{}) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		if fmt.Sprint(keys) != fmt.Sprint(namedCollection.Keys) {
+			return fmt.Errorf("expected keys to be %v, was %v", namedCollection.Keys, keys)
+		}
+
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		return nil
+	}
+
+	buildResource := func(clusterName *string) string {
+		namedCollection := resourcebuilder.New(resourceType, resourceName).
+			WithStringAttribute("name", "nc"+acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)).
+			WithMapAttribute("values", map[string]cty.Value{
+				"url":    cty.StringVal("https://bucket.s3.amazonaws.com/data/"),
+				"format": cty.StringVal("Parquet"),
+			}).
+			WithMapAttribute("sensitive_values", map[string]cty.Value{
+				"access_key_id":     cty.StringVal("AKIAEXAMPLE"),
+				"secret_access_key": cty.StringVal("it's a secret"),
+			})
+
+		if clusterName != nil {
+			namedCollection = namedCollection.WithStringAttribute("cluster_name", *clusterName)
+		}
+
+		return namedCollection.Build()
+	}
+
+	tests := []runner.TestCase{
+		{
+			Name:                "Create Named Collection using Native protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "native",
+			Resource:            buildResource(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Named Collection using HTTP protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "http",
+			Resource:            buildResource(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Named Collection using Native protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "native",
+			Resource:            buildResource(&clusterName),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Named Collection using HTTP protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "http",
+			Resource:            buildResource(&clusterName),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
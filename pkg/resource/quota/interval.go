@@ -0,0 +1,143 @@
+package quota
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+)
+
+// units lists the units of quota intervals from the longest, with their length in seconds as computed by ClickHouse.
+var units = []struct {
+	name    string
+	seconds uint64
+}{
+	{name: "year", seconds: 31556952},
+	{name: "quarter", seconds: 7889238},
+	{name: "month", seconds: 2629746},
+	{name: "week", seconds: 604800},
+	{name: "day", seconds: 86400},
+	{name: "hour", seconds: 3600},
+	{name: "minute", seconds: 60},
+	{name: "second", seconds: 1},
+}
+
+func unitNames() []string {
+	ret := make([]string, 0, len(units))
+	for _, u := range units {
+		ret = append(ret, u.name)
+	}
+
+	return ret
+}
+
+// durationSeconds returns the length of the interval in seconds.
+func (i Interval) durationSeconds() uint64 {
+	for _, u := range units {
+		if u.name == i.Unit.ValueString() {
+			return uint64(i.Duration.ValueInt64()) * u.seconds
+		}
+	}
+
+	return 0
+}
+
+func (i Interval) toDBOps() dbops.QuotaInterval {
+	return dbops.QuotaInterval{
+		DurationSeconds:  i.durationSeconds(),
+		Randomized:       i.Randomized.ValueBool(),
+		MaxQueries:       uint64Pointer(i.MaxQueries),
+		MaxQuerySelects:  uint64Pointer(i.MaxQuerySelects),
+		MaxQueryInserts:  uint64Pointer(i.MaxQueryInserts),
+		MaxErrors:        uint64Pointer(i.MaxErrors),
+		MaxResultRows:    uint64Pointer(i.MaxResultRows),
+		MaxResultBytes:   uint64Pointer(i.MaxResultBytes),
+		MaxReadRows:      uint64Pointer(i.MaxReadRows),
+		MaxReadBytes:     uint64Pointer(i.MaxReadBytes),
+		MaxExecutionTime: i.MaxExecutionTime.ValueFloat64Pointer(),
+	}
+}
+
+func intervalsToDBOps(intervals []Interval) []dbops.QuotaInterval {
+	ret := make([]dbops.QuotaInterval, 0, len(intervals))
+	for _, i := range intervals {
+		ret = append(ret, i.toDBOps())
+	}
+
+	return ret
+}
+
+// intervalsFromDBOps converts the intervals read from ClickHouse, which only knows their length in seconds.
+// Intervals as long as one in current keep its position, duration and unit, so that a configuration using a
+// different unit than the one picked here does not show a difference. Other intervals are appended using the longest
+// unit their length is a multiple of.
+func intervalsFromDBOps(intervals []dbops.QuotaInterval, current []Interval) []Interval {
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	bySeconds := make(map[uint64]dbops.QuotaInterval, len(intervals))
+	for _, i := range intervals {
+		bySeconds[i.DurationSeconds] = i
+	}
+
+	ret := make([]Interval, 0, len(intervals))
+	for _, c := range current {
+		seconds := c.durationSeconds()
+		i, ok := bySeconds[seconds]
+		if !ok {
+			continue
+		}
+		delete(bySeconds, seconds)
+
+		ret = append(ret, intervalFromDBOps(i, c.Duration, c.Unit))
+	}
+
+	for _, i := range intervals {
+		if _, ok := bySeconds[i.DurationSeconds]; !ok {
+			continue
+		}
+
+		for _, u := range units {
+			if i.DurationSeconds%u.seconds == 0 {
+				ret = append(ret, intervalFromDBOps(i, types.Int64Value(int64(i.DurationSeconds/u.seconds)), types.StringValue(u.name)))
+				break
+			}
+		}
+	}
+
+	return ret
+}
+
+func intervalFromDBOps(i dbops.QuotaInterval, duration types.Int64, unit types.String) Interval {
+	return Interval{
+		Duration:         duration,
+		Unit:             unit,
+		Randomized:       types.BoolValue(i.Randomized),
+		MaxQueries:       int64Value(i.MaxQueries),
+		MaxQuerySelects:  int64Value(i.MaxQuerySelects),
+		MaxQueryInserts:  int64Value(i.MaxQueryInserts),
+		MaxErrors:        int64Value(i.MaxErrors),
+		MaxResultRows:    int64Value(i.MaxResultRows),
+		MaxResultBytes:   int64Value(i.MaxResultBytes),
+		MaxReadRows:      int64Value(i.MaxReadRows),
+		MaxReadBytes:     int64Value(i.MaxReadBytes),
+		MaxExecutionTime: types.Float64PointerValue(i.MaxExecutionTime),
+	}
+}
+
+func uint64Pointer(value types.Int64) *uint64 {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	ret := uint64(value.ValueInt64())
+	return &ret
+}
+
+func int64Value(value *uint64) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(int64(*value))
+}
@@ -0,0 +1,101 @@
+package quota
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+)
+
+func Test_intervalsFromDBOps(t *testing.T) {
+	queries := uint64(100)
+	executionTime := 0.5
+
+	interval := func(duration int64, unit string) Interval {
+		return Interval{
+			Duration:         types.Int64Value(duration),
+			Unit:             types.StringValue(unit),
+			Randomized:       types.BoolValue(false),
+			MaxQueries:       types.Int64Null(),
+			MaxQuerySelects:  types.Int64Null(),
+			MaxQueryInserts:  types.Int64Null(),
+			MaxErrors:        types.Int64Null(),
+			MaxResultRows:    types.Int64Null(),
+			MaxResultBytes:   types.Int64Null(),
+			MaxReadRows:      types.Int64Null(),
+			MaxReadBytes:     types.Int64Null(),
+			MaxExecutionTime: types.Float64Null(),
+		}
+	}
+
+	withLimits := func(duration int64, unit string) Interval {
+		i := interval(duration, unit)
+		i.MaxQueries = types.Int64Value(100)
+		i.MaxExecutionTime = types.Float64Value(0.5)
+		return i
+	}
+
+	tests := []struct {
+		name      string
+		intervals []dbops.QuotaInterval
+		current   []Interval
+		want      []Interval
+	}{
+		{
+			name: "No intervals",
+			want: nil,
+		},
+		{
+			name: "Longest unit is picked",
+			intervals: []dbops.QuotaInterval{
+				{DurationSeconds: 90},
+				{DurationSeconds: 7200},
+				{DurationSeconds: 2629746},
+			},
+			want: []Interval{
+				interval(90, "second"),
+				interval(2, "hour"),
+				interval(1, "month"),
+			},
+		},
+		{
+			name: "Configured units and order are kept",
+			intervals: []dbops.QuotaInterval{
+				{DurationSeconds: 3600, MaxQueries: &queries, MaxExecutionTime: &executionTime},
+				{DurationSeconds: 86400},
+			},
+			current: []Interval{
+				interval(24, "hour"),
+				interval(60, "minute"),
+			},
+			want: []Interval{
+				interval(24, "hour"),
+				withLimits(60, "minute"),
+			},
+		},
+		{
+			name: "Removed intervals are dropped and new ones appended",
+			intervals: []dbops.QuotaInterval{
+				{DurationSeconds: 3600, MaxQueries: &queries, MaxExecutionTime: &executionTime},
+				{DurationSeconds: 604800},
+			},
+			current: []Interval{
+				interval(1, "minute"),
+				interval(1, "hour"),
+			},
+			want: []Interval{
+				withLimits(1, "hour"),
+				interval(1, "week"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := intervalsFromDBOps(tt.intervals, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("intervalsFromDBOps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,486 @@
+package quota
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed quota.md
+var quotaResourceDescription string
+
+var (
+	_ resource.Resource                   = &Resource{}
+	_ resource.ResourceWithConfigure      = &Resource{}
+	_ resource.ResourceWithImportState    = &Resource{}
+	_ resource.ResourceWithModifyPlan     = &Resource{}
+	_ resource.ResourceWithValidateConfig = &Resource{}
+)
+
+// validKeys are the values accepted by KEYED BY, multiple keys are separated by a comma.
+var validKeys = []string{
+	"user_name",
+	"ip_address",
+	"forwarded_ip_address",
+	"client_key",
+	"client_key,user_name",
+	"client_key,ip_address",
+}
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client dbops.Client
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_quota"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	limitAttribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Optional:    true,
+			Description: description + " Not limited if left null.",
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to create the resource into. If omitted, resource will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\nWhen using a self hosted ClickHouse instance, this field should only be set when there is more than one replica and you are not using 'replicated' storage for user_directory.\nWhen set, every replica is checked on refresh and the resource is planned for creation again if it is missing on any of them. Replicas where it is defined differently than on the others are reported in a warning.\n",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The system-assigned ID for the quota",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the quota",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"keyed_by": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("What the consumption is tracked by: one of %s. If null, consumption is tracked once for all the users and roles the quota applies to.", strings.Join(validKeys, ", ")),
+				Validators: []validator.String{
+					stringvalidator.OneOf(validKeys...),
+				},
+			},
+			"intervals": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Periods of time over which the consumption is tracked and limited. Each interval must have a different length.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"duration": schema.Int64Attribute{
+							Required:    true,
+							Description: "Length of the interval, in `unit`.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"unit": schema.StringAttribute{
+							Required:    true,
+							Description: fmt.Sprintf("Unit of `duration`: one of %s.", strings.Join(unitNames(), ", ")),
+							Validators: []validator.String{
+								stringvalidator.OneOf(unitNames()...),
+							},
+						},
+						"randomized": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "If true, the start of the interval is randomized, so that intervals of different users don't all restart at the same time.",
+						},
+						"max_queries":       limitAttribute("Maximum number of queries."),
+						"max_query_selects": limitAttribute("Maximum number of SELECT queries."),
+						"max_query_inserts": limitAttribute("Maximum number of INSERT queries."),
+						"max_errors":        limitAttribute("Maximum number of queries that threw an exception."),
+						"max_result_rows":   limitAttribute("Maximum number of rows returned as results."),
+						"max_result_bytes":  limitAttribute("Maximum number of bytes returned as results."),
+						"max_read_rows":     limitAttribute("Maximum number of rows read from tables."),
+						"max_read_bytes":    limitAttribute("Maximum number of bytes read from tables."),
+						"max_execution_time": schema.Float64Attribute{
+							Optional:    true,
+							Description: "Maximum total query execution time, in seconds. Not limited if left null.",
+							Validators: []validator.Float64{
+								float64validator.AtLeast(0),
+							},
+						},
+					},
+				},
+			},
+			"apply_to": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Names of the `users` and `roles` the quota applies to",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+		MarkdownDescription: quotaResourceDescription,
+	}
+}
+
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config Quota
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ClickHouse identifies intervals by their length, so the same length cannot be used twice.
+	seen := make(map[uint64]bool)
+	for _, i := range config.Intervals {
+		if i.Duration.IsUnknown() || i.Unit.IsUnknown() {
+			continue
+		}
+
+		seconds := i.durationSeconds()
+		if seen[seconds] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("intervals"),
+				"Duplicated Quota Interval",
+				fmt.Sprintf("More than one interval lasts %d %s, intervals must have different lengths", i.Duration.ValueInt64(), i.Unit.ValueString()),
+			)
+		}
+		seen[seconds] = true
+	}
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationPlan)
+
+	if req.Plan.Raw.IsNull() {
+		// If the entire plan is null, the resource is planned for destruction.
+		return
+	}
+
+	if r.client != nil {
+		isReplicatedStorage, err := r.client.IsReplicatedStorage(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Checking if service is using replicated storage",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+
+		if isReplicatedStorage {
+			var config Quota
+			diags := req.Config.Get(ctx, &config)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Quota cannot specify 'cluster_name' or apply will fail.
+			if !config.ClusterName.IsNull() {
+				resp.Diagnostics.AddWarning(
+					"Invalid configuration",
+					"Your ClickHouse cluster is using Replicated storage for quotas, please remove the 'cluster_name' attribute from your Quota resource definition if you encounter any errors.",
+				)
+			}
+		}
+	}
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(dbops.Client)
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
+	var plan Quota
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	quota, diags := toDBOps(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createdQuota, err := r.client.CreateQuota(ctx, quota, plan.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Quota",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	if createdQuota == nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Quota",
+			"The quota was not found after being created",
+		)
+		return
+	}
+
+	state := plan
+	resp.Diagnostics.Append(setFromDBOps(ctx, &state, createdQuota)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
+	var state Quota
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	quota, err := r.client.GetQuota(ctx, state.ID.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse Quota",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if quota != nil && !state.ClusterName.IsNull() {
+		drift, err := r.client.FindReplicaDrift(ctx, dbops.QuotasTable, quota.Name, state.ClusterName.ValueString())
+		if resourceutil.ReportReplicaDrift(ctx, resp, "Error Reading ClickHouse Quota", fmt.Sprintf("Quota %q", quota.Name), state.ClusterName.ValueString(), drift, err) {
+			return
+		}
+	}
+
+	if quota != nil {
+		resp.Diagnostics.Append(setFromDBOps(ctx, &state, quota)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		diags = resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+	} else {
+		resp.State.RemoveResource(ctx)
+	}
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationUpdate)
+
+	var plan, state Quota
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	quota, diags := toDBOps(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	quota.ID = state.ID.ValueString()
+
+	updatedQuota, err := r.client.UpdateQuota(ctx, quota, plan.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating ClickHouse Quota",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	if updatedQuota == nil {
+		resp.Diagnostics.AddError(
+			"Error Updating ClickHouse Quota",
+			"The quota was not found after being updated",
+		)
+		return
+	}
+
+	state = plan
+	resp.Diagnostics.Append(setFromDBOps(ctx, &state, updatedQuota)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
+	var state Quota
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteQuota(ctx, state.ID.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting ClickHouse Quota",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationImport)
+
+	// req.ID can either be in the form <cluster name>:<quota ref> or just <quota ref>
+	// <quota ref> can either be the name or the UUID of the quota.
+
+	// Check if cluster name is specified
+	ref := req.ID
+	var clusterName *string
+	if strings.Contains(req.ID, ":") {
+		clusterName = &strings.Split(req.ID, ":")[0]
+		ref = strings.Split(req.ID, ":")[1]
+	}
+
+	// Check if ref is a UUID
+	_, err := uuid.Parse(ref)
+	if err != nil {
+		// Failed parsing UUID, try importing using the quota name
+		quota, err := r.client.FindQuotaByName(ctx, ref, clusterName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Cannot find quota",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+
+		if quota == nil {
+			resp.Diagnostics.AddError(
+				"Cannot find quota",
+				fmt.Sprintf("No quota named %q was found", ref),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), quota.ID)...)
+	} else {
+		// User passed a UUID
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ref)...)
+	}
+
+	if clusterName != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), clusterName)...)
+	}
+}
+
+func toDBOps(ctx context.Context, model Quota) (dbops.Quota, diag.Diagnostics) {
+	quota := dbops.Quota{
+		Name:      model.Name.ValueString(),
+		Intervals: intervalsToDBOps(model.Intervals),
+	}
+
+	if !model.KeyedBy.IsNull() {
+		quota.KeyedBy = strings.Split(model.KeyedBy.ValueString(), ",")
+	}
+
+	var diags diag.Diagnostics
+	if !model.ApplyTo.IsNull() {
+		diags = model.ApplyTo.ElementsAs(ctx, &quota.ApplyTo, false)
+	}
+
+	return quota, diags
+}
+
+// setFromDBOps updates model with the quota read from ClickHouse.
+func setFromDBOps(ctx context.Context, model *Quota, quota *dbops.Quota) diag.Diagnostics {
+	model.ID = types.StringValue(quota.ID)
+	model.Name = types.StringValue(quota.Name)
+	model.Intervals = intervalsFromDBOps(quota.Intervals, model.Intervals)
+
+	model.KeyedBy = types.StringNull()
+	if len(quota.KeyedBy) > 0 {
+		model.KeyedBy = types.StringValue(strings.Join(quota.KeyedBy, ","))
+	}
+
+	model.ApplyTo = types.SetNull(types.StringType)
+	if len(quota.ApplyTo) > 0 {
+		applyTo, diags := types.SetValueFrom(ctx, types.StringType, quota.ApplyTo)
+		if diags.HasError() {
+			return diags
+		}
+		model.ApplyTo = applyTo
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package quota_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	resourceType = "clickhousedbops_quota"
+	resourceName = "foo"
+
+	roleName = "limited"
+)
+
+func TestQuota_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		id := attrs["id"]
+		if id == "" {
+			return false, fmt.Errorf("id attribute was not set")
+		}
+		quota, err := dbopsClient.GetQuota(ctx, id, clusterName)
+		return quota != nil, err
+	}
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		id := attrs["id"]
+		if id == nil {
+			return fmt.Errorf("id was nil")
+		}
+
+		quota, err := dbopsClient.GetQuota(ctx, id.(string), clusterName)
+		if err != nil {
+			return err
+		}
+
+		if quota == nil {
+			return fmt.Errorf("quota with id %q was not found", id)
+		}
+
+		// Check state fields are aligned with the quota we retrieved from CH.
+		if attrs["name"].(string) != quota.Name {
+			return fmt.Errorf("expected name to be %q, was %q", quota.Name, attrs["name"].(string))
+		}
+		if len(quota.KeyedBy) != 1 || quota.KeyedBy[0] != "user_name" {
+			return fmt.Errorf("expected quota to be keyed by user_name, is keyed by %v", quota.KeyedBy)
+		}
+		if len(quota.ApplyTo) != 1 || quota.ApplyTo[0] != roleName {
+			return fmt.Errorf("expected quota to apply to %q, applies to %v", roleName, quota.ApplyTo)
+		}
+
+		if len(quota.Intervals) != 2 {
+			return fmt.Errorf("expected 2 intervals, got %d", len(quota.Intervals))
+		}
+		hourly := quota.Intervals[0]
+		if hourly.DurationSeconds != 3600 || hourly.MaxQueries == nil || *hourly.MaxQueries != 100 || hourly.MaxExecutionTime == nil || *hourly.MaxExecutionTime != 0.5 {
+			return fmt.Errorf("unexpected hourly interval %+v", hourly)
+		}
+		daily := quota.Intervals[1]
+		if daily.DurationSeconds != 86400 || !daily.Randomized || daily.MaxQueries != nil {
+			return fmt.Errorf("unexpected daily interval %+v", daily)
+		}
+
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		return nil
+	}
+
+	buildResources := func(clusterName *string) string {
+		role := resourcebuilder.New("clickhousedbops_role", roleName).
+			WithStringAttribute("name", roleName)
+		quota := resourcebuilder.New(resourceType, resourceName).
+			WithStringAttribute("name", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)).
+			WithStringAttribute("keyed_by", "user_name").
+			WithListAttribute("intervals", []cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"duration":           cty.NumberIntVal(1),
+					"unit":               cty.StringVal("hour"),
+					"randomized":         cty.False,
+					"max_queries":        cty.NumberIntVal(100),
+					"max_execution_time": cty.NumberFloatVal(0.5),
+				}),
+				cty.ObjectVal(map[string]cty.Value{
+					"duration":           cty.NumberIntVal(24),
+					"unit":               cty.StringVal("hour"),
+					"randomized":         cty.True,
+					"max_queries":        cty.NullVal(cty.Number),
+					"max_execution_time": cty.NullVal(cty.Number),
+				}),
+			}).
+			WithListAttribute("apply_to", []cty.Value{cty.StringVal(roleName)}).
+			WithDependsOn("clickhousedbops_role", roleName)
+
+		if clusterName != nil {
+			role = role.WithStringAttribute("cluster_name", *clusterName)
+			quota = quota.WithStringAttribute("cluster_name", *clusterName)
+		}
+
+		return quota.
+			AddDependency(role.Build()).
+			Build()
+	}
+
+	tests := []runner.TestCase{
+		{
+			Name:                "Create Quota using Native protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "native",
+			Resource:            buildResources(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Quota using HTTP protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "http",
+			Resource:            buildResources(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Quota using Native protocol on a cluster using replicated storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-replicated.xml"},
+			Protocol:            "native",
+			Resource:            buildResources(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Quota using HTTP protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "http",
+			Resource:            buildResources(&clusterName),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
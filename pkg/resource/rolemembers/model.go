@@ -0,0 +1,12 @@
+package rolemembers
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RoleMembers struct {
+	ClusterName types.String `tfsdk:"cluster_name"`
+	RoleName    types.String `tfsdk:"role_name"`
+	UserNames   types.Set    `tfsdk:"user_names"`
+	RoleNames   types.Set    `tfsdk:"role_names"`
+}
@@ -0,0 +1,351 @@
+package rolemembers
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed rolemembers.md
+var roleMembersResourceDescription string
+
+var (
+	_ resource.Resource                = &Resource{}
+	_ resource.ResourceWithConfigure   = &Resource{}
+	_ resource.ResourceWithModifyPlan  = &Resource{}
+	_ resource.ResourceWithImportState = &Resource{}
+)
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client dbops.Client
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_members"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to create the resource into. If omitted, resource will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\nWhen using a self hosted ClickHouse instance, this field should only be set when there is more than one replica and you are not using 'replicated' storage for user_directory.\n",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the role whose members are managed by this resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"user_names": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Names of the `users` to grant `role_name` to. The role is revoked from any other user.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"role_names": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Names of the `roles` to grant `role_name` to. The role is revoked from any other role.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+		MarkdownDescription: roleMembersResourceDescription,
+	}
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationPlan)
+
+	if req.Plan.Raw.IsNull() {
+		// If the entire plan is null, the resource is planned for destruction.
+		return
+	}
+
+	if r.client != nil {
+		isReplicatedStorage, err := r.client.IsReplicatedStorage(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Checking if service is using replicated storage",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+
+		if isReplicatedStorage {
+			var config RoleMembers
+			diags := req.Config.Get(ctx, &config)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// RoleMembers cannot specify 'cluster_name' or apply will fail.
+			if !config.ClusterName.IsNull() {
+				resp.Diagnostics.AddWarning(
+					"Invalid configuration",
+					"Your ClickHouse cluster is using Replicated storage for role grants, please remove the 'cluster_name' attribute from your RoleMembers resource definition if you encounter any errors.",
+				)
+			}
+		}
+	}
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(dbops.Client)
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
+	var plan RoleMembers
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.sync(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Role Members",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
+	var state RoleMembers
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.client.FindRoleByName(ctx, state.RoleName.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse Role Members",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if role == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	users, roles, err := r.getMembers(ctx, state.RoleName.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse Role Members",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	state.UserNames, diags = toSetValue(ctx, state.UserNames, users)
+	resp.Diagnostics.Append(diags...)
+	state.RoleNames, diags = toSetValue(ctx, state.RoleNames, roles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationUpdate)
+
+	var plan RoleMembers
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.sync(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating ClickHouse Role Members",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
+	var state RoleMembers
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Revoke the role from all of its members.
+	state.UserNames = types.SetNull(types.StringType)
+	state.RoleNames = types.SetNull(types.StringType)
+
+	err := r.sync(ctx, state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting ClickHouse Role Members",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationImport)
+
+	// req.ID can either be in the form <cluster name>:<role name> or just <role name>
+
+	// Check if cluster name is specified
+	roleName := req.ID
+	var clusterName *string
+	if strings.Contains(req.ID, ":") {
+		clusterName = &strings.Split(req.ID, ":")[0]
+		roleName = strings.Split(req.ID, ":")[1]
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_name"), roleName)...)
+
+	if clusterName != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), clusterName)...)
+	}
+}
+
+// sync grants and revokes the role so that its members are exactly the ones in `desired`.
+func (r *Resource) sync(ctx context.Context, desired RoleMembers) error {
+	roleName := desired.RoleName.ValueString()
+	clusterName := desired.ClusterName.ValueStringPointer()
+
+	wantedUsers := make([]string, 0)
+	if !desired.UserNames.IsNull() {
+		diags := desired.UserNames.ElementsAs(ctx, &wantedUsers, false)
+		if diags.HasError() {
+			return fmt.Errorf("error reading user_names")
+		}
+	}
+
+	wantedRoles := make([]string, 0)
+	if !desired.RoleNames.IsNull() {
+		diags := desired.RoleNames.ElementsAs(ctx, &wantedRoles, false)
+		if diags.HasError() {
+			return fmt.Errorf("error reading role_names")
+		}
+	}
+
+	users, roles, err := r.getMembers(ctx, roleName, clusterName)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range difference(users, wantedUsers) {
+		err = r.client.RevokeGrantRole(ctx, roleName, &u, nil, clusterName)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, g := range difference(roles, wantedRoles) {
+		err = r.client.RevokeGrantRole(ctx, roleName, nil, &g, clusterName)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, u := range difference(wantedUsers, users) {
+		_, err = r.client.GrantRole(ctx, dbops.GrantRole{RoleName: roleName, GranteeUserName: &u}, clusterName)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, g := range difference(wantedRoles, roles) {
+		_, err = r.client.GrantRole(ctx, dbops.GrantRole{RoleName: roleName, GranteeRoleName: &g}, clusterName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getMembers returns the names of the users and the roles the given role is granted to.
+func (r *Resource) getMembers(ctx context.Context, roleName string, clusterName *string) ([]string, []string, error) {
+	grants, err := r.client.GetAllGrantRolesForRole(ctx, roleName, clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users := make([]string, 0)
+	roles := make([]string, 0)
+
+	// When querying a cluster, the same grant is returned once per replica.
+	seenUsers := make(map[string]bool)
+	seenRoles := make(map[string]bool)
+
+	for _, g := range grants {
+		if g.GranteeUserName != nil && !seenUsers[*g.GranteeUserName] {
+			seenUsers[*g.GranteeUserName] = true
+			users = append(users, *g.GranteeUserName)
+		}
+
+		if g.GranteeRoleName != nil && !seenRoles[*g.GranteeRoleName] {
+			seenRoles[*g.GranteeRoleName] = true
+			roles = append(roles, *g.GranteeRoleName)
+		}
+	}
+
+	return users, roles, nil
+}
@@ -0,0 +1,151 @@
+package rolemembers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	resourceType = "clickhousedbops_role_members"
+	resourceName = "foo"
+
+	roleName       = "role1"
+	memberUserName = "user1"
+	memberRoleName = "role2"
+)
+
+func TestRoleMembers_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		role := attrs["role_name"]
+		if role == "" {
+			return false, fmt.Errorf("role_name attribute was not set")
+		}
+
+		grants, err := dbopsClient.GetAllGrantRolesForRole(ctx, role, clusterName)
+		return len(grants) > 0, err
+	}
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		role, ok := attrs["role_name"].(string)
+		if !ok {
+			return fmt.Errorf("role_name attribute was not set")
+		}
+
+		grants, err := dbopsClient.GetAllGrantRolesForRole(ctx, role, clusterName)
+		if err != nil {
+			return err
+		}
+
+		foundUser, foundRole := false, false
+		for _, g := range grants {
+			switch {
+			case g.GranteeUserName != nil && *g.GranteeUserName == memberUserName:
+				foundUser = true
+			case g.GranteeRoleName != nil && *g.GranteeRoleName == memberRoleName:
+				foundRole = true
+			default:
+				return fmt.Errorf("role %q was granted to an unexpected grantee", role)
+			}
+		}
+
+		if !foundUser {
+			return fmt.Errorf("role %q was not granted to user %q", role, memberUserName)
+		}
+
+		if !foundRole {
+			return fmt.Errorf("role %q was not granted to role %q", role, memberRoleName)
+		}
+
+		return nil
+	}
+
+	buildResources := func(clusterName *string) string {
+		role := resourcebuilder.New("clickhousedbops_role", roleName).
+			WithStringAttribute("name", roleName)
+		memberRole := resourcebuilder.New("clickhousedbops_role", memberRoleName).
+			WithStringAttribute("name", memberRoleName)
+		memberUser := resourcebuilder.New("clickhousedbops_user", memberUserName).
+			WithStringAttribute("name", memberUserName).
+			WithFunction("password_sha256_hash_wo", "sha256", "test").
+			WithIntAttribute("password_sha256_hash_wo_version", 1)
+		roleMembers := resourcebuilder.New(resourceType, resourceName).
+			WithResourceFieldReference("role_name", "clickhousedbops_role", roleName, "name").
+			WithListAttribute("user_names", []cty.Value{cty.StringVal(memberUserName)}).
+			WithListAttribute("role_names", []cty.Value{cty.StringVal(memberRoleName)}).
+			WithDependsOn("clickhousedbops_user", memberUserName).
+			WithDependsOn("clickhousedbops_role", memberRoleName)
+
+		if clusterName != nil {
+			role = role.WithStringAttribute("cluster_name", *clusterName)
+			memberRole = memberRole.WithStringAttribute("cluster_name", *clusterName)
+			memberUser = memberUser.WithStringAttribute("cluster_name", *clusterName)
+			roleMembers = roleMembers.WithStringAttribute("cluster_name", *clusterName)
+		}
+
+		return roleMembers.
+			AddDependency(role.Build()).
+			AddDependency(memberRole.Build()).
+			AddDependency(memberUser.Build()).
+			Build()
+	}
+
+	tests := []runner.TestCase{
+		{
+			Name:                "Set role members using Native protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "native",
+			Resource:            buildResources(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Set role members using HTTP protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "http",
+			Resource:            buildResources(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Set role members using Native protocol on a cluster using replicated storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-replicated.xml"},
+			Protocol:            "native",
+			Resource:            buildResources(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Set role members using HTTP protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "http",
+			Resource:            buildResources(&clusterName),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
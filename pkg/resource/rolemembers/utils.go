@@ -0,0 +1,34 @@
+package rolemembers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// difference returns the items in `a` that are not in `b`.
+func difference(a []string, b []string) []string {
+	inB := make(map[string]bool)
+	for _, s := range b {
+		inB[s] = true
+	}
+
+	ret := make([]string, 0)
+	for _, s := range a {
+		if !inB[s] {
+			ret = append(ret, s)
+		}
+	}
+
+	return ret
+}
+
+// toSetValue turns the given names into a set, preserving a null `current` value when there are no names.
+func toSetValue(ctx context.Context, current types.Set, names []string) (types.Set, diag.Diagnostics) {
+	if len(names) == 0 && current.IsNull() {
+		return current, nil
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, names)
+}
@@ -0,0 +1,47 @@
+package rolemembers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_difference(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want []string
+	}{
+		{
+			name: "Both empty",
+			a:    []string{},
+			b:    []string{},
+			want: []string{},
+		},
+		{
+			name: "Nothing in common",
+			a:    []string{"alice", "bob"},
+			b:    []string{"carol"},
+			want: []string{"alice", "bob"},
+		},
+		{
+			name: "Some in common",
+			a:    []string{"alice", "bob"},
+			b:    []string{"bob", "carol"},
+			want: []string{"alice"},
+		},
+		{
+			name: "All in common",
+			a:    []string{"alice", "bob"},
+			b:    []string{"bob", "alice"},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := difference(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("difference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package rowpolicy
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RowPolicy struct {
+	ClusterName  types.String `tfsdk:"cluster_name"`
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	TableName    types.String `tfsdk:"table_name"`
+	SelectFilter types.String `tfsdk:"select_filter"`
+	Restrictive  types.Bool   `tfsdk:"restrictive"`
+	ApplyTo      types.Set    `tfsdk:"apply_to"`
+}
@@ -0,0 +1,425 @@
+package rowpolicy
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed rowpolicy.md
+var rowPolicyResourceDescription string
+
+var (
+	_ resource.Resource                = &Resource{}
+	_ resource.ResourceWithConfigure   = &Resource{}
+	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.ResourceWithModifyPlan  = &Resource{}
+)
+
+// selectFilterKey is the private state key holding the filter as formatted by ClickHouse after the last apply.
+const selectFilterKey = "select_filter"
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client dbops.Client
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_row_policy"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to create the resource into. If omitted, resource will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\nWhen using a self hosted ClickHouse instance, this field should only be set when there is more than one replica and you are not using 'replicated' storage for user_directory.\nWhen set, every replica is checked on refresh and the resource is planned for creation again if it is missing on any of them. Replicas where it is defined differently than on the others are reported in a warning.\n",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The system-assigned ID for the row policy",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the row policy, unique for each table",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"database_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the database of the table the row policy applies to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"table_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the table the row policy applies to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"select_filter": schema.StringAttribute{
+				Required:    true,
+				Description: "SQL expression rows must satisfy to be returned by SELECT queries, for example `tenant_id = 1`",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"restrictive": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "If true, the policy is restrictive and rows must satisfy it in addition to the permissive policies. Permissive policies are combined with OR, restrictive ones with AND.",
+			},
+			"apply_to": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Names of the `users` and `roles` the row policy applies to",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+		MarkdownDescription: rowPolicyResourceDescription,
+	}
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationPlan)
+
+	if req.Plan.Raw.IsNull() {
+		// If the entire plan is null, the resource is planned for destruction.
+		return
+	}
+
+	if r.client != nil {
+		isReplicatedStorage, err := r.client.IsReplicatedStorage(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Checking if service is using replicated storage",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+
+		if isReplicatedStorage {
+			var config RowPolicy
+			diags := req.Config.Get(ctx, &config)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Row policy cannot specify 'cluster_name' or apply will fail.
+			if !config.ClusterName.IsNull() {
+				resp.Diagnostics.AddWarning(
+					"Invalid configuration",
+					"Your ClickHouse cluster is using Replicated storage for row policies, please remove the 'cluster_name' attribute from your Row Policy resource definition if you encounter any errors.",
+				)
+			}
+		}
+	}
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(dbops.Client)
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
+	var plan RowPolicy
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applyTo := make([]string, 0)
+	resp.Diagnostics.Append(plan.ApplyTo.ElementsAs(ctx, &applyTo, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rowPolicy, err := r.client.CreateRowPolicy(ctx, dbops.RowPolicy{
+		Name:         plan.Name.ValueString(),
+		Database:     plan.DatabaseName.ValueString(),
+		Table:        plan.TableName.ValueString(),
+		SelectFilter: plan.SelectFilter.ValueString(),
+		Restrictive:  plan.Restrictive.ValueBool(),
+		ApplyTo:      applyTo,
+	}, plan.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Row Policy",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	if rowPolicy == nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Row Policy",
+			"The row policy was not found after being created",
+		)
+		return
+	}
+
+	// The filter is kept as configured, ClickHouse stores it reformatted.
+	state := plan
+	state.ID = types.StringValue(rowPolicy.ID)
+
+	resp.Diagnostics.Append(resourceutil.SetApplied(ctx, resp.Private, selectFilterKey, rowPolicy.SelectFilter)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
+	var state RowPolicy
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rowPolicy, err := r.client.GetRowPolicy(ctx, state.ID.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse Row Policy",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if rowPolicy != nil && !state.ClusterName.IsNull() {
+		drift, err := r.client.FindReplicaDrift(ctx, dbops.RowPoliciesTable, rowPolicy.FullName, state.ClusterName.ValueString())
+		if resourceutil.ReportReplicaDrift(ctx, resp, "Error Reading ClickHouse Row Policy", fmt.Sprintf("Row policy %q", rowPolicy.FullName), state.ClusterName.ValueString(), drift, err) {
+			return
+		}
+	}
+
+	if rowPolicy == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Only report the filter read from ClickHouse if it changed since the last apply, as the configured one might be
+	// formatted differently.
+	applied, diags := resourceutil.GetApplied[string](ctx, req.Private, selectFilterKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if applied == nil || *applied != rowPolicy.SelectFilter {
+		state.SelectFilter = types.StringValue(rowPolicy.SelectFilter)
+	}
+
+	applyTo, diags := types.SetValueFrom(ctx, types.StringType, rowPolicy.ApplyTo)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Name = types.StringValue(rowPolicy.Name)
+	state.DatabaseName = types.StringValue(rowPolicy.Database)
+	state.TableName = types.StringValue(rowPolicy.Table)
+	state.Restrictive = types.BoolValue(rowPolicy.Restrictive)
+	state.ApplyTo = applyTo
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationUpdate)
+
+	var plan, state RowPolicy
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applyTo := make([]string, 0)
+	resp.Diagnostics.Append(plan.ApplyTo.ElementsAs(ctx, &applyTo, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The filter is only sent when changed in the configuration, comparing it with the one stored in ClickHouse
+	// would always find a difference when it is formatted differently.
+	selectFilter := plan.SelectFilter.ValueString()
+	if plan.SelectFilter.Equal(state.SelectFilter) {
+		applied, diags := resourceutil.GetApplied[string](ctx, req.Private, selectFilterKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if applied != nil {
+			selectFilter = *applied
+		}
+	}
+
+	rowPolicy, err := r.client.UpdateRowPolicy(ctx, dbops.RowPolicy{
+		ID:           state.ID.ValueString(),
+		Name:         plan.Name.ValueString(),
+		SelectFilter: selectFilter,
+		Restrictive:  plan.Restrictive.ValueBool(),
+		ApplyTo:      applyTo,
+	}, plan.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating ClickHouse Row Policy",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	if rowPolicy == nil {
+		resp.Diagnostics.AddError(
+			"Error Updating ClickHouse Row Policy",
+			"The row policy was not found after being updated",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resourceutil.SetApplied(ctx, resp.Private, selectFilterKey, rowPolicy.SelectFilter)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+	state.ID = types.StringValue(rowPolicy.ID)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
+	var state RowPolicy
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRowPolicy(ctx, state.ID.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting ClickHouse Row Policy",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationImport)
+
+	// req.ID can either be in the form <cluster name>:<row policy ref> or just <row policy ref>
+	// <row policy ref> can either be the full name (like `name ON db.table`) or the UUID of the row policy.
+
+	// Check if cluster name is specified
+	ref := req.ID
+	var clusterName *string
+	if strings.Contains(req.ID, ":") {
+		clusterName = &strings.Split(req.ID, ":")[0]
+		ref = strings.Split(req.ID, ":")[1]
+	}
+
+	// Check if ref is a UUID
+	_, err := uuid.Parse(ref)
+	if err != nil {
+		// Failed parsing UUID, try importing using the full name
+		rowPolicy, err := r.client.FindRowPolicyByName(ctx, ref, clusterName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Cannot find row policy",
+				fmt.Sprintf("%+v\n", err),
+			)
+			return
+		}
+
+		if rowPolicy == nil {
+			resp.Diagnostics.AddError(
+				"Cannot find row policy",
+				fmt.Sprintf("No row policy named %q was found, use the `name ON database.table` format", ref),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), rowPolicy.ID)...)
+	} else {
+		// User passed a UUID
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ref)...)
+	}
+
+	if clusterName != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), clusterName)...)
+	}
+}
@@ -0,0 +1,142 @@
+package rowpolicy_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	resourceType = "clickhousedbops_row_policy"
+	resourceName = "foo"
+
+	roleName = "reader"
+)
+
+func TestRowPolicy_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		id := attrs["id"]
+		if id == "" {
+			return false, fmt.Errorf("id attribute was not set")
+		}
+		rowPolicy, err := dbopsClient.GetRowPolicy(ctx, id, clusterName)
+		return rowPolicy != nil, err
+	}
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		id := attrs["id"]
+		if id == nil {
+			return fmt.Errorf("id was nil")
+		}
+
+		rowPolicy, err := dbopsClient.GetRowPolicy(ctx, id.(string), clusterName)
+		if err != nil {
+			return err
+		}
+
+		if rowPolicy == nil {
+			return fmt.Errorf("row policy with id %q was not found", id)
+		}
+
+		// Check state fields are aligned with the row policy we retrieved from CH.
+		if attrs["name"].(string) != rowPolicy.Name {
+			return fmt.Errorf("expected name to be %q, was %q", rowPolicy.Name, attrs["name"].(string))
+		}
+		if attrs["database_name"].(string) != rowPolicy.Database {
+			return fmt.Errorf("expected database_name to be %q, was %q", rowPolicy.Database, attrs["database_name"].(string))
+		}
+		if attrs["table_name"].(string) != rowPolicy.Table {
+			return fmt.Errorf("expected table_name to be %q, was %q", rowPolicy.Table, attrs["table_name"].(string))
+		}
+		if attrs["restrictive"].(bool) != rowPolicy.Restrictive {
+			return fmt.Errorf("expected restrictive to be %t, was %t", rowPolicy.Restrictive, attrs["restrictive"].(bool))
+		}
+		if len(rowPolicy.ApplyTo) != 1 || rowPolicy.ApplyTo[0] != roleName {
+			return fmt.Errorf("expected row policy to apply to %q, applies to %v", roleName, rowPolicy.ApplyTo)
+		}
+
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		return nil
+	}
+
+	buildResources := func(clusterName *string, restrictive bool) string {
+		role := resourcebuilder.New("clickhousedbops_role", roleName).
+			WithStringAttribute("name", roleName)
+		rowPolicy := resourcebuilder.New(resourceType, resourceName).
+			WithStringAttribute("name", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)).
+			WithStringAttribute("database_name", "default").
+			WithStringAttribute("table_name", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)).
+			WithStringAttribute("select_filter", "tenant_id=1").
+			WithBoolAttribute("restrictive", restrictive).
+			WithListAttribute("apply_to", []cty.Value{cty.StringVal(roleName)}).
+			WithDependsOn("clickhousedbops_role", roleName)
+
+		if clusterName != nil {
+			role = role.WithStringAttribute("cluster_name", *clusterName)
+			rowPolicy = rowPolicy.WithStringAttribute("cluster_name", *clusterName)
+		}
+
+		return rowPolicy.
+			AddDependency(role.Build()).
+			Build()
+	}
+
+	tests := []runner.TestCase{
+		{
+			Name:                "Create Row Policy using Native protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "native",
+			Resource:            buildResources(nil, false),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create restrictive Row Policy using HTTP protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "http",
+			Resource:            buildResources(nil, true),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Row Policy using Native protocol on a cluster using replicated storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-replicated.xml"},
+			Protocol:            "native",
+			Resource:            buildResources(nil, false),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Row Policy using HTTP protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "http",
+			Resource:            buildResources(&clusterName, false),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
@@ -0,0 +1,106 @@
+package table
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+)
+
+func (c Column) toDBOps() dbops.TableColumn {
+	return dbops.TableColumn{
+		Name:         c.Name.ValueString(),
+		PreviousName: c.PreviousName.ValueStringPointer(),
+		Type:         c.Type.ValueString(),
+		Default:      c.Default.ValueStringPointer(),
+		Codec:        c.Codec.ValueStringPointer(),
+		Comment:      c.Comment.ValueStringPointer(),
+	}
+}
+
+func columnsToDBOps(columns []Column) []dbops.TableColumn {
+	ret := make([]dbops.TableColumn, 0, len(columns))
+	for _, c := range columns {
+		ret = append(ret, c.toDBOps())
+	}
+
+	return ret
+}
+
+// columnsFromDBOps converts the columns read from ClickHouse, keeping their order.
+// ClickHouse formats types, defaults and codecs, so the values of the columns in current are kept as long as
+// ClickHouse still holds what it held after they were applied, as listed in applied. Columns missing from current
+// or applied use the values read from ClickHouse. The previous names, unknown to ClickHouse, are kept from current.
+func columnsFromDBOps(columns []dbops.TableColumn, current []Column, applied []dbops.TableColumn) []Column {
+	currentByName := make(map[string]Column, len(current))
+	for _, c := range current {
+		currentByName[c.Name.ValueString()] = c
+	}
+	appliedByName := make(map[string]dbops.TableColumn, len(applied))
+	for _, c := range applied {
+		appliedByName[c.Name] = c
+	}
+
+	ret := make([]Column, 0, len(columns))
+	for _, found := range columns {
+		column := Column{
+			Name:         types.StringValue(found.Name),
+			PreviousName: types.StringNull(),
+			Type:         types.StringValue(found.Type),
+			Default:      types.StringPointerValue(found.Default),
+			Codec:        types.StringPointerValue(found.Codec),
+			Comment:      types.StringPointerValue(found.Comment),
+		}
+
+		c, inCurrent := currentByName[found.Name]
+		if inCurrent {
+			column.PreviousName = c.PreviousName
+		}
+		a, inApplied := appliedByName[found.Name]
+		if inCurrent && inApplied {
+			column.Type = changedValue(c.Type, &found.Type, &a.Type)
+			column.Default = changedValue(c.Default, found.Default, a.Default)
+			column.Codec = changedValue(c.Codec, found.Codec, a.Codec)
+		}
+
+		ret = append(ret, column)
+	}
+
+	return ret
+}
+
+// droppedColumns returns the names of the columns in current that are neither kept nor renamed in planned, in order.
+// A planned column is renamed when its previous name is in current and its name is not, like when updating the table.
+func droppedColumns(current []Column, planned []Column) []string {
+	currentNames := make(map[string]bool, len(current))
+	for _, c := range current {
+		currentNames[c.Name.ValueString()] = true
+	}
+
+	kept := make(map[string]bool, len(planned))
+	for _, c := range planned {
+		if currentNames[c.Name.ValueString()] {
+			kept[c.Name.ValueString()] = true
+		} else if !c.PreviousName.IsNull() && currentNames[c.PreviousName.ValueString()] {
+			kept[c.PreviousName.ValueString()] = true
+		}
+	}
+
+	dropped := make([]string, 0)
+	for _, c := range current {
+		if !kept[c.Name.ValueString()] {
+			dropped = append(dropped, c.Name.ValueString())
+		}
+	}
+
+	return dropped
+}
+
+// changedValue returns found if it differs from applied, the value read from ClickHouse after the last apply, or
+// current if it doesn't.
+func changedValue(current types.String, found *string, applied *string) types.String {
+	if found == nil && applied == nil || found != nil && applied != nil && *found == *applied {
+		return current
+	}
+
+	return types.StringPointerValue(found)
+}
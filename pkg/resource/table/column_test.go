@@ -0,0 +1,152 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+)
+
+func Test_columnsFromDBOps(t *testing.T) {
+	strptr := func(s string) *string {
+		return &s
+	}
+
+	column := func(name string, columnType string, defaultExpression *string) Column {
+		return Column{
+			Name:    types.StringValue(name),
+			Type:    types.StringValue(columnType),
+			Default: types.StringPointerValue(defaultExpression),
+			Codec:   types.StringNull(),
+			Comment: types.StringNull(),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		columns []dbops.TableColumn
+		current []Column
+		applied []dbops.TableColumn
+		want    []Column
+	}{
+		{
+			name: "Unknown applied columns",
+			columns: []dbops.TableColumn{
+				{Name: "id", Type: "UInt64"},
+				{Name: "ts", Type: "DateTime", Default: strptr("now()")},
+			},
+			want: []Column{
+				column("id", "UInt64", nil),
+				column("ts", "DateTime", strptr("now()")),
+			},
+		},
+		{
+			name: "Configured formatting is kept",
+			columns: []dbops.TableColumn{
+				{Name: "id", Type: "UInt64"},
+				{Name: "ts", Type: "DateTime", Default: strptr("now()")},
+			},
+			current: []Column{
+				column("id", "UInt64", nil),
+				column("ts", "DateTime", strptr("NOW( )")),
+			},
+			applied: []dbops.TableColumn{
+				{Name: "id", Type: "UInt64"},
+				{Name: "ts", Type: "DateTime", Default: strptr("now()")},
+			},
+			want: []Column{
+				column("id", "UInt64", nil),
+				column("ts", "DateTime", strptr("NOW( )")),
+			},
+		},
+		{
+			name: "Changes are detected and order follows ClickHouse",
+			columns: []dbops.TableColumn{
+				{Name: "ts", Type: "DateTime64(3)", Default: strptr("now()")},
+				{Name: "id", Type: "UInt64"},
+				{Name: "v", Type: "String"},
+			},
+			current: []Column{
+				column("id", "UInt64", nil),
+				column("ts", "DateTime", strptr("NOW( )")),
+				column("x", "String", nil),
+			},
+			applied: []dbops.TableColumn{
+				{Name: "id", Type: "UInt64"},
+				{Name: "ts", Type: "DateTime", Default: strptr("now()")},
+				{Name: "x", Type: "String"},
+			},
+			want: []Column{
+				column("ts", "DateTime64(3)", strptr("NOW( )")),
+				column("id", "UInt64", nil),
+				column("v", "String", nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := columnsFromDBOps(tt.columns, tt.current, tt.applied); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("columnsFromDBOps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_droppedColumns(t *testing.T) {
+	column := func(name string, previousName *string) Column {
+		return Column{
+			Name:         types.StringValue(name),
+			PreviousName: types.StringPointerValue(previousName),
+		}
+	}
+	strptr := func(s string) *string {
+		return &s
+	}
+
+	tests := []struct {
+		name    string
+		current []Column
+		planned []Column
+		want    []string
+	}{
+		{
+			name:    "Kept and added columns",
+			current: []Column{column("id", nil), column("ts", nil)},
+			planned: []Column{column("ts", nil), column("id", nil), column("v", nil)},
+			want:    []string{},
+		},
+		{
+			name:    "Removed column",
+			current: []Column{column("id", nil), column("ts", nil), column("v", nil)},
+			planned: []Column{column("id", nil)},
+			want:    []string{"ts", "v"},
+		},
+		{
+			name:    "Renamed column",
+			current: []Column{column("id", nil), column("ts", nil)},
+			planned: []Column{column("id", nil), column("created_at", strptr("ts"))},
+			want:    []string{},
+		},
+		{
+			name:    "Previous name of an already renamed column",
+			current: []Column{column("id", nil), column("created_at", strptr("ts"))},
+			planned: []Column{column("id", nil), column("created_at", strptr("ts"))},
+			want:    []string{},
+		},
+		{
+			name:    "Previous name missing from the table",
+			current: []Column{column("id", nil), column("ts", nil)},
+			planned: []Column{column("id", nil), column("created_at", strptr("time"))},
+			want:    []string{"ts"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := droppedColumns(tt.current, tt.planned); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("droppedColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
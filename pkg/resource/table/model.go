@@ -0,0 +1,28 @@
+package table
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Table struct {
+	ClusterName  types.String `tfsdk:"cluster_name"`
+	DatabaseName types.String `tfsdk:"database_name"`
+	Name         types.String `tfsdk:"name"`
+	Columns      []Column     `tfsdk:"columns"`
+	Engine       types.String `tfsdk:"engine"`
+	PartitionBy  types.String `tfsdk:"partition_by"`
+	PrimaryKey   types.String `tfsdk:"primary_key"`
+	OrderBy      types.String `tfsdk:"order_by"`
+	TTL          types.String `tfsdk:"ttl"`
+	Settings     types.Map    `tfsdk:"settings"`
+	Comment      types.String `tfsdk:"comment"`
+}
+
+type Column struct {
+	Name         types.String `tfsdk:"name"`
+	PreviousName types.String `tfsdk:"previous_name"`
+	Type         types.String `tfsdk:"type"`
+	Default      types.String `tfsdk:"default"`
+	Codec        types.String `tfsdk:"codec"`
+	Comment      types.String `tfsdk:"comment"`
+}
@@ -0,0 +1,103 @@
+package table
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+// sortingKeyElements returns the expressions the sorting key is made of, like `id` and `toDate(ts)` for
+// `(id, toDate(ts))`. String literals and quoted identifiers are kept as they are.
+func sortingKeyElements(orderBy string) []string {
+	s := strings.TrimSpace(orderBy)
+	if strings.HasPrefix(s, "(") && resourceutil.ClosingParenthesis(s) == len(s)-1 {
+		s = s[1 : len(s)-1]
+	}
+
+	elements := make([]string, 0)
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = resourceutil.ClosingQuote(s, i) - 1
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				elements = append(elements, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		elements = append(elements, last)
+	}
+
+	return elements
+}
+
+// sortingKeyExtended returns true if the sorting key after is the one before extended with columns in added.
+// ClickHouse can only change the sorting key of a table that way, adding the columns in the same query.
+func sortingKeyExtended(before string, after string, added []string) bool {
+	beforeElements := sortingKeyElements(before)
+	afterElements := sortingKeyElements(after)
+	if len(afterElements) <= len(beforeElements) {
+		return false
+	}
+
+	for i, e := range beforeElements {
+		if afterElements[i] != e {
+			return false
+		}
+	}
+
+	isAdded := make(map[string]bool, len(added))
+	for _, name := range added {
+		isAdded[name] = true
+		isAdded["`"+name+"`"] = true
+	}
+	for _, e := range afterElements[len(beforeElements):] {
+		if !isAdded[e] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// orderByRequiresReplace recreates the table unless the sorting key is only extended with columns added at the same
+// time, which is the only change ClickHouse supports.
+func orderByRequiresReplace(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	var planned, current []Column
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("columns"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("columns"), &current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing := make(map[string]bool, len(current))
+	for _, c := range current {
+		existing[c.Name.ValueString()] = true
+	}
+	added := make([]string, 0)
+	for _, c := range planned {
+		if !c.Name.IsUnknown() && !existing[c.Name.ValueString()] {
+			added = append(added, c.Name.ValueString())
+		}
+	}
+
+	resp.RequiresReplace = !sortingKeyExtended(req.StateValue.ValueString(), req.PlanValue.ValueString(), added)
+}
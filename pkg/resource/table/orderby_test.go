@@ -0,0 +1,99 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_sortingKeyElements(t *testing.T) {
+	tests := []struct {
+		name    string
+		orderBy string
+		want    []string
+	}{
+		{
+			name:    "Single column",
+			orderBy: "id",
+			want:    []string{"id"},
+		},
+		{
+			name:    "Tuple",
+			orderBy: "(id, toDate(ts))",
+			want:    []string{"id", "toDate(ts)"},
+		},
+		{
+			name:    "Empty tuple",
+			orderBy: "()",
+			want:    []string{},
+		},
+		{
+			name:    "Commas in literals and function calls",
+			orderBy: "(`a,b`, splitByChar(',', s))",
+			want:    []string{"`a,b`", "splitByChar(',', s)"},
+		},
+		{
+			name:    "Parentheses that are not around the whole expression",
+			orderBy: "(a + 1) * 2",
+			want:    []string{"(a + 1) * 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sortingKeyElements(tt.orderBy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortingKeyElements() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sortingKeyExtended(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		added  []string
+		want   bool
+	}{
+		{
+			name:   "Extended with added column",
+			before: "id",
+			after:  "(id, v)",
+			added:  []string{"v"},
+			want:   true,
+		},
+		{
+			name:   "Extended with quoted added column",
+			before: "(id, ts)",
+			after:  "(id, ts, `my col`)",
+			added:  []string{"my col"},
+			want:   true,
+		},
+		{
+			name:   "Extended with existing column",
+			before: "id",
+			after:  "(id, ts)",
+			added:  []string{"v"},
+			want:   false,
+		},
+		{
+			name:   "Column removed",
+			before: "(id, ts)",
+			after:  "id",
+			want:   false,
+		},
+		{
+			name:   "Columns reordered",
+			before: "(id, ts)",
+			after:  "(ts, id, v)",
+			added:  []string{"v"},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sortingKeyExtended(tt.before, tt.after, tt.added); got != tt.want {
+				t.Errorf("sortingKeyExtended() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
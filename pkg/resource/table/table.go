@@ -0,0 +1,552 @@
+package table
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
+)
+
+//go:embed table.md
+var tableResourceDescription string
+
+var (
+	_ resource.Resource                   = &Resource{}
+	_ resource.ResourceWithConfigure      = &Resource{}
+	_ resource.ResourceWithImportState    = &Resource{}
+	_ resource.ResourceWithValidateConfig = &Resource{}
+	_ resource.ResourceWithModifyPlan     = &Resource{}
+)
+
+// appliedKey is the private state key holding the table as read from ClickHouse after the last apply.
+const appliedKey = "applied"
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client dbops.Client
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_table"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	expressionAttribute := func(description string, planModifiers ...planmodifier.String) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:      true,
+			Description:   description,
+			PlanModifiers: planModifiers,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the cluster to create the table into. If omitted, the table will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster or a database using the Replicated engine.\nShould be set when hitting a cluster with more than one replica.\nWhen set, every replica is checked on refresh and the table is planned for creation again if it is missing on any of them. Replicas where it is defined differently than on the others are reported in a warning.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the database the table belongs to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the table. Renaming the table is done in place, keeping its data.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"columns": schema.ListNestedAttribute{
+				Required:    true,
+				Description: "Columns of the table, in order. Columns can be added, renamed, removed, reordered and changed without recreating the table. Removing a column drops its data, and a warning is reported when planning it.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Name of the column. When renaming a column, set `previous_name` to keep its data, otherwise the column is dropped and a new one is added.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"previous_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "Name the column had before being renamed. When the table has a column called `previous_name` and none called `name`, the column is renamed in place, keeping its data. It can be removed once the rename is applied.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "Data type of the column, for example `UInt64` or `LowCardinality(String)`.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"default": schema.StringAttribute{
+							Optional:    true,
+							Description: "Expression computing the value of the column when it is not inserted, for example `now()`.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"codec": schema.StringAttribute{
+							Optional:    true,
+							Description: "Compression codecs of the column, without the surrounding `CODEC()`, for example `Delta, ZSTD(1)`.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"comment": schema.StringAttribute{
+							Optional:    true,
+							Description: "Comment associated with the column",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"engine": schema.StringAttribute{
+				Required:    true,
+				Description: "Engine of the table, with its parameters, for example `MergeTree` or `ReplicatedMergeTree('/clickhouse/tables/{shard}/{database}/{table}', '{replica}')`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"partition_by": expressionAttribute(
+				"Partitioning key expression, for example `toYYYYMM(date)`. Changing it recreates the table.",
+				stringplanmodifier.RequiresReplace(),
+			),
+			"primary_key": expressionAttribute(
+				"Primary key expression, when it differs from the sorting key. It must be a prefix of `order_by`. Changing it recreates the table.",
+				stringplanmodifier.RequiresReplace(),
+			),
+			"order_by": expressionAttribute(
+				"Sorting key expression, for example `(id, toDate(ts))`. Extending it with columns added at the same time is done in place, any other change recreates the table.",
+				stringplanmodifier.RequiresReplaceIf(
+					orderByRequiresReplace,
+					"Recreates the table unless the sorting key is only extended with new columns.",
+					"Recreates the table unless the sorting key is only extended with new columns.",
+				),
+			),
+			"ttl": expressionAttribute(
+				"TTL expression of the table, for example `date + INTERVAL 1 MONTH DELETE`.",
+			),
+			"settings": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Settings of the table, by name, for example `index_granularity`. Only the settings listed here are managed.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comment associated with the table",
+				Validators: []validator.String{
+					// If user specifies the comment field, it can't be the empty string otherwise we get an error from terraform
+					// due to the difference between null and empty string. User can always set this field to null or leave it out completely.
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+		MarkdownDescription: tableResourceDescription,
+	}
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(dbops.Client)
+}
+
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config Table
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i, c := range config.Columns {
+		if c.Name.IsUnknown() || c.Name.IsNull() {
+			continue
+		}
+
+		if seen[c.Name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("columns").AtListIndex(i).AtName("name"),
+				"Duplicated Column",
+				fmt.Sprintf("More than one column is called %q, columns must have different names", c.Name.ValueString()),
+			)
+		}
+		seen[c.Name.ValueString()] = true
+	}
+
+	for i, c := range config.Columns {
+		if c.PreviousName.IsUnknown() || c.PreviousName.IsNull() {
+			continue
+		}
+
+		if seen[c.PreviousName.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("columns").AtListIndex(i).AtName("previous_name"),
+				"Invalid Previous Name",
+				fmt.Sprintf("The previous name %q of a column must not be the name of a column", c.PreviousName.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || len(resp.RequiresReplace) > 0 {
+		// Nothing is dropped in place when creating, destroying or recreating the table.
+		return
+	}
+
+	var plan, state Table
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, c := range plan.Columns {
+		if c.Name.IsUnknown() || c.PreviousName.IsUnknown() {
+			return
+		}
+	}
+
+	dropped := droppedColumns(state.Columns, plan.Columns)
+	if len(dropped) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Columns Will Be Dropped",
+			fmt.Sprintf("Columns %s of table %q.%q will be dropped along with their data. To rename a column instead, set its previous_name.", strings.Join(dropped, ", "), state.DatabaseName.ValueString(), state.Name.ValueString()),
+		)
+	}
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
+	var plan Table
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := toDBOps(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := r.client.CreateTable(ctx, desired, plan.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Table",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	if table == nil {
+		resp.Diagnostics.AddError(
+			"Error Creating ClickHouse Table",
+			"The table was not found after being created",
+		)
+		return
+	}
+
+	// The table is kept as configured, ClickHouse stores its expressions reformatted.
+	resp.Diagnostics.Append(resourceutil.SetApplied(ctx, resp.Private, appliedKey, table)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
+	var state Table
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := r.client.GetTable(ctx, state.DatabaseName.ValueString(), state.Name.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading ClickHouse Table",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if table != nil && !state.ClusterName.IsNull() {
+		drift, err := r.client.FindTableReplicaDrift(ctx, table.Database, table.Name, state.ClusterName.ValueString())
+		if resourceutil.ReportReplicaDrift(ctx, resp, "Error Reading ClickHouse Table", fmt.Sprintf("Table %q.%q", table.Database, table.Name), state.ClusterName.ValueString(), drift, err) {
+			return
+		}
+	}
+
+	if table == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	applied, diags := resourceutil.GetApplied[dbops.Table](ctx, req.Private, appliedKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setFromDBOps(ctx, &state, table, applied)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationUpdate)
+
+	var plan, state Table
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := toDBOps(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	current, diags := toDBOps(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, err := r.client.UpdateTable(ctx, current, desired, plan.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating ClickHouse Table",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
+	if table == nil {
+		resp.Diagnostics.AddError(
+			"Error Updating ClickHouse Table",
+			"The table was not found after being updated",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resourceutil.SetApplied(ctx, resp.Private, appliedKey, table)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
+	var state Table
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteTable(ctx, state.DatabaseName.ValueString(), state.Name.ValueString(), state.ClusterName.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting ClickHouse Table",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationImport)
+
+	// req.ID can either be in the form <cluster name>:<database name>.<table name> or just <database name>.<table name>
+
+	// Check if cluster name is specified
+	ref := req.ID
+	var clusterName *string
+	if strings.Contains(req.ID, ":") {
+		clusterName = &strings.Split(req.ID, ":")[0]
+		ref = strings.Split(req.ID, ":")[1]
+	}
+
+	database, name, ok := strings.Cut(ref, ".")
+	if !ok || database == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an ID in the form [<cluster name>:]<database name>.<table name>, got %q", req.ID),
+		)
+		return
+	}
+
+	table, err := r.client.GetTable(ctx, database, name, clusterName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Cannot find table",
+			fmt.Sprintf("%+v\n", err),
+		)
+		return
+	}
+
+	if table == nil {
+		resp.Diagnostics.AddError(
+			"Cannot find table",
+			fmt.Sprintf("No table called %q was found in database %q", name, database),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("database_name"), table.Database)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), table.Name)...)
+
+	if clusterName != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_name"), clusterName)...)
+	}
+}
+
+func toDBOps(ctx context.Context, model Table) (dbops.Table, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var settings map[string]string
+	if !model.Settings.IsNull() {
+		diags.Append(model.Settings.ElementsAs(ctx, &settings, false)...)
+	}
+
+	return dbops.Table{
+		Database:    model.DatabaseName.ValueString(),
+		Name:        model.Name.ValueString(),
+		Columns:     columnsToDBOps(model.Columns),
+		Engine:      model.Engine.ValueString(),
+		PartitionBy: model.PartitionBy.ValueStringPointer(),
+		PrimaryKey:  model.PrimaryKey.ValueStringPointer(),
+		OrderBy:     model.OrderBy.ValueStringPointer(),
+		TTL:         model.TTL.ValueStringPointer(),
+		Settings:    settings,
+		Comment:     model.Comment.ValueString(),
+	}, diags
+}
+
+// setFromDBOps updates model with the table read from ClickHouse. The engine and the expressions are only replaced
+// when they changed since the last apply, as ClickHouse reformats them. applied is nil when unknown, like after an
+// import, in which case the values read from ClickHouse are used.
+func setFromDBOps(ctx context.Context, model *Table, table *dbops.Table, applied *dbops.Table) diag.Diagnostics {
+	if applied == nil {
+		applied = &dbops.Table{}
+	}
+
+	model.Columns = columnsFromDBOps(table.Columns, model.Columns, applied.Columns)
+	model.Engine = changedValue(model.Engine, &table.Engine, &applied.Engine)
+	model.PartitionBy = changedValue(model.PartitionBy, table.PartitionBy, applied.PartitionBy)
+	model.PrimaryKey = changedValue(model.PrimaryKey, table.PrimaryKey, applied.PrimaryKey)
+	model.OrderBy = changedValue(model.OrderBy, table.OrderBy, applied.OrderBy)
+	model.TTL = changedValue(model.TTL, table.TTL, applied.TTL)
+
+	if table.Comment != "" {
+		model.Comment = types.StringValue(table.Comment)
+	} else {
+		model.Comment = types.StringNull()
+	}
+
+	if model.Settings.IsNull() {
+		return nil
+	}
+
+	// Only the settings in the configuration are checked, as ClickHouse adds some, like index_granularity, to every
+	// table.
+	var diags diag.Diagnostics
+	settings := make(map[string]string)
+	diags.Append(model.Settings.ElementsAs(ctx, &settings, false)...)
+	for name := range settings {
+		value, ok := table.Settings[name]
+		if !ok {
+			delete(settings, name)
+			continue
+		}
+		if appliedValue, ok := applied.Settings[name]; !ok || appliedValue != value {
+			settings[name] = value
+		}
+	}
+
+	synced, d := types.MapValueFrom(ctx, types.StringType, settings)
+	diags.Append(d...)
+	model.Settings = synced
+
+	return diags
+}
@@ -0,0 +1,170 @@
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/nilcompare"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/resourcebuilder"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/testutils/runner"
+)
+
+const (
+	resourceType = "clickhousedbops_table"
+	resourceName = "foo"
+)
+
+func TestTable_acceptance(t *testing.T) {
+	clusterName := "cluster1"
+
+	checkNotExistsFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]string) (bool, error) {
+		database := attrs["database_name"]
+		name := attrs["name"]
+		if database == "" || name == "" {
+			return false, fmt.Errorf("database_name or name attribute was not set")
+		}
+		table, err := dbopsClient.GetTable(ctx, database, name, clusterName)
+		return table != nil, err
+	}
+
+	checkAttributesFunc := func(ctx context.Context, dbopsClient dbops.Client, clusterName *string, attrs map[string]interface{}) error {
+		database := attrs["database_name"]
+		name := attrs["name"]
+		if database == nil || name == nil {
+			return fmt.Errorf("database_name or name was nil")
+		}
+
+		table, err := dbopsClient.GetTable(ctx, database.(string), name.(string), clusterName)
+		if err != nil {
+			return err
+		}
+
+		if table == nil {
+			return fmt.Errorf("table %q.%q was not found", database, name)
+		}
+
+		// Check state fields are aligned with the table we retrieved from CH.
+		columns, ok := attrs["columns"].([]interface{})
+		if !ok || len(columns) != len(table.Columns) {
+			return fmt.Errorf("expected %d columns, state has %v", len(table.Columns), attrs["columns"])
+		}
+		for i, c := range columns {
+			if c.(map[string]interface{})["name"] != table.Columns[i].Name {
+				return fmt.Errorf("expected column %d to be %q, was %v", i, table.Columns[i].Name, c.(map[string]interface{})["name"])
+			}
+		}
+
+		if attrs["engine"].(string) != table.Engine {
+			return fmt.Errorf("expected engine to be %q, was %q", table.Engine, attrs["engine"].(string))
+		}
+
+		if !nilcompare.NilCompare(table.OrderBy, attrs["order_by"]) {
+			return fmt.Errorf("wrong value for order_by attribute")
+		}
+
+		var comment *string
+		if table.Comment != "" {
+			comment = &table.Comment
+		}
+		if !nilcompare.NilCompare(comment, attrs["comment"]) {
+			return fmt.Errorf("wrong value for comment attribute")
+		}
+
+		if !nilcompare.NilCompare(clusterName, attrs["cluster_name"]) {
+			return fmt.Errorf("wrong value for cluster_name attribute")
+		}
+
+		return nil
+	}
+
+	// Elements of a list must have the same type, unset attributes are null.
+	column := func(name string, columnType string, defaultExpression cty.Value, codec cty.Value, comment cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"name":    cty.StringVal(name),
+			"type":    cty.StringVal(columnType),
+			"default": defaultExpression,
+			"codec":   codec,
+			"comment": comment,
+		})
+	}
+	null := cty.NullVal(cty.String)
+
+	columns := []cty.Value{
+		column("id", "UInt64", null, null, null),
+		column("ts", "DateTime", cty.StringVal("now()"), cty.StringVal("ZSTD(1)"), null),
+		column("message", "String", null, null, cty.StringVal("Raw log line")),
+	}
+
+	buildResource := func(clusterName *string) string {
+		table := resourcebuilder.New(resourceType, resourceName).
+			WithStringAttribute("database_name", "default").
+			WithStringAttribute("name", "tbl"+acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)).
+			WithListAttribute("columns", columns).
+			WithStringAttribute("engine", "MergeTree").
+			WithStringAttribute("partition_by", "toYYYYMM(ts)").
+			WithStringAttribute("order_by", "id").
+			WithStringAttribute("ttl", "ts + toIntervalDay(7)").
+			WithMapAttribute("settings", map[string]cty.Value{
+				"index_granularity": cty.StringVal("8192"),
+			}).
+			WithStringAttribute("comment", "Application events")
+
+		if clusterName != nil {
+			table = table.WithStringAttribute("cluster_name", *clusterName)
+		}
+
+		return table.Build()
+	}
+
+	tests := []runner.TestCase{
+		{
+			Name:                "Create Table using Native protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "native",
+			Resource:            buildResource(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Table using HTTP protocol on a single replica",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-single.xml"},
+			Protocol:            "http",
+			Resource:            buildResource(nil),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Table using Native protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "native",
+			Resource:            buildResource(&clusterName),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+		{
+			Name:                "Create Table using HTTP protocol on a cluster using localfile storage",
+			ChEnv:               map[string]string{"CONFIGFILE": "config-localfile.xml"},
+			ClusterName:         &clusterName,
+			Protocol:            "http",
+			Resource:            buildResource(&clusterName),
+			ResourceName:        resourceName,
+			ResourceAddress:     fmt.Sprintf("%s.%s", resourceType, resourceName),
+			CheckNotExistsFunc:  checkNotExistsFunc,
+			CheckAttributesFunc: checkAttributesFunc,
+		},
+	}
+
+	runner.RunTests(t, tests)
+}
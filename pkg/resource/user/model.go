@@ -10,4 +10,5 @@ type User struct {
 	Name                      types.String `tfsdk:"name"`
 	PasswordSha256Hash        types.String `tfsdk:"password_sha256_hash_wo"`
 	PasswordSha256HashVersion types.Int32  `tfsdk:"password_sha256_hash_wo_version"`
+	ValidUntil                types.String `tfsdk:"valid_until"`
 }
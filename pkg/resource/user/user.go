@@ -18,7 +18,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/clickhouseclient"
 	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/resourceutil"
 )
 
 //go:embed user.md
@@ -47,7 +49,7 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 		Attributes: map[string]schema.Attribute{
 			"cluster_name": schema.StringAttribute{
 				Optional:    true,
-				Description: "Name of the cluster to create the resource into. If omitted, resource will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\nWhen using a self hosted ClickHouse instance, this field should only be set when there is more than one replica and you are not using 'replicated' storage for user_directory.\n",
+				Description: "Name of the cluster to create the resource into. If omitted, resource will be created on the replica hit by the query.\nThis field must be left null when using a ClickHouse Cloud cluster.\nWhen using a self hosted ClickHouse instance, this field should only be set when there is more than one replica and you are not using 'replicated' storage for user_directory.\nWhen set, every replica is checked on refresh and the resource is planned for creation again if it is missing on any of them. Replicas where it is defined differently than on the others are reported in a warning.\n",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -78,18 +80,46 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 					int32planmodifier.RequiresReplace(),
 				},
 			},
+			"valid_until": schema.StringAttribute{
+				Optional:    true,
+				Description: "Expiration date of the user credentials, for example '2030-01-01 00:00:00' or '2030-01-01 00:00:00 UTC'. If omitted, the credentials never expire. Requires ClickHouse 23.9 or later.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 		},
 		MarkdownDescription: userResourceDescription,
 	}
 }
 
 func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationPlan)
+
 	if req.Plan.Raw.IsNull() {
 		// If the entire plan is null, the resource is planned for destruction.
 		return
 	}
 
 	if r.client != nil {
+		var config User
+		diags := req.Config.Get(ctx, &config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !config.ValidUntil.IsNull() {
+			err := r.client.CheckFeature(dbops.FeatureUserValidUntil)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("valid_until"),
+					"Unsupported ClickHouse version",
+					err.Error(),
+				)
+				return
+			}
+		}
+
 		isReplicatedStorage, err := r.client.IsReplicatedStorage(ctx)
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -100,13 +130,6 @@ func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReques
 		}
 
 		if isReplicatedStorage {
-			var config User
-			diags := req.Config.Get(ctx, &config)
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
 			// User cannot specify 'cluster_name' or apply will fail.
 			if !config.ClusterName.IsNull() {
 				resp.Diagnostics.AddWarning(
@@ -127,6 +150,8 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, _
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationCreate)
+
 	var plan User
 	var config User
 	diags := req.Plan.Get(ctx, &plan)
@@ -145,6 +170,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	user := dbops.User{
 		Name:               plan.Name.ValueString(),
 		PasswordSha256Hash: config.PasswordSha256Hash.ValueString(),
+		ValidUntil:         plan.ValidUntil.ValueStringPointer(),
 	}
 
 	createdUser, err := r.client.CreateUser(ctx, user, plan.ClusterName.ValueStringPointer())
@@ -156,12 +182,18 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	state := User{
 		ClusterName:               plan.ClusterName,
 		ID:                        types.StringValue(createdUser.ID),
 		Name:                      types.StringValue(createdUser.Name),
 		PasswordSha256Hash:        types.StringValue(user.PasswordSha256Hash),
 		PasswordSha256HashVersion: plan.PasswordSha256HashVersion,
+		ValidUntil:                plan.ValidUntil,
 	}
 
 	diags = resp.State.Set(ctx, state)
@@ -172,6 +204,8 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 }
 
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationRead)
+
 	var state User
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -188,8 +222,16 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	if user != nil && !state.ClusterName.IsNull() {
+		drift, err := r.client.FindReplicaDrift(ctx, dbops.UsersTable, user.Name, state.ClusterName.ValueString())
+		if resourceutil.ReportReplicaDrift(ctx, resp, "Error Reading ClickHouse User", fmt.Sprintf("User %q", user.Name), state.ClusterName.ValueString(), drift, err) {
+			return
+		}
+	}
+
 	if user != nil {
 		state.Name = types.StringValue(user.Name)
+		state.ValidUntil = readValidUntil(state.ValidUntil, user)
 
 		diags = resp.State.Set(ctx, &state)
 		resp.Diagnostics.Append(diags...)
@@ -199,6 +241,8 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationUpdate)
+
 	var plan, state User
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -212,9 +256,20 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	// Only send the expiration date when it changed, clearing it means the credentials never expire.
+	var validUntil *string
+	if !plan.ValidUntil.Equal(state.ValidUntil) {
+		validUntil = plan.ValidUntil.ValueStringPointer()
+		if validUntil == nil {
+			infinity := "infinity"
+			validUntil = &infinity
+		}
+	}
+
 	user, err := r.client.UpdateUser(ctx, dbops.User{
-		ID:   state.ID.ValueString(),
-		Name: plan.Name.ValueString(),
+		ID:         state.ID.ValueString(),
+		Name:       plan.Name.ValueString(),
+		ValidUntil: validUntil,
 	}, plan.ClusterName.ValueStringPointer())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -224,12 +279,20 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	if r.client.DryRun() {
+		resp.Diagnostics.Append(resourceutil.SetPlannedState(req.Plan, &resp.State)...)
+		return
+	}
+
 	state.Name = types.StringValue(user.Name)
+	state.ValidUntil = plan.ValidUntil
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationDelete)
+
 	var state User
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -248,6 +311,8 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 }
 
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = resourceutil.WithResourceQueryTags(ctx, r, clickhouseclient.OperationImport)
+
 	// req.ID can either be in the form <cluster name>:<user ref> or just <user ref>
 	// user ref can either be the name or the UUID of the user.
 
@@ -0,0 +1,62 @@
+package user
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+)
+
+const dateTimeLayout = "2006-01-02 15:04:05"
+
+// readValidUntil returns the valid_until value to keep in the state given the user read from ClickHouse.
+// The current value is kept when it is the same date written differently, like with an explicit time zone.
+func readValidUntil(current types.String, user *dbops.User) types.String {
+	if user.ValidUntil == nil {
+		return types.StringNull()
+	}
+
+	if current.IsNull() || current.IsUnknown() {
+		return types.StringValue(*user.ValidUntil)
+	}
+
+	value := strings.TrimSpace(current.ValueString())
+	if value == *user.ValidUntil || value+" 00:00:00" == *user.ValidUntil {
+		return current
+	}
+
+	// Dates with a time zone, like '2030-01-01 00:00:00 UTC', are compared in UTC.
+	if user.ValidUntilUTC != nil {
+		if t, ok := parseWithTimeZone(value); ok && t.UTC().Format(dateTimeLayout) == *user.ValidUntilUTC {
+			return current
+		}
+	}
+
+	return types.StringValue(*user.ValidUntil)
+}
+
+// parseWithTimeZone parses dates like '2030-01-01 00:00:00 Europe/Berlin' or '2030-01-01T00:00:00Z'.
+func parseWithTimeZone(value string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+
+	idx := strings.LastIndex(value, " ")
+	if idx < 0 {
+		return time.Time{}, false
+	}
+
+	location, err := time.LoadLocation(value[idx+1:])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	t, err := time.ParseInLocation(dateTimeLayout, value[:idx], location)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
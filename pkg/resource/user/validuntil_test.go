@@ -0,0 +1,71 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/ClickHouse/terraform-provider-clickhousedbops/internal/dbops"
+)
+
+func Test_readValidUntil(t *testing.T) {
+	serverTime := "2030-01-01 01:00:00"
+	utcTime := "2030-01-01 00:00:00"
+
+	tests := []struct {
+		name    string
+		current types.String
+		user    dbops.User
+		want    types.String
+	}{
+		{
+			name:    "No expiration",
+			current: types.StringNull(),
+			user:    dbops.User{},
+			want:    types.StringNull(),
+		},
+		{
+			name:    "Expiration removed outside of terraform",
+			current: types.StringValue(serverTime),
+			user:    dbops.User{},
+			want:    types.StringNull(),
+		},
+		{
+			name:    "Expiration added outside of terraform",
+			current: types.StringNull(),
+			user:    dbops.User{ValidUntil: &serverTime, ValidUntilUTC: &utcTime},
+			want:    types.StringValue(serverTime),
+		},
+		{
+			name:    "Same date in the server time zone",
+			current: types.StringValue(serverTime),
+			user:    dbops.User{ValidUntil: &serverTime, ValidUntilUTC: &utcTime},
+			want:    types.StringValue(serverTime),
+		},
+		{
+			name:    "Same date in UTC",
+			current: types.StringValue("2030-01-01 00:00:00 UTC"),
+			user:    dbops.User{ValidUntil: &serverTime, ValidUntilUTC: &utcTime},
+			want:    types.StringValue("2030-01-01 00:00:00 UTC"),
+		},
+		{
+			name:    "Same date in RFC 3339",
+			current: types.StringValue("2030-01-01T01:00:00+01:00"),
+			user:    dbops.User{ValidUntil: &serverTime, ValidUntilUTC: &utcTime},
+			want:    types.StringValue("2030-01-01T01:00:00+01:00"),
+		},
+		{
+			name:    "Date changed outside of terraform",
+			current: types.StringValue("2029-01-01 00:00:00 UTC"),
+			user:    dbops.User{ValidUntil: &serverTime, ValidUntilUTC: &utcTime},
+			want:    types.StringValue(serverTime),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readValidUntil(tt.current, &tt.user); !got.Equal(tt.want) {
+				t.Errorf("readValidUntil() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}